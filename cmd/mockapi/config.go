@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/anggaaryas/go-mockapi"
+)
+
+type config struct {
+	addr         string
+	grpcAddr     string
+	baseURL      string
+	dbFile       string
+	dataSource   string
+	router       string
+	style        string
+	seedFile     string
+	routesFile   string
+	generate     int
+	generateSeed uint64
+}
+
+func envOrDefault(getenv func(string) string, key string, defaultValue string) string {
+	if value := getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// envUintOrDefault is envOrDefault for unsigned integer settings.
+func envUintOrDefault(getenv func(string) string, key string, defaultValue uint64) (uint64, error) {
+	value := getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q, expected a non-negative integer", key, value)
+	}
+	return n, nil
+}
+
+// parseConfig reads the server configuration from flags, falling back to
+// environment variables and then to the built-in defaults.
+func parseConfig(args []string, getenv func(string) string, output io.Writer) (config, error) {
+	var cfg config
+
+	generate, err := envUintOrDefault(getenv, "MOCKAPI_GENERATE", 0)
+	if err != nil {
+		return config{}, err
+	}
+	generateSeed, err := envUintOrDefault(getenv, "MOCKAPI_GENERATE_SEED", 1)
+	if err != nil {
+		return config{}, err
+	}
+
+	fs := flag.NewFlagSet("mockapi", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.StringVar(&cfg.addr, "addr", envOrDefault(getenv, "MOCKAPI_ADDR", ":8080"), "listen address (env MOCKAPI_ADDR)")
+	fs.StringVar(&cfg.grpcAddr, "grpc-addr", envOrDefault(getenv, "MOCKAPI_GRPC_ADDR", ""), "listen address of the gRPC BookService, disabled when empty (env MOCKAPI_GRPC_ADDR)")
+	fs.StringVar(&cfg.baseURL, "base-url", envOrDefault(getenv, "BASE_URL", ""), "base URL used for cover image URLs (env BASE_URL)")
+	fs.StringVar(&cfg.dbFile, "db", envOrDefault(getenv, "MOCKAPI_DB", "books.db"), "SQLite database file for the gorm datasource (env MOCKAPI_DB)")
+	fs.StringVar(&cfg.dataSource, "datasource", envOrDefault(getenv, "MOCKAPI_DATASOURCE", "gorm"), "datasource to use: gorm or memory (env MOCKAPI_DATASOURCE)")
+	fs.StringVar(&cfg.router, "router", envOrDefault(getenv, "MOCKAPI_ROUTER", "gin"), "router to use: gin or std (env MOCKAPI_ROUTER)")
+	fs.StringVar(&cfg.style, "style", envOrDefault(getenv, "MOCKAPI_STYLE", "plain"), "response style of the book routes: plain, jsonapi or hal (env MOCKAPI_STYLE)")
+	fs.StringVar(&cfg.routesFile, "routes", envOrDefault(getenv, "MOCKAPI_ROUTES", ""), "JSON or YAML file of extra mock routes (env MOCKAPI_ROUTES)")
+	fs.IntVar(&cfg.generate, "generate", int(generate), "number of synthetic books to start with instead of the built-in dataset (env MOCKAPI_GENERATE)")
+	fs.Uint64Var(&cfg.generateSeed, "generate-seed", generateSeed, "random seed for -generate, the same seed gives the same books (env MOCKAPI_GENERATE_SEED)")
+	fs.StringVar(&cfg.seedFile, "seed", envOrDefault(getenv, "MOCKAPI_SEED", ""), "JSON, YAML or CSV file of books to start with instead of the built-in dataset (env MOCKAPI_SEED)")
+
+	if err := fs.Parse(args); err != nil {
+		return config{}, err
+	}
+
+	switch cfg.dataSource {
+	case "gorm", "memory":
+	default:
+		return config{}, fmt.Errorf("unknown datasource %q, expected gorm or memory", cfg.dataSource)
+	}
+	switch cfg.router {
+	case "gin", "std":
+	default:
+		return config{}, fmt.Errorf("unknown router %q, expected gin or std", cfg.router)
+	}
+	if _, err := mockapi.ParseResponseStyle(cfg.style); err != nil {
+		return config{}, err
+	}
+
+	if cfg.generate < 0 {
+		return config{}, fmt.Errorf("invalid -generate %d, expected a non-negative number of books", cfg.generate)
+	}
+	if cfg.generate > 0 && cfg.seedFile != "" {
+		return config{}, fmt.Errorf("-generate and -seed cannot be used together")
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"io"
+	"testing"
+)
+
+func testEnv(env map[string]string) func(string) string {
+	return func(key string) string {
+		return env[key]
+	}
+}
+
+func TestParseConfig_Defaults(t *testing.T) {
+	cfg, err := parseConfig(nil, testEnv(nil), io.Discard)
+	if err != nil {
+		t.Fatalf("parseConfig failed: %v", err)
+	}
+
+	expected := config{addr: ":8080", dbFile: "books.db", dataSource: "gorm", router: "gin", style: "plain", generateSeed: 1}
+	if cfg != expected {
+		t.Errorf("Expected config %+v, got %+v", expected, cfg)
+	}
+}
+
+func TestParseConfig_FromEnv(t *testing.T) {
+	env := map[string]string{
+		"MOCKAPI_ADDR":          ":9090",
+		"MOCKAPI_GRPC_ADDR":     ":9091",
+		"BASE_URL":              "http://mock.local",
+		"MOCKAPI_DB":            "test.db",
+		"MOCKAPI_DATASOURCE":    "memory",
+		"MOCKAPI_ROUTER":        "std",
+		"MOCKAPI_STYLE":         "hal",
+		"MOCKAPI_SEED":          "books.yaml",
+		"MOCKAPI_ROUTES":        "routes.yaml",
+		"MOCKAPI_GENERATE_SEED": "7",
+	}
+
+	cfg, err := parseConfig(nil, testEnv(env), io.Discard)
+	if err != nil {
+		t.Fatalf("parseConfig failed: %v", err)
+	}
+
+	expected := config{addr: ":9090", grpcAddr: ":9091", baseURL: "http://mock.local", dbFile: "test.db", dataSource: "memory", router: "std", style: "hal", seedFile: "books.yaml", routesFile: "routes.yaml", generateSeed: 7}
+	if cfg != expected {
+		t.Errorf("Expected config %+v, got %+v", expected, cfg)
+	}
+}
+
+func TestParseConfig_FlagsOverrideEnv(t *testing.T) {
+	env := map[string]string{"MOCKAPI_ADDR": ":9090", "MOCKAPI_ROUTER": "std"}
+
+	cfg, err := parseConfig([]string{"-addr", ":7070", "-router", "gin"}, testEnv(env), io.Discard)
+	if err != nil {
+		t.Fatalf("parseConfig failed: %v", err)
+	}
+
+	if cfg.addr != ":7070" {
+		t.Errorf("Expected addr :7070, got %s", cfg.addr)
+	}
+	if cfg.router != "gin" {
+		t.Errorf("Expected router gin, got %s", cfg.router)
+	}
+}
+
+func TestParseConfig_UnknownDataSource(t *testing.T) {
+	_, err := parseConfig([]string{"-datasource", "redis"}, testEnv(nil), io.Discard)
+	if err == nil {
+		t.Error("Expected error for unknown datasource")
+	}
+}
+
+func TestParseConfig_UnknownRouter(t *testing.T) {
+	_, err := parseConfig([]string{"-router", "echo"}, testEnv(nil), io.Discard)
+	if err == nil {
+		t.Error("Expected error for unknown router")
+	}
+}
+
+func TestParseConfig_UnknownStyle(t *testing.T) {
+	_, err := parseConfig([]string{"-style", "siren"}, testEnv(nil), io.Discard)
+	if err == nil {
+		t.Error("Expected error for unknown style")
+	}
+}
+
+func TestParseConfig_Generate(t *testing.T) {
+	cfg, err := parseConfig([]string{"-generate", "100000"}, testEnv(map[string]string{"MOCKAPI_GENERATE_SEED": "9"}), io.Discard)
+	if err != nil {
+		t.Fatalf("parseConfig failed: %v", err)
+	}
+	if cfg.generate != 100000 || cfg.generateSeed != 9 {
+		t.Errorf("Expected 100000 books with seed 9, got %d with seed %d", cfg.generate, cfg.generateSeed)
+	}
+
+	invalid := []struct {
+		args []string
+		env  map[string]string
+	}{
+		{[]string{"-generate", "-1"}, nil},
+		{[]string{"-generate", "10", "-seed", "books.json"}, nil},
+		{nil, map[string]string{"MOCKAPI_GENERATE": "many"}},
+	}
+	for _, tt := range invalid {
+		if _, err := parseConfig(tt.args, testEnv(tt.env), io.Discard); err == nil {
+			t.Errorf("Expected error for args %v and env %v", tt.args, tt.env)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+module github.com/anggaaryas/go-mockapi/cmd/mockapi
+
+go 1.25.1
+
+require (
+	github.com/anggaaryas/go-mockapi v0.1.3
+	github.com/anggaaryas/go-mockapi/datasource/gorm v0.0.0
+	github.com/anggaaryas/go-mockapi/datasource/memory v0.0.0
+	github.com/anggaaryas/go-mockapi/router/ginrouter v0.0.0
+	github.com/anggaaryas/go-mockapi/router/graphql v0.0.0
+	github.com/anggaaryas/go-mockapi/router/grpcrouter v0.0.0
+	github.com/anggaaryas/go-mockapi/router/stdrouter v0.0.0
+	github.com/gin-gonic/gin v1.11.0
+	google.golang.org/grpc v1.82.1
+	gorm.io/driver/sqlite v1.6.0
+	gorm.io/gorm v1.31.1
+)
+
+require (
+	github.com/bytedance/gopkg v0.1.3 // indirect
+	github.com/bytedance/sonic v1.14.2 // indirect
+	github.com/bytedance/sonic/loader v0.4.0 // indirect
+	github.com/cloudwego/base64x v0.1.6 // indirect
+	github.com/gabriel-vasile/mimetype v1.4.11 // indirect
+	github.com/gin-contrib/sse v1.1.0 // indirect
+	github.com/go-playground/locales v0.14.1 // indirect
+	github.com/go-playground/universal-translator v0.18.1 // indirect
+	github.com/go-playground/validator/v10 v10.28.0 // indirect
+	github.com/goccy/go-json v0.10.5 // indirect
+	github.com/goccy/go-yaml v1.18.0 // indirect
+	github.com/graph-gophers/graphql-go v1.9.0 // indirect
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	github.com/json-iterator/go v1.1.12 // indirect
+	github.com/klauspost/cpuid/v2 v2.3.0 // indirect
+	github.com/leodido/go-urn v1.4.0 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/mattn/go-sqlite3 v1.14.32 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
+	github.com/pelletier/go-toml/v2 v2.2.4 // indirect
+	github.com/quic-go/qpack v0.5.1 // indirect
+	github.com/quic-go/quic-go v0.55.0 // indirect
+	github.com/twitchyliquid64/golang-asm v0.15.1 // indirect
+	github.com/ugorji/go/codec v1.3.1 // indirect
+	golang.org/x/arch v0.22.0 // indirect
+	golang.org/x/crypto v0.50.0 // indirect
+	golang.org/x/image v0.25.0 // indirect
+	golang.org/x/mod v0.34.0 // indirect
+	golang.org/x/net v0.53.0 // indirect
+	golang.org/x/sync v0.20.0 // indirect
+	golang.org/x/sys v0.43.0 // indirect
+	golang.org/x/text v0.36.0 // indirect
+	golang.org/x/tools v0.43.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260414002931-afd174a4e478 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace (
+	github.com/anggaaryas/go-mockapi => ../..
+	github.com/anggaaryas/go-mockapi/datasource/gorm => ../../datasource/gorm
+	github.com/anggaaryas/go-mockapi/datasource/memory => ../../datasource/memory
+	github.com/anggaaryas/go-mockapi/router/ginrouter => ../../router/ginrouter
+	github.com/anggaaryas/go-mockapi/router/graphql => ../../router/graphql
+	github.com/anggaaryas/go-mockapi/router/grpcrouter => ../../router/grpcrouter
+	github.com/anggaaryas/go-mockapi/router/stdrouter => ../../router/stdrouter
+)
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/anggaaryas/go-mockapi"
+	gormsql "github.com/anggaaryas/go-mockapi/datasource/gorm"
+	"github.com/anggaaryas/go-mockapi/datasource/memory"
+	"github.com/anggaaryas/go-mockapi/router/ginrouter"
+	"github.com/anggaaryas/go-mockapi/router/graphql"
+	"github.com/anggaaryas/go-mockapi/router/grpcrouter"
+	"github.com/anggaaryas/go-mockapi/router/stdrouter"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+func newDataSource(cfg config) (mockapi.DataSource, error) {
+	var seed []mockapi.Book
+	if cfg.seedFile != "" {
+		books, err := mockapi.LoadBooksFile(cfg.seedFile)
+		if err != nil {
+			return nil, err
+		}
+		seed = books
+	}
+	if cfg.generate > 0 {
+		seed = mockapi.GenerateBooks(cfg.generate, cfg.generateSeed)
+	}
+
+	if cfg.dataSource == "memory" {
+		if seed != nil {
+			return memory.CreateWithSeed(seed), nil
+		}
+		return memory.Create(), nil
+	}
+
+	db, err := gorm.Open(sqlite.Open(cfg.dbFile), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	if seed != nil {
+		return gormsql.CreateWithSeed(db, seed), nil
+	}
+	return gormsql.Create(db), nil
+}
+
+func newRouter(cfg config) (mockapi.Router, http.Handler, error) {
+	if cfg.router == "std" {
+		mux := http.NewServeMux()
+		router, err := stdrouter.CreateWithStyle(mux, mockapi.ResponseStyle(cfg.style))
+		return router, mux, err
+	}
+
+	r := gin.Default()
+	router, err := ginrouter.CreateWithStyle(r, mockapi.ResponseStyle(cfg.style))
+	return router, r, err
+}
+
+// setupRoutes registers the routes defined in cfg.routesFile, if any.
+func setupRoutes(cfg config, ds mockapi.DataSource, router mockapi.Router) error {
+	if cfg.routesFile == "" {
+		return nil
+	}
+	routeRouter, ok := router.(mockapi.MockRouteRouter)
+	if !ok {
+		return fmt.Errorf("router %T cannot serve defined routes", router)
+	}
+	definitions, err := mockapi.LoadRoutesFile(cfg.routesFile)
+	if err != nil {
+		return err
+	}
+	routes, err := mockapi.NewMockRoutes(definitions, mockapi.NewService(ds))
+	if err != nil {
+		return err
+	}
+	for _, route := range routes {
+		if err := routeRouter.SetupMockRoute(route); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setupAdmin registers the data admin endpoints when handler is a Gin engine,
+// as the net/http router has no admin routes.
+func setupAdmin(ds mockapi.DataSource, handler http.Handler) error {
+	engine, ok := handler.(*gin.Engine)
+	if !ok {
+		return nil
+	}
+	admin, err := ginrouter.NewAdmin(ds)
+	if err != nil {
+		return err
+	}
+	admin.SetupAdminRoute(engine)
+	return nil
+}
+
+// setupGraphQL serves the GraphQL endpoint over ds next to the REST routes.
+// It only serves the shared data, so requests in a session are rejected.
+func setupGraphQL(ds mockapi.DataSource, handler http.Handler) {
+	graphqlHandler := graphql.NewHandler(mockapi.NewService(ds))
+	switch h := handler.(type) {
+	case *gin.Engine:
+		h.Match([]string{http.MethodGet, http.MethodPost}, graphql.Path, ginrouter.SharedOnly, gin.WrapH(graphqlHandler))
+	case *http.ServeMux:
+		h.Handle("GET "+graphql.Path, graphqlHandler)
+		h.Handle("POST "+graphql.Path, graphqlHandler)
+	}
+}
+
+// newGRPCServer returns a gRPC server of the BookService over ds, listening
+// on cfg.grpcAddr, or nil when cfg.grpcAddr is empty. Sessions do not cover
+// gRPC, so calls that send the session header as metadata are rejected.
+func newGRPCServer(cfg config, ds mockapi.DataSource) (*grpc.Server, net.Listener, error) {
+	if cfg.grpcAddr == "" {
+		return nil, nil, nil
+	}
+	listener, err := net.Listen("tcp", cfg.grpcAddr)
+	if err != nil {
+		return nil, nil, err
+	}
+	server := grpc.NewServer(grpc.UnaryInterceptor(rejectSessions))
+	if err := grpcrouter.Create(server).SetupMockApiRoute(mockapi.NewService(ds)); err != nil {
+		listener.Close()
+		return nil, nil, err
+	}
+	return server, listener, nil
+}
+
+// rejectSessions fails gRPC calls that ask for a session, as they would
+// otherwise silently read the shared data.
+func rejectSessions(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if ids := metadata.ValueFromIncomingContext(ctx, ginrouter.DefaultSessionHeader); len(ids) > 0 {
+		return nil, status.Error(codes.InvalidArgument, "sessions are not available over gRPC")
+	}
+	return handler(ctx, req)
+}
+
+// setupSessions isolates the data of each session when handler is a Gin
+// engine, returning the handler that also accepts the session path prefix.
+// It must be called before the mock routes are registered.
+func setupSessions(ds mockapi.DataSource, handler http.Handler) (http.Handler, error) {
+	engine, ok := handler.(*gin.Engine)
+	if !ok {
+		return handler, nil
+	}
+	sessions, err := ginrouter.NewSessions(ds, ginrouter.SessionConfig{NewDataSource: memory.Create})
+	if err != nil {
+		return nil, err
+	}
+	engine.Use(sessions.Middleware())
+	sessions.SetupAdminRoute(engine)
+	return sessions.Handler(engine), nil
+}
+
+func run(ctx context.Context, cfg config) error {
+	if cfg.baseURL != "" {
+		os.Setenv("BASE_URL", cfg.baseURL)
+	}
+
+	ds, err := newDataSource(cfg)
+	if err != nil {
+		return err
+	}
+	router, handler, err := newRouter(cfg)
+	if err != nil {
+		return err
+	}
+	sessionHandler, err := setupSessions(ds, handler)
+	if err != nil {
+		return err
+	}
+	mockapi.Use(ds, router)
+	if err := setupRoutes(cfg, ds, router); err != nil {
+		return err
+	}
+	if err := setupAdmin(ds, handler); err != nil {
+		return err
+	}
+	setupGraphQL(ds, handler)
+	grpcServer, grpcListener, err := newGRPCServer(cfg, ds)
+	if err != nil {
+		return err
+	}
+	if grpcServer != nil {
+		defer grpcServer.GracefulStop()
+		go func() {
+			log.Printf("mockapi gRPC listening on %s", grpcListener.Addr())
+			if err := grpcServer.Serve(grpcListener); err != nil {
+				log.Printf("gRPC server stopped: %v", err)
+			}
+		}()
+	}
+
+	server := &http.Server{
+		Addr:    cfg.addr,
+		Handler: sessionHandler,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Printf("mockapi listening on %s (datasource=%s, router=%s)", cfg.addr, cfg.dataSource, cfg.router)
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Println("shutting down mockapi")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func main() {
+	cfg, err := parseConfig(os.Args[1:], os.Getenv, os.Stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, cfg); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anggaaryas/go-mockapi"
+	"github.com/anggaaryas/go-mockapi/router/ginrouter"
+	"github.com/anggaaryas/go-mockapi/router/graphql"
+	"github.com/anggaaryas/go-mockapi/router/grpcrouter"
+	"github.com/anggaaryas/go-mockapi/router/grpcrouter/bookpb"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewDataSourceAndRouter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, cfg := range []config{
+		{dataSource: "memory", router: "std"},
+		{dataSource: "gorm", router: "gin", dbFile: filepath.Join(t.TempDir(), "books.db")},
+	} {
+		ds, err := newDataSource(cfg)
+		if err != nil {
+			t.Fatalf("newDataSource(%s) failed: %v", cfg.dataSource, err)
+		}
+		router, handler, err := newRouter(cfg)
+		if err != nil {
+			t.Fatalf("newRouter(%s) failed: %v", cfg.router, err)
+		}
+		mockapi.Use(ds, router)
+
+		req, _ := http.NewRequest("GET", "/api/books/1", nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status code %d for %s/%s, got %d", http.StatusOK, cfg.dataSource, cfg.router, w.Code)
+		}
+	}
+
+	for _, name := range []string{"gin", "std"} {
+		if _, _, err := newRouter(config{router: name, style: "xml"}); err == nil {
+			t.Errorf("Expected error for an invalid style with %s", name)
+		}
+	}
+}
+
+func TestNewDataSource_Seed(t *testing.T) {
+	seedFile := filepath.Join(t.TempDir(), "books.csv")
+	if err := os.WriteFile(seedFile, []byte("title,author\nSeed Book,Seed Author\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	for _, cfg := range []config{
+		{dataSource: "memory", seedFile: seedFile},
+		{dataSource: "gorm", dbFile: filepath.Join(t.TempDir(), "books.db"), seedFile: seedFile},
+	} {
+		ds, err := newDataSource(cfg)
+		if err != nil {
+			t.Fatalf("newDataSource(%s) failed: %v", cfg.dataSource, err)
+		}
+		if err := ds.PopulateData(); err != nil {
+			t.Fatalf("PopulateData(%s) failed: %v", cfg.dataSource, err)
+		}
+		count, _ := ds.GetBooksCount(mockapi.BookQuery{})
+		if count != 1 {
+			t.Errorf("Expected 1 seeded book for %s, got %d", cfg.dataSource, count)
+		}
+	}
+
+	if _, err := newDataSource(config{dataSource: "memory", seedFile: "missing.json"}); err == nil {
+		t.Error("Expected error for missing seed file")
+	}
+}
+
+func TestNewDataSource_Generate(t *testing.T) {
+	ds, err := newDataSource(config{dataSource: "memory", generate: 500, generateSeed: 3})
+	if err != nil {
+		t.Fatalf("newDataSource failed: %v", err)
+	}
+	if err := ds.PopulateData(); err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+	if count, _ := ds.GetBooksCount(mockapi.BookQuery{}); count != 500 {
+		t.Errorf("Expected 500 generated books, got %d", count)
+	}
+	book, _ := ds.GetBookByID("1")
+	if book != mockapi.GenerateBooks(1, 3)[0] {
+		t.Errorf("Expected the first generated book for seed 3, got %+v", book)
+	}
+}
+
+func TestSetupRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	routesFile := filepath.Join(t.TempDir(), "routes.yaml")
+	routes := "routes:\n  - method: GET\n    path: /api/featured\n    body: '{{ (book \"1\").Title | json }}'\n"
+	if err := os.WriteFile(routesFile, []byte(routes), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	for _, cfg := range []config{
+		{dataSource: "memory", router: "std", routesFile: routesFile},
+		{dataSource: "memory", router: "gin", routesFile: routesFile},
+	} {
+		ds, _ := newDataSource(cfg)
+		router, handler, _ := newRouter(cfg)
+		mockapi.Use(ds, router)
+		if err := setupRoutes(cfg, ds, router); err != nil {
+			t.Fatalf("setupRoutes failed: %v", err)
+		}
+
+		req, _ := http.NewRequest("GET", "/api/featured", nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK || w.Body.String() != `"The Go Programming Language"` {
+			t.Errorf("Expected featured book for %s, got %d %s", cfg.router, w.Code, w.Body.String())
+		}
+	}
+
+	cfg := config{dataSource: "memory", router: "std", routesFile: "missing.yaml"}
+	ds, _ := newDataSource(cfg)
+	router, _, _ := newRouter(cfg)
+	if err := setupRoutes(cfg, ds, router); err == nil {
+		t.Error("Expected error for missing routes file")
+	}
+
+	cfg.routesFile = routesFile
+	if err := setupRoutes(cfg, ds, grpcrouter.Create(grpc.NewServer())); err == nil {
+		t.Error("Expected error for a router that cannot serve defined routes")
+	}
+}
+
+func TestSetupAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, cfg := range []config{
+		{dataSource: "memory", router: "gin"},
+		{dataSource: "gorm", router: "gin", dbFile: filepath.Join(t.TempDir(), "admin.db")},
+	} {
+		ds, err := newDataSource(cfg)
+		if err != nil {
+			t.Fatalf("newDataSource failed: %v", err)
+		}
+		router, handler, _ := newRouter(cfg)
+		mockapi.Use(ds, router)
+		if err := setupAdmin(ds, handler); err != nil {
+			t.Fatalf("setupAdmin failed: %v", err)
+		}
+
+		ds.DeleteBook("1")
+		req, _ := http.NewRequest("POST", mockapi.GetMockapiPath()+"/admin/reset", nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNoContent {
+			t.Errorf("Expected status code %d for %s, got %d", http.StatusNoContent, cfg.dataSource, w.Code)
+		}
+		if _, err := ds.GetBookByID("1"); err != nil {
+			t.Errorf("Expected book 1 to be reset for %s, got %v", cfg.dataSource, err)
+		}
+	}
+}
+
+func TestSetupGraphQL(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, cfg := range []config{
+		{dataSource: "memory", router: "gin"},
+		{dataSource: "memory", router: "std"},
+	} {
+		ds, _ := newDataSource(cfg)
+		router, handler, _ := newRouter(cfg)
+		mockapi.Use(ds, router)
+		setupGraphQL(ds, handler)
+
+		req, _ := http.NewRequest("POST", graphql.Path, strings.NewReader(`{"query": "{ book(id: \"1\") { id } }"}`))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK || w.Body.String() != `{"data":{"book":{"id":"1"}}}`+"\n" {
+			t.Errorf("Expected book 1 for %s, got %d %s", cfg.router, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestNewGRPCServer(t *testing.T) {
+	ds, _ := newDataSource(config{dataSource: "memory"})
+	if err := ds.PopulateData(); err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+
+	if server, _, err := newGRPCServer(config{}, ds); server != nil || err != nil {
+		t.Errorf("Expected no gRPC server without an address, got %v, %v", server, err)
+	}
+
+	server, listener, err := newGRPCServer(config{grpcAddr: "127.0.0.1:0"}, ds)
+	if err != nil {
+		t.Fatalf("newGRPCServer failed: %v", err)
+	}
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer conn.Close()
+	book, err := bookpb.NewBookServiceClient(conn).GetBook(context.Background(), &bookpb.GetBookRequest{Id: 1})
+	if err != nil || book.GetId() != 1 {
+		t.Errorf("Expected book 1, got %v, %v", book, err)
+	}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), ginrouter.DefaultSessionHeader, "ci-job-1")
+	if _, err := bookpb.NewBookServiceClient(conn).GetBook(ctx, &bookpb.GetBookRequest{Id: 1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a call in a session, got %v", err)
+	}
+}
+
+func TestSetupSessions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := config{dataSource: "gorm", router: "gin", dbFile: filepath.Join(t.TempDir(), "sessions.db")}
+	ds, err := newDataSource(cfg)
+	if err != nil {
+		t.Fatalf("newDataSource failed: %v", err)
+	}
+	router, handler, _ := newRouter(cfg)
+	sessionHandler, err := setupSessions(ds, handler)
+	if err != nil {
+		t.Fatalf("setupSessions failed: %v", err)
+	}
+	mockapi.Use(ds, router)
+
+	req, _ := http.NewRequest("DELETE", "/sessions/ci-job-1/api/books/1", nil)
+	w := httptest.NewRecorder()
+	sessionHandler.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status code %d, got %d", http.StatusNoContent, w.Code)
+	}
+
+	if _, err := ds.GetBookByID("1"); err != nil {
+		t.Errorf("Expected the shared book 1 to be kept, got %v", err)
+	}
+	req, _ = http.NewRequest("GET", "/api/books/1", nil)
+	req.Header.Set(ginrouter.DefaultSessionHeader, "ci-job-1")
+	w = httptest.NewRecorder()
+	sessionHandler.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected book 1 to be deleted in the session, got %d", w.Code)
+	}
+
+	setupGraphQL(ds, handler)
+	req, _ = http.NewRequest("POST", "/sessions/ci-job-1"+graphql.Path, strings.NewReader(`{"query": "{ book(id: \"1\") { id } }"}`))
+	w = httptest.NewRecorder()
+	sessionHandler.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected GraphQL to be rejected in a session, got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestRun_GracefulShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx, config{addr: "127.0.0.1:0", grpcAddr: "127.0.0.1:0", dataSource: "memory", router: "std"})
+	}()
+
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected clean shutdown, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected run to return after the context is cancelled")
+	}
+}
@@ -0,0 +1,77 @@
+package mockapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+)
+
+// BookCursor marks a position in a sorted book listing. It holds the sort
+// field value and ID of the book at that position, so pages stay stable when
+// books are inserted or deleted around it.
+type BookCursor struct {
+	Sort   string `json:"s"`
+	Order  string `json:"o"`
+	Value  string `json:"v,omitempty"`
+	ID     int    `json:"id"`
+	Before bool   `json:"b,omitempty"`
+}
+
+// NewBookCursor returns a cursor positioned at book for the given sorted query.
+func NewBookCursor(query BookQuery, book Book, before bool) BookCursor {
+	query = query.WithDefaults()
+	return BookCursor{
+		Sort:   query.Sort,
+		Order:  query.Order,
+		Value:  BookSortValue(book, query.Sort),
+		ID:     book.ID,
+		Before: before,
+	}
+}
+
+// BookSortValue returns the value of the given sort field of book as a string.
+func BookSortValue(book Book, field string) string {
+	switch field {
+	case "title":
+		return book.Title
+	case "author":
+		return book.Author
+	case "category":
+		return book.Category
+	}
+	return strconv.Itoa(book.ID)
+}
+
+// reversed returns the same position paging in the opposite direction.
+func (c BookCursor) reversed() BookCursor {
+	c.Before = !c.Before
+	return c
+}
+
+// Encode returns the cursor as an opaque URL-safe token.
+func (c BookCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeBookCursor parses a token returned by BookCursor.Encode.
+func DecodeBookCursor(token string) (BookCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return BookCursor{}, NewInvalidCursorError()
+	}
+	var cursor BookCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return BookCursor{}, NewInvalidCursorError()
+	}
+	if !IsBookSortField(cursor.Sort) || !IsSortOrder(cursor.Order) {
+		return BookCursor{}, NewInvalidCursorError()
+	}
+	return cursor, nil
+}
+
+// Matches reports whether the cursor was created for the sort of query.
+func (c BookCursor) Matches(query BookQuery) bool {
+	query = query.WithDefaults()
+	return c.Sort == query.Sort && c.Order == query.Order
+}
@@ -0,0 +1,44 @@
+package mockapi
+
+import "testing"
+
+func TestBookCursor_EncodeDecode(t *testing.T) {
+	book := Book{ID: 7, Title: "Refactoring"}
+	cursor := NewBookCursor(BookQuery{Sort: "title", Order: OrderDesc}, book, true)
+
+	decoded, err := DecodeBookCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("DecodeBookCursor failed: %v", err)
+	}
+	if decoded != cursor {
+		t.Errorf("Expected cursor %+v, got %+v", cursor, decoded)
+	}
+	if decoded.Value != "Refactoring" || decoded.ID != 7 || !decoded.Before {
+		t.Errorf("Unexpected cursor %+v", decoded)
+	}
+}
+
+func TestDecodeBookCursor_Invalid(t *testing.T) {
+	invalid := []string{
+		"!!!",
+		"bm90IGpzb24",
+		BookCursor{Sort: "price", Order: OrderAsc}.Encode(),
+		BookCursor{Sort: "id", Order: "up"}.Encode(),
+	}
+	for _, token := range invalid {
+		if _, err := DecodeBookCursor(token); err == nil {
+			t.Errorf("Expected error for token %s", token)
+		}
+	}
+}
+
+func TestBookCursor_Matches(t *testing.T) {
+	cursor := NewBookCursor(BookQuery{}, Book{ID: 1}, false)
+
+	if !cursor.Matches(BookQuery{Sort: "id", Order: OrderAsc}) {
+		t.Error("Expected cursor to match the default sort")
+	}
+	if cursor.Matches(BookQuery{Sort: "title"}) {
+		t.Error("Expected cursor not to match a different sort field")
+	}
+}
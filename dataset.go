@@ -0,0 +1,164 @@
+package mockapi
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// GetBaseURL returns the base URL used to build cover image URLs, taken from
+// the BASE_URL environment variable.
+func GetBaseURL() string {
+	baseURL := os.Getenv("BASE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+	return baseURL
+}
+
+// GetCoverURL returns the public URL of an embedded cover image.
+func GetCoverURL(filename string) string {
+	return fmt.Sprintf("%s%s%s", GetBaseURL(), GetMockapiStaticImagePath(), filename)
+}
+
+// GetPlaceholderURL returns the public URL of the placeholder cover of book id.
+func GetPlaceholderURL(id int) string {
+	return fmt.Sprintf("%s%s/%d", GetBaseURL(), GetMockapiPlaceholderPath(), id)
+}
+
+// BookCoverURL returns the cover URL of book. Books without a cover, or whose
+// cover names an embedded image that does not exist, get their placeholder
+// cover. Other URLs are returned as they are.
+func BookCoverURL(book Book) string {
+	cover := book.CoverURL
+	if i := strings.Index(cover, GetMockapiStaticImagePath()); i >= 0 {
+		cover = cover[i+len(GetMockapiStaticImagePath()):]
+	} else if strings.Contains(cover, "/") {
+		return book.CoverURL
+	}
+	if cover == "" || !fs.ValidPath(cover) {
+		return GetPlaceholderURL(book.ID)
+	}
+	if _, err := fs.Stat(GetStaticFS(), "image/"+cover); err != nil {
+		return GetPlaceholderURL(book.ID)
+	}
+	return GetCoverURL(cover)
+}
+
+// GetInitialBooks returns the built-in dataset of 50 programming books.
+func GetInitialBooks() []Book {
+	var books = []Book{
+		{ID: 1, Title: "The Go Programming Language", Author: "Alan A. A. Donovan", Category: "Programming", Desc: "A comprehensive guide to Go programming", CoverURL: GetCoverURL("go-programming-languange.jpg")},
+		{ID: 2, Title: "Clean Code", Author: "Robert C. Martin", Category: "Programming", Desc: "A handbook of agile software craftsmanship", CoverURL: GetCoverURL("clean-code.jpg")},
+		{ID: 3, Title: "Design Patterns", Author: "Erich Gamma", Category: "Programming", Desc: "Elements of reusable object-oriented software", CoverURL: GetCoverURL("design-pattern.jpg")},
+		{ID: 4, Title: "The Pragmatic Programmer", Author: "Andrew Hunt", Category: "Programming", Desc: "Your journey to mastery", CoverURL: GetCoverURL("the-pragmatic-programmer.jpg")},
+		{ID: 5, Title: "Introduction to Algorithms", Author: "Thomas H. Cormen", Category: "Computer Science", Desc: "A comprehensive introduction to algorithms", CoverURL: GetCoverURL("introduction-to-algorithms.jpg")},
+		{ID: 6, Title: "Code Complete", Author: "Steve McConnell", Category: "Programming", Desc: "A practical handbook of software construction", CoverURL: GetCoverURL("code-complete.jpg")},
+		{ID: 7, Title: "Refactoring", Author: "Martin Fowler", Category: "Programming", Desc: "Improving the design of existing code", CoverURL: GetCoverURL("refactoring.jpg")},
+		{ID: 8, Title: "Head First Design Patterns", Author: "Eric Freeman", Category: "Programming", Desc: "A brain-friendly guide to design patterns", CoverURL: GetCoverURL("head-first-design-pattern.jpg")},
+		{ID: 9, Title: "The Mythical Man-Month", Author: "Frederick P. Brooks Jr.", Category: "Software Engineering", Desc: "Essays on software engineering", CoverURL: GetCoverURL("the-mythical-man-month.jpg")},
+		{ID: 10, Title: "Cracking the Coding Interview", Author: "Gayle Laakmann McDowell", Category: "Programming", Desc: "189 programming questions and solutions", CoverURL: GetCoverURL("cracking-the-code-interview.jpg")},
+		{ID: 11, Title: "You Don't Know JS", Author: "Kyle Simpson", Category: "Programming", Desc: "Deep dive into JavaScript", CoverURL: GetCoverURL("you-dont-know-js.jpg")},
+		{ID: 12, Title: "Eloquent JavaScript", Author: "Marijn Haverbeke", Category: "Programming", Desc: "A modern introduction to programming", CoverURL: GetCoverURL("eloquent-javascript.jpg")},
+		{ID: 13, Title: "JavaScript: The Good Parts", Author: "Douglas Crockford", Category: "Programming", Desc: "Unearthing the excellence in JavaScript", CoverURL: GetCoverURL("javascript-the-good-parts.jpg")},
+		{ID: 14, Title: "The Art of Computer Programming", Author: "Donald Knuth", Category: "Computer Science", Desc: "Fundamental algorithms", CoverURL: GetCoverURL("the-art-of-computer-programming.jpg")},
+		{ID: 15, Title: "Structure and Interpretation of Computer Programs", Author: "Harold Abelson", Category: "Computer Science", Desc: "Classic computer science text", CoverURL: GetCoverURL("structure-and-interpretation-of-computer-programs.jpg")},
+		{ID: 16, Title: "Python Crash Course", Author: "Eric Matthes", Category: "Programming", Desc: "A hands-on project-based introduction to programming", CoverURL: GetCoverURL("python-crash-course.jpg")},
+		{ID: 17, Title: "Learning Python", Author: "Mark Lutz", Category: "Programming", Desc: "Powerful object-oriented programming", CoverURL: GetCoverURL("learning-python.jpg")},
+		{ID: 18, Title: "Fluent Python", Author: "Luciano Ramalho", Category: "Programming", Desc: "Clear, concise, and effective programming", CoverURL: GetCoverURL("fluent-python.jpg")},
+		{ID: 19, Title: "Automate the Boring Stuff with Python", Author: "Al Sweigart", Category: "Programming", Desc: "Practical programming for total beginners", CoverURL: GetCoverURL("automate-the-boring-stuff-with-python.jpg")},
+		{ID: 20, Title: "Effective Java", Author: "Joshua Bloch", Category: "Programming", Desc: "Best practices for the Java platform", CoverURL: GetCoverURL("effective-java.jpg")},
+		{ID: 21, Title: "Java: The Complete Reference", Author: "Herbert Schildt", Category: "Programming", Desc: "Comprehensive guide to Java programming", CoverURL: GetCoverURL("java-the-complete-reference.jpg")},
+		{ID: 22, Title: "Head First Java", Author: "Kathy Sierra", Category: "Programming", Desc: "A brain-friendly guide to Java", CoverURL: GetCoverURL("head-first-java.jpg")},
+		{ID: 23, Title: "Thinking in Java", Author: "Bruce Eckel", Category: "Programming", Desc: "The definitive introduction to Java", CoverURL: GetCoverURL("thinking-in-java.jpg")},
+		{ID: 24, Title: "C Programming Language", Author: "Brian Kernighan", Category: "Programming", Desc: "The classic C programming guide", CoverURL: GetCoverURL("c-programming-language.jpg")},
+		{ID: 25, Title: "C++ Primer", Author: "Stanley Lippman", Category: "Programming", Desc: "Comprehensive introduction to C++", CoverURL: GetCoverURL("Cpp-Primer.jpg")},
+		{ID: 26, Title: "Effective Modern C++", Author: "Scott Meyers", Category: "Programming", Desc: "42 specific ways to improve your use of C++11 and C++14", CoverURL: GetCoverURL("effective-modern-cpp.jpg")},
+		{ID: 27, Title: "The C++ Programming Language", Author: "Bjarne Stroustrup", Category: "Programming", Desc: "The definitive guide by the creator of C++", CoverURL: GetCoverURL("the-cpp-programming-language.jpg")},
+		{ID: 28, Title: "Ruby on Rails Tutorial", Author: "Michael Hartl", Category: "Web Development", Desc: "Learn web development with Rails", CoverURL: GetCoverURL("ruby-on-rails-tutorial.jpg")},
+		{ID: 29, Title: "Programming Ruby", Author: "Dave Thomas", Category: "Programming", Desc: "The pragmatic programmers guide", CoverURL: GetCoverURL("programming-ruby.jpg")},
+		{ID: 30, Title: "Node.js Design Patterns", Author: "Mario Casciaro", Category: "Web Development", Desc: "Master best practices to build modular applications", CoverURL: GetCoverURL("node-js-design-patterns.jpg")},
+		{ID: 31, Title: "Learning React", Author: "Alex Banks", Category: "Web Development", Desc: "Modern patterns for developing React apps", CoverURL: GetCoverURL("learning-react.jpg")},
+		{ID: 32, Title: "React Up & Running", Author: "Stoyan Stefanov", Category: "Web Development", Desc: "Building web applications with React", CoverURL: GetCoverURL("react-up-running.jpg")},
+		{ID: 33, Title: "Vue.js in Action", Author: "Erik Hanchett", Category: "Web Development", Desc: "Building modern web applications with Vue", CoverURL: GetCoverURL("vue-js-in-action.jpg")},
+		{ID: 34, Title: "Angular in Action", Author: "Jeremy Wilken", Category: "Web Development", Desc: "Build dynamic web applications with Angular", CoverURL: GetCoverURL("angular-in-action.jpg")},
+		{ID: 35, Title: "Docker Deep Dive", Author: "Nigel Poulton", Category: "DevOps", Desc: "Zero to Docker in a single book", CoverURL: GetCoverURL("docker-deep-dive.jpg")},
+		{ID: 36, Title: "Kubernetes in Action", Author: "Marko Luksa", Category: "DevOps", Desc: "Learn Kubernetes from a developer perspective", CoverURL: GetCoverURL("kubernetes-in-action.jpg")},
+		{ID: 37, Title: "The DevOps Handbook", Author: "Gene Kim", Category: "DevOps", Desc: "How to create world-class agility, reliability, and security", CoverURL: GetCoverURL("the-devops-handbook.jpg")},
+		{ID: 38, Title: "Site Reliability Engineering", Author: "Betsy Beyer", Category: "DevOps", Desc: "How Google runs production systems", CoverURL: GetCoverURL("site-reliability-engineering.jpg")},
+		{ID: 39, Title: "Continuous Delivery", Author: "Jez Humble", Category: "DevOps", Desc: "Reliable software releases through automation", CoverURL: GetCoverURL("continuous-delivery.jpg")},
+		{ID: 40, Title: "Database Design for Mere Mortals", Author: "Michael Hernandez", Category: "Database", Desc: "A hands-on guide to relational database design", CoverURL: GetCoverURL("database-design-for-mere-mortals.jpg")},
+		{ID: 41, Title: "SQL Performance Explained", Author: "Markus Winand", Category: "Database", Desc: "Everything developers need to know about SQL performance", CoverURL: GetCoverURL("sql-performance-explained.jpg")},
+		{ID: 42, Title: "MongoDB: The Definitive Guide", Author: "Shannon Bradshaw", Category: "Database", Desc: "Powerful and scalable data storage", CoverURL: GetCoverURL("mongodb-the-definitive-guide.jpg")},
+		{ID: 43, Title: "Redis in Action", Author: "Josiah Carlson", Category: "Database", Desc: "Learn Redis through practical examples", CoverURL: GetCoverURL("redis-in-action.jpg")},
+		{ID: 44, Title: "Machine Learning Yearning", Author: "Andrew Ng", Category: "Machine Learning", Desc: "Technical strategy for AI engineers", CoverURL: GetCoverURL("machine-learning-yearning.jpg")},
+		{ID: 45, Title: "Hands-On Machine Learning", Author: "Aurélien Géron", Category: "Machine Learning", Desc: "With Scikit-Learn, Keras, and TensorFlow", CoverURL: GetCoverURL("hands-on-machine-learning.jpg")},
+		{ID: 46, Title: "Deep Learning", Author: "Ian Goodfellow", Category: "Machine Learning", Desc: "Comprehensive introduction to deep learning", CoverURL: GetCoverURL("deep-learning.jpg")},
+		{ID: 47, Title: "Pattern Recognition and Machine Learning", Author: "Christopher Bishop", Category: "Machine Learning", Desc: "A comprehensive treatment of the field", CoverURL: GetCoverURL("pattern-recognition-and-machine-learning.jpg")},
+		{ID: 48, Title: "Artificial Intelligence: A Modern Approach", Author: "Stuart Russell", Category: "Artificial Intelligence", Desc: "The definitive AI textbook", CoverURL: GetCoverURL("artificial-intelligence-a-modern-approach.jpg")},
+		{ID: 49, Title: "Designing Data-Intensive Applications", Author: "Martin Kleppmann", Category: "System Design", Desc: "The big ideas behind reliable, scalable systems", CoverURL: GetCoverURL("designing-data-intensive-applications.jpg")},
+		{ID: 50, Title: "System Design Interview", Author: "Alex Xu", Category: "System Design", Desc: "An insider's guide to system design", CoverURL: GetCoverURL("system-design-interview.jpg")},
+	}
+
+	return books
+}
+
+// GetInitialAuthors returns one author per distinct author name in books,
+// numbered in order of first appearance.
+func GetInitialAuthors(books []Book) []Author {
+	authors := []Author{}
+	seen := map[string]bool{}
+	for _, book := range books {
+		if book.Author == "" || seen[book.Author] {
+			continue
+		}
+		seen[book.Author] = true
+		authors = append(authors, Author{ID: len(authors) + 1, Name: book.Author})
+	}
+	return authors
+}
+
+// GetInitialCategories returns one category per distinct category name in
+// books, numbered in order of first appearance.
+func GetInitialCategories(books []Book) []Category {
+	categories := []Category{}
+	seen := map[string]bool{}
+	for _, book := range books {
+		if book.Category == "" || seen[book.Category] {
+			continue
+		}
+		seen[book.Category] = true
+		categories = append(categories, Category{ID: len(categories) + 1, Name: book.Category})
+	}
+	return categories
+}
+
+var initialReviewers = []string{"Alice", "Budi", "Chen", "Dewi", "Emma", "Farhan", "Grace"}
+
+var initialReviewComments = []string{
+	"Dense in places, but worth every page.",
+	"Changed the way I think about my work.",
+	"A solid reference I keep coming back to.",
+	"Good ideas, though some chapters feel dated.",
+	"Clear explanations and practical examples.",
+}
+
+// GetInitialReviews returns two reviews for every book in books. The reviews
+// only depend on the book IDs, so every data source seeds the same ones.
+func GetInitialReviews(books []Book) []Review {
+	reviews := make([]Review, 0, len(books)*2)
+	for _, book := range books {
+		for i := 0; i < 2; i++ {
+			n := book.ID*2 + i
+			reviews = append(reviews, Review{
+				ID:       len(reviews) + 1,
+				BookID:   book.ID,
+				Reviewer: initialReviewers[n%len(initialReviewers)],
+				Rating:   3 + n%3,
+				Comment:  initialReviewComments[n%len(initialReviewComments)],
+			})
+		}
+	}
+	return reviews
+}
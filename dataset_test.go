@@ -0,0 +1,96 @@
+package mockapi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestGetCoverURL(t *testing.T) {
+	originalURL := os.Getenv("BASE_URL")
+	os.Setenv("BASE_URL", "http://test.com")
+	defer os.Setenv("BASE_URL", originalURL)
+
+	result := GetCoverURL("test.jpg")
+	expected := "http://test.com/mockapi/static/image/test.jpg"
+	if result != expected {
+		t.Errorf("Expected cover URL %s, got %s", expected, result)
+	}
+}
+
+func TestBookCoverURL(t *testing.T) {
+	originalURL := os.Getenv("BASE_URL")
+	os.Setenv("BASE_URL", "http://test.com")
+	defer os.Setenv("BASE_URL", originalURL)
+
+	tests := []struct {
+		cover    string
+		expected string
+	}{
+		{"", "http://test.com/mockapi/placeholder/7"},
+		{"clean-code.jpg", "http://test.com/mockapi/static/image/clean-code.jpg"},
+		{"missing.jpg", "http://test.com/mockapi/placeholder/7"},
+		{"http://localhost:8080/mockapi/static/image/clean-code.jpg", "http://test.com/mockapi/static/image/clean-code.jpg"},
+		{"http://localhost:8080/mockapi/static/image/missing.jpg", "http://test.com/mockapi/placeholder/7"},
+		{"https://example.com/cover.jpg", "https://example.com/cover.jpg"},
+	}
+	for _, tt := range tests {
+		if result := BookCoverURL(Book{ID: 7, CoverURL: tt.cover}); result != tt.expected {
+			t.Errorf("Expected cover URL %s for %q, got %s", tt.expected, tt.cover, result)
+		}
+	}
+}
+
+func TestGetInitialBooks(t *testing.T) {
+	books := GetInitialBooks()
+
+	expectedCount := 50
+	if len(books) != expectedCount {
+		t.Fatalf("Expected %d books, got %d", expectedCount, len(books))
+	}
+
+	for i, book := range books {
+		if book.ID != i+1 {
+			t.Errorf("Expected book at index %d to have ID %d, got %d", i, i+1, book.ID)
+		}
+		if BookCoverURL(book) != book.CoverURL {
+			t.Errorf("Expected the cover of book %d to be embedded, got %s", book.ID, book.CoverURL)
+		}
+	}
+}
+
+func TestGetInitialAuthorsAndCategories(t *testing.T) {
+	books := []Book{
+		{ID: 1, Author: "A", Category: "X"},
+		{ID: 2, Author: "B", Category: "X"},
+		{ID: 3, Author: "A", Category: "Y"},
+	}
+
+	authors := GetInitialAuthors(books)
+	if len(authors) != 2 || authors[0] != (Author{ID: 1, Name: "A"}) || authors[1] != (Author{ID: 2, Name: "B"}) {
+		t.Errorf("Unexpected authors %+v", authors)
+	}
+	categories := GetInitialCategories(books)
+	if len(categories) != 2 || categories[0] != (Category{ID: 1, Name: "X"}) || categories[1] != (Category{ID: 2, Name: "Y"}) {
+		t.Errorf("Unexpected categories %+v", categories)
+	}
+}
+
+func TestGetInitialReviews(t *testing.T) {
+	books := GetInitialBooks()
+	reviews := GetInitialReviews(books)
+
+	if len(reviews) != 2*len(books) {
+		t.Fatalf("Expected %d reviews, got %d", 2*len(books), len(reviews))
+	}
+	for i, review := range reviews {
+		if review.ID != i+1 {
+			t.Errorf("Expected review at index %d to have ID %d, got %d", i, i+1, review.ID)
+		}
+		if review.BookID != books[i/2].ID {
+			t.Errorf("Expected review %d to belong to book %d, got %d", review.ID, books[i/2].ID, review.BookID)
+		}
+		if review.Rating < 1 || review.Rating > 5 || review.Reviewer == "" {
+			t.Errorf("Invalid review %+v", review)
+		}
+	}
+}
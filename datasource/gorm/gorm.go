@@ -1,27 +1,29 @@
 package gormsql
 
 import (
-	"fmt"
-	"os"
+	"errors"
+	"slices"
+	"strings"
 
 	"github.com/anggaaryas/go-mockapi"
 	"gorm.io/gorm"
 )
 
+// insertBatchSize is the number of rows per INSERT when seeding, which keeps
+// large seeds such as mockapi.GenerateBooks below SQLite's variable limit.
+const insertBatchSize = 1000
+
 type dataSource struct {
-	db *gorm.DB
+	db   *gorm.DB
+	seed []mockapi.Book
 }
 
 func getBaseURL() string {
-	baseURL := os.Getenv("BASE_URL")
-	if baseURL == "" {
-		baseURL = "http://localhost:8080"
-	}
-	return baseURL
+	return mockapi.GetBaseURL()
 }
 
 func getCoverURL(filename string) string {
-	return fmt.Sprintf("%s%s%s", getBaseURL(), mockapi.GetMockapiStaticImagePath(), filename)
+	return mockapi.GetCoverURL(filename)
 }
 
 func Create(db *gorm.DB) mockapi.DataSource {
@@ -30,126 +32,340 @@ func Create(db *gorm.DB) mockapi.DataSource {
 	}
 }
 
+// CreateWithSeed returns a DataSource whose PopulateData inserts seed, e.g.
+// books read with mockapi.LoadBooksFile, instead of the built-in dataset.
+func CreateWithSeed(db *gorm.DB, seed []mockapi.Book) mockapi.DataSource {
+	return &dataSource{
+		db:   db,
+		seed: seed,
+	}
+}
+
 func getInitialBooks() []mockapi.Book {
-	var books = []mockapi.Book{
-		{ID: 1, Title: "The Go Programming Language", Author: "Alan A. A. Donovan", Category: "Programming", Desc: "A comprehensive guide to Go programming", CoverURL: getCoverURL("go-programming-languange.jpg")},
-		{ID: 2, Title: "Clean Code", Author: "Robert C. Martin", Category: "Programming", Desc: "A handbook of agile software craftsmanship", CoverURL: getCoverURL("clean-code.jpg")},
-		{ID: 3, Title: "Design Patterns", Author: "Erich Gamma", Category: "Programming", Desc: "Elements of reusable object-oriented software", CoverURL: getCoverURL("design-pattern.jpg")},
-		{ID: 4, Title: "The Pragmatic Programmer", Author: "Andrew Hunt", Category: "Programming", Desc: "Your journey to mastery", CoverURL: getCoverURL("the-pragmatic-programmer.jpg")},
-		{ID: 5, Title: "Introduction to Algorithms", Author: "Thomas H. Cormen", Category: "Computer Science", Desc: "A comprehensive introduction to algorithms", CoverURL: getCoverURL("introduction-to-algorithms.jpg")},
-		{ID: 6, Title: "Code Complete", Author: "Steve McConnell", Category: "Programming", Desc: "A practical handbook of software construction", CoverURL: getCoverURL("code-complete.jpg")},
-		{ID: 7, Title: "Refactoring", Author: "Martin Fowler", Category: "Programming", Desc: "Improving the design of existing code", CoverURL: getCoverURL("refactoring.jpg")},
-		{ID: 8, Title: "Head First Design Patterns", Author: "Eric Freeman", Category: "Programming", Desc: "A brain-friendly guide to design patterns", CoverURL: getCoverURL("head-first-design-pattern.jpg")},
-		{ID: 9, Title: "The Mythical Man-Month", Author: "Frederick P. Brooks Jr.", Category: "Software Engineering", Desc: "Essays on software engineering", CoverURL: getCoverURL("the-mythical-man-month.jpg")},
-		{ID: 10, Title: "Cracking the Coding Interview", Author: "Gayle Laakmann McDowell", Category: "Programming", Desc: "189 programming questions and solutions", CoverURL: getCoverURL("cracking-the-code-interview.jpg")},
-		{ID: 11, Title: "You Don't Know JS", Author: "Kyle Simpson", Category: "Programming", Desc: "Deep dive into JavaScript", CoverURL: getCoverURL("you-dont-know-js.jpg")},
-		{ID: 12, Title: "Eloquent JavaScript", Author: "Marijn Haverbeke", Category: "Programming", Desc: "A modern introduction to programming", CoverURL: getCoverURL("eloquent-javascript.jpg")},
-		{ID: 13, Title: "JavaScript: The Good Parts", Author: "Douglas Crockford", Category: "Programming", Desc: "Unearthing the excellence in JavaScript", CoverURL: getCoverURL("javascript-the-good-parts.jpg")},
-		{ID: 14, Title: "The Art of Computer Programming", Author: "Donald Knuth", Category: "Computer Science", Desc: "Fundamental algorithms", CoverURL: getCoverURL("the-art-of-computer-programming.jpg")},
-		{ID: 15, Title: "Structure and Interpretation of Computer Programs", Author: "Harold Abelson", Category: "Computer Science", Desc: "Classic computer science text", CoverURL: getCoverURL("structure-and-interpretation-of-computer-programs.jpg")},
-		{ID: 16, Title: "Python Crash Course", Author: "Eric Matthes", Category: "Programming", Desc: "A hands-on project-based introduction to programming", CoverURL: getCoverURL("python-crash-course.jpg")},
-		{ID: 17, Title: "Learning Python", Author: "Mark Lutz", Category: "Programming", Desc: "Powerful object-oriented programming", CoverURL: getCoverURL("learning-python.jpg")},
-		{ID: 18, Title: "Fluent Python", Author: "Luciano Ramalho", Category: "Programming", Desc: "Clear, concise, and effective programming", CoverURL: getCoverURL("fluent-python.jpg")},
-		{ID: 19, Title: "Automate the Boring Stuff with Python", Author: "Al Sweigart", Category: "Programming", Desc: "Practical programming for total beginners", CoverURL: getCoverURL("automate-the-boring-stuff-with-python.jpg")},
-		{ID: 20, Title: "Effective Java", Author: "Joshua Bloch", Category: "Programming", Desc: "Best practices for the Java platform", CoverURL: getCoverURL("effective-java.jpg")},
-		{ID: 21, Title: "Java: The Complete Reference", Author: "Herbert Schildt", Category: "Programming", Desc: "Comprehensive guide to Java programming", CoverURL: getCoverURL("java-the-complete-reference.jpg")},
-		{ID: 22, Title: "Head First Java", Author: "Kathy Sierra", Category: "Programming", Desc: "A brain-friendly guide to Java", CoverURL: getCoverURL("head-first-java.jpg")},
-		{ID: 23, Title: "Thinking in Java", Author: "Bruce Eckel", Category: "Programming", Desc: "The definitive introduction to Java", CoverURL: getCoverURL("thinking-in-java.jpg")},
-		{ID: 24, Title: "C Programming Language", Author: "Brian Kernighan", Category: "Programming", Desc: "The classic C programming guide", CoverURL: getCoverURL("c-programming-language.jpg")},
-		{ID: 25, Title: "C++ Primer", Author: "Stanley Lippman", Category: "Programming", Desc: "Comprehensive introduction to C++", CoverURL: getCoverURL("Cpp-Primer.jpg")},
-		{ID: 26, Title: "Effective Modern C++", Author: "Scott Meyers", Category: "Programming", Desc: "42 specific ways to improve your use of C++11 and C++14", CoverURL: getCoverURL("effective-modern-cpp.jpg")},
-		{ID: 27, Title: "The C++ Programming Language", Author: "Bjarne Stroustrup", Category: "Programming", Desc: "The definitive guide by the creator of C++", CoverURL: getCoverURL("the-cpp-programming-language.jpg")},
-		{ID: 28, Title: "Ruby on Rails Tutorial", Author: "Michael Hartl", Category: "Web Development", Desc: "Learn web development with Rails", CoverURL: getCoverURL("ruby-on-rails-tutorial.jpg")},
-		{ID: 29, Title: "Programming Ruby", Author: "Dave Thomas", Category: "Programming", Desc: "The pragmatic programmers guide", CoverURL: getCoverURL("programming-ruby.jpg")},
-		{ID: 30, Title: "Node.js Design Patterns", Author: "Mario Casciaro", Category: "Web Development", Desc: "Master best practices to build modular applications", CoverURL: getCoverURL("node-js-design-patterns.jpg")},
-		{ID: 31, Title: "Learning React", Author: "Alex Banks", Category: "Web Development", Desc: "Modern patterns for developing React apps", CoverURL: getCoverURL("learning-react.jpg")},
-		{ID: 32, Title: "React Up & Running", Author: "Stoyan Stefanov", Category: "Web Development", Desc: "Building web applications with React", CoverURL: getCoverURL("react-up-running.jpg")},
-		{ID: 33, Title: "Vue.js in Action", Author: "Erik Hanchett", Category: "Web Development", Desc: "Building modern web applications with Vue", CoverURL: getCoverURL("vue-js-in-action.jpg")},
-		{ID: 34, Title: "Angular in Action", Author: "Jeremy Wilken", Category: "Web Development", Desc: "Build dynamic web applications with Angular", CoverURL: getCoverURL("angular-in-action.jpg")},
-		{ID: 35, Title: "Docker Deep Dive", Author: "Nigel Poulton", Category: "DevOps", Desc: "Zero to Docker in a single book", CoverURL: getCoverURL("docker-deep-dive.jpg")},
-		{ID: 36, Title: "Kubernetes in Action", Author: "Marko Luksa", Category: "DevOps", Desc: "Learn Kubernetes from a developer perspective", CoverURL: getCoverURL("kubernetes-in-action.jpg")},
-		{ID: 37, Title: "The DevOps Handbook", Author: "Gene Kim", Category: "DevOps", Desc: "How to create world-class agility, reliability, and security", CoverURL: getCoverURL("the-devops-handbook.jpg")},
-		{ID: 38, Title: "Site Reliability Engineering", Author: "Betsy Beyer", Category: "DevOps", Desc: "How Google runs production systems", CoverURL: getCoverURL("site-reliability-engineering.jpg")},
-		{ID: 39, Title: "Continuous Delivery", Author: "Jez Humble", Category: "DevOps", Desc: "Reliable software releases through automation", CoverURL: getCoverURL("continuous-delivery.jpg")},
-		{ID: 40, Title: "Database Design for Mere Mortals", Author: "Michael Hernandez", Category: "Database", Desc: "A hands-on guide to relational database design", CoverURL: getCoverURL("database-design-for-mere-mortals.jpg")},
-		{ID: 41, Title: "SQL Performance Explained", Author: "Markus Winand", Category: "Database", Desc: "Everything developers need to know about SQL performance", CoverURL: getCoverURL("sql-performance-explained.jpg")},
-		{ID: 42, Title: "MongoDB: The Definitive Guide", Author: "Shannon Bradshaw", Category: "Database", Desc: "Powerful and scalable data storage", CoverURL: getCoverURL("mongodb-the-definitive-guide.jpg")},
-		{ID: 43, Title: "Redis in Action", Author: "Josiah Carlson", Category: "Database", Desc: "Learn Redis through practical examples", CoverURL: getCoverURL("redis-in-action.jpg")},
-		{ID: 44, Title: "Machine Learning Yearning", Author: "Andrew Ng", Category: "Machine Learning", Desc: "Technical strategy for AI engineers", CoverURL: getCoverURL("machine-learning-yearning.jpg")},
-		{ID: 45, Title: "Hands-On Machine Learning", Author: "Aurélien Géron", Category: "Machine Learning", Desc: "With Scikit-Learn, Keras, and TensorFlow", CoverURL: getCoverURL("hands-on-machine-learning.jpg")},
-		{ID: 46, Title: "Deep Learning", Author: "Ian Goodfellow", Category: "Machine Learning", Desc: "Comprehensive introduction to deep learning", CoverURL: getCoverURL("deep-learning.jpg")},
-		{ID: 47, Title: "Pattern Recognition and Machine Learning", Author: "Christopher Bishop", Category: "Machine Learning", Desc: "A comprehensive treatment of the field", CoverURL: getCoverURL("pattern-recognition-and-machine-learning.jpg")},
-		{ID: 48, Title: "Artificial Intelligence: A Modern Approach", Author: "Stuart Russell", Category: "Artificial Intelligence", Desc: "The definitive AI textbook", CoverURL: getCoverURL("artificial-intelligence-a-modern-approach.jpg")},
-		{ID: 49, Title: "Designing Data-Intensive Applications", Author: "Martin Kleppmann", Category: "System Design", Desc: "The big ideas behind reliable, scalable systems", CoverURL: getCoverURL("designing-data-intensive-applications.jpg")},
-		{ID: 50, Title: "System Design Interview", Author: "Alex Xu", Category: "System Design", Desc: "An insider's guide to system design", CoverURL: getCoverURL("system-design-interview.jpg")},
-	}
-	
-	return books
+	return mockapi.GetInitialBooks()
 }
 
 func (ds *dataSource) PopulateData() error {
-	err := ds.db.AutoMigrate(&mockapi.Book{})
+	err := ds.db.AutoMigrate(&mockapi.Book{}, &authorRecord{}, &categoryRecord{}, &reviewRecord{})
 	if err != nil {
 		panic("failed to migrate database")
 	}
+	if err := dropNameConstraints(ds.db); err != nil {
+		return err
+	}
 	return ds.db.Transaction(func(tx *gorm.DB) error {
 		var count int64
 		if err := tx.Model(&mockapi.Book{}).Count(&count).Error; err != nil {
 			return err
 		}
 
-		if count > 0 {
-			return nil
+		if count == 0 {
+			if err := insertBooks(tx, ds.seed); err != nil {
+				return err
+			}
 		}
 
-		books := getInitialBooks()
+		return populateRelated(tx)
+	})
+}
 
-		if err := tx.Create(&books).Error; err != nil {
+// nameConstraints are the foreign keys from books to authors and categories
+// that databases migrated by earlier versions have.
+var nameConstraints = []string{"fk_authors_books", "fk_categories_books"}
+
+// dropNameConstraints drops nameConstraints, which fail the writes of books
+// whose author or category is not stored yet.
+func dropNameConstraints(db *gorm.DB) error {
+	migrator := db.Migrator()
+	for _, name := range nameConstraints {
+		if !migrator.HasConstraint(&mockapi.Book{}, name) {
+			continue
+		}
+		if err := migrator.DropConstraint(&mockapi.Book{}, name); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
+// insertBooks inserts seed, or the built-in dataset when seed is nil.
+func insertBooks(tx *gorm.DB, seed []mockapi.Book) error {
+	books := slices.Clone(seed)
+	if books == nil {
+		books = getInitialBooks()
+	}
+	if len(books) == 0 {
 		return nil
+	}
+	return tx.CreateInBatches(&books, insertBatchSize).Error
+}
+
+// ResetData replaces all books and related records with seed, or with the
+// seed the data source was created with when seed is nil. PopulateData must
+// have been called to create the tables.
+func (ds *dataSource) ResetData(seed []mockapi.Book) error {
+	if seed == nil {
+		seed = ds.seed
+	}
+	return ds.db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteAll(tx); err != nil {
+			return err
+		}
+		if err := insertBooks(tx, seed); err != nil {
+			return err
+		}
+		return populateRelated(tx)
+	})
+}
+
+// deleteAll empties every table, reviews first as they refer to books.
+func deleteAll(tx *gorm.DB) error {
+	for _, model := range []any{&reviewRecord{}, &mockapi.Book{}, &authorRecord{}, &categoryRecord{}} {
+		if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportData returns every record, ordered by ID.
+func (ds *dataSource) ExportData() (mockapi.Dataset, error) {
+	data := mockapi.Dataset{Books: []mockapi.Book{}}
+	if err := ds.db.Order("id").Find(&data.Books).Error; err != nil {
+		return mockapi.Dataset{}, err
+	}
+	authors, err := ds.GetAuthors()
+	if err != nil {
+		return mockapi.Dataset{}, err
+	}
+	categories, err := ds.GetCategories()
+	if err != nil {
+		return mockapi.Dataset{}, err
+	}
+	var reviews []reviewRecord
+	if err := ds.db.Order("id").Find(&reviews).Error; err != nil {
+		return mockapi.Dataset{}, err
+	}
+
+	data.Authors = authors
+	data.Categories = categories
+	data.Reviews = make([]mockapi.Review, 0, len(reviews))
+	for _, review := range reviews {
+		data.Reviews = append(data.Reviews, review.toReview())
+	}
+	return data, nil
+}
+
+// ImportData replaces every record with data in a single transaction.
+func (ds *dataSource) ImportData(data mockapi.Dataset) error {
+	if err := mockapi.ValidateDataset(&data); err != nil {
+		return err
+	}
+
+	var authors []authorRecord
+	for _, author := range data.Authors {
+		authors = append(authors, authorRecord{ID: author.ID, Name: author.Name})
+	}
+	var categories []categoryRecord
+	for _, category := range data.Categories {
+		categories = append(categories, categoryRecord{ID: category.ID, Name: category.Name})
+	}
+	var reviews []reviewRecord
+	for _, review := range data.Reviews {
+		reviews = append(reviews, newReviewRecord(review))
+	}
+
+	return ds.db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteAll(tx); err != nil {
+			return err
+		}
+		if err := insertBooks(tx, data.Books); err != nil {
+			return err
+		}
+		if err := createIfEmpty(tx, &authorRecord{}, &authors, len(authors)); err != nil {
+			return err
+		}
+		if err := createIfEmpty(tx, &categoryRecord{}, &categories, len(categories)); err != nil {
+			return err
+		}
+		return createIfEmpty(tx, &reviewRecord{}, &reviews, len(reviews))
 	})
 }
 
 func (ds *dataSource) GetBookByID(id string) (mockapi.Book, error) {
 	var book mockapi.Book
 	if err := ds.db.First(&book, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return mockapi.Book{}, mockapi.NewBookNotFoundError(id)
+		}
 		return mockapi.Book{}, err
 	}
 	return book, nil
 }
 
-func (ds *dataSource) GetBooks(page int, pageSize int, search string) ([]mockapi.Book, error) {
+// likeEscaper escapes the LIKE wildcards of a search term, for conditions
+// using ESCAPE '\'.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// containsPattern returns the LIKE pattern matching values that contain
+// search literally, as the memory data source does.
+func containsPattern(search string) string {
+	return "%" + likeEscaper.Replace(search) + "%"
+}
+
+func filterBooks(db *gorm.DB, query mockapi.BookQuery) *gorm.DB {
+	if query.Search != "" {
+		searchPattern := containsPattern(query.Search)
+		db = db.Where(`title LIKE ? ESCAPE '\' OR author LIKE ? ESCAPE '\'`, searchPattern, searchPattern)
+	}
+	if len(query.Category) > 0 {
+		db = db.Where("category IN ?", query.Category)
+	}
+	if len(query.Author) > 0 {
+		db = db.Where("author IN ?", query.Author)
+	}
+	return db
+}
+
+func oppositeOrder(order string) string {
+	if order == mockapi.OrderDesc {
+		return mockapi.OrderAsc
+	}
+	return mockapi.OrderDesc
+}
+
+// sortBooks orders by the query's sort field with ID as tie-breaker. When
+// reverse is set, the whole ordering is flipped.
+func sortBooks(db *gorm.DB, query mockapi.BookQuery, reverse bool) *gorm.DB {
+	query = query.WithDefaults()
+	if !mockapi.IsBookSortField(query.Sort) || !mockapi.IsSortOrder(query.Order) {
+		return db
+	}
+	order, idOrder := query.Order, mockapi.OrderAsc
+	if reverse {
+		order, idOrder = oppositeOrder(order), oppositeOrder(idOrder)
+	}
+	db = db.Order(query.Sort + " " + order)
+	if query.Sort != "id" {
+		db = db.Order("id " + idOrder)
+	}
+	return db
+}
+
+// seekBooks keeps only the books following the cursor position in the
+// cursor's paging direction.
+func seekBooks(db *gorm.DB, cursor mockapi.BookCursor) *gorm.DB {
+	op := ">"
+	if (cursor.Order == mockapi.OrderDesc) != cursor.Before {
+		op = "<"
+	}
+	if cursor.Sort == "id" {
+		return db.Where("id "+op+" ?", cursor.ID)
+	}
+	idOp := ">"
+	if cursor.Before {
+		idOp = "<"
+	}
+	return db.Where(cursor.Sort+" "+op+" ? OR ("+cursor.Sort+" = ? AND id "+idOp+" ?)", cursor.Value, cursor.Value, cursor.ID)
+}
+
+func (ds *dataSource) GetBooks(query mockapi.BookQuery) ([]mockapi.Book, error) {
 	var books []mockapi.Book
-	offset := (page - 1) * pageSize
-	
-	if search != "" {
-		searchPattern := "%" + search + "%"
-		if err := ds.db.Offset(offset).Limit(pageSize).Where("title LIKE ? OR author LIKE ?", searchPattern, searchPattern).Find(&books).Error; err != nil {
-			return nil, err
+	offset := (query.Page - 1) * query.PageSize
+
+	db := sortBooks(filterBooks(ds.db, query), query, false)
+	if err := db.Offset(offset).Limit(query.PageSize).Find(&books).Error; err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+func (ds *dataSource) GetBooksCount(query mockapi.BookQuery) (int64, error) {
+	var count int64
+	if err := filterBooks(ds.db.Model(&mockapi.Book{}), query).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (ds *dataSource) GetBooksByCursor(query mockapi.BookQuery, cursor *mockapi.BookCursor) ([]mockapi.Book, error) {
+	var books []mockapi.Book
+	db := filterBooks(ds.db, query)
+	if cursor != nil {
+		if !mockapi.IsBookSortField(cursor.Sort) || !mockapi.IsSortOrder(cursor.Order) {
+			return nil, mockapi.NewInvalidCursorError()
 		}
-		return books, nil
+		db = seekBooks(db, *cursor)
 	}
 
-	if err := ds.db.Offset(offset).Limit(pageSize).Find(&books).Error; err != nil {
+	db = sortBooks(db, query, cursor != nil && cursor.Before)
+	if err := db.Limit(query.PageSize).Find(&books).Error; err != nil {
 		return nil, err
 	}
 	return books, nil
 }
 
-func (ds *dataSource) GetBooksCount(search string) (int64, error) {
-	var count int64
-	if search != "" {
-		searchPattern := "%" + search + "%"
-		if err := ds.db.Model(&mockapi.Book{}).Where("title LIKE ? OR author LIKE ?", searchPattern, searchPattern).Count(&count).Error; err != nil {
-			return 0, err
+func (ds *dataSource) CreateBook(book mockapi.Book) (mockapi.Book, error) {
+	err := ds.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&book).Error; err != nil {
+			return err
 		}
-		return count, nil
+		return addRelated(tx, book)
+	})
+	if err != nil {
+		return mockapi.Book{}, err
 	}
+	return book, nil
+}
 
-	if err := ds.db.Model(&mockapi.Book{}).Count(&count).Error; err != nil {
-		return 0, err
+func (ds *dataSource) UpdateBook(id string, book mockapi.Book) (mockapi.Book, error) {
+	existing, err := ds.GetBookByID(id)
+	if err != nil {
+		return mockapi.Book{}, err
 	}
-	return count, nil
-}
\ No newline at end of file
+	book.ID = existing.ID
+	err = ds.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&book).Error; err != nil {
+			return err
+		}
+		return addRelated(tx, book)
+	})
+	if err != nil {
+		return mockapi.Book{}, err
+	}
+	return book, nil
+}
+
+func (ds *dataSource) PatchBook(id string, patch mockapi.BookPatch) (mockapi.Book, error) {
+	book, err := ds.GetBookByID(id)
+	if err != nil {
+		return mockapi.Book{}, err
+	}
+	patch.Apply(&book)
+	err = ds.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&book).Error; err != nil {
+			return err
+		}
+		return addRelated(tx, book)
+	})
+	if err != nil {
+		return mockapi.Book{}, err
+	}
+	return book, nil
+}
+
+func (ds *dataSource) DeleteBook(id string) error {
+	return ds.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&reviewRecord{}, "book_id = ?", id).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&mockapi.Book{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return mockapi.NewBookNotFoundError(id)
+		}
+		return nil
+	})
+}
@@ -2,11 +2,13 @@ package gormsql
 
 import (
 	"os"
+	"strconv"
 	"testing"
 
 	"github.com/anggaaryas/go-mockapi"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 func setupTestDB(t *testing.T) *gorm.DB {
@@ -153,6 +155,145 @@ func TestPopulateData_AlreadyPopulated(t *testing.T) {
 	}
 }
 
+func TestPopulateData_WithSeed(t *testing.T) {
+	db := setupTestDB(t)
+	ds := CreateWithSeed(db, []mockapi.Book{
+		{ID: 3, Title: "Seed One", Author: "Author One"},
+		{ID: 9, Title: "Seed Two", Author: "Author Two"},
+	})
+
+	if err := ds.PopulateData(); err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+
+	var count int64
+	db.Model(&mockapi.Book{}).Count(&count)
+	if count != 2 {
+		t.Errorf("Expected 2 books, got %d", count)
+	}
+
+	book, err := ds.GetBookByID("9")
+	if err != nil {
+		t.Fatalf("GetBookByID failed: %v", err)
+	}
+	if book.Title != "Seed Two" {
+		t.Errorf("Expected title 'Seed Two', got '%s'", book.Title)
+	}
+}
+
+func TestPopulateData_GeneratedBooks(t *testing.T) {
+	db := setupTestDB(t)
+	db.Logger = db.Logger.LogMode(logger.Silent)
+	ds := CreateWithSeed(db, mockapi.GenerateBooks(2500, 1))
+
+	if err := ds.PopulateData(); err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+
+	count, _ := ds.GetBooksCount(mockapi.BookQuery{})
+	if count != 2500 {
+		t.Errorf("Expected 2500 books, got %d", count)
+	}
+	if reviews, _ := ds.GetReviews("2500"); len(reviews) != 2 {
+		t.Errorf("Expected 2 reviews for the last book, got %d", len(reviews))
+	}
+}
+
+func TestPopulateData_EmptySeed(t *testing.T) {
+	db := setupTestDB(t)
+	ds := CreateWithSeed(db, []mockapi.Book{})
+
+	if err := ds.PopulateData(); err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+
+	var count int64
+	db.Model(&mockapi.Book{}).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected no books, got %d", count)
+	}
+}
+
+func TestResetData(t *testing.T) {
+	db := setupTestDB(t)
+	ds := Create(db)
+	if err := ds.PopulateData(); err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+	resettable := ds.(mockapi.ResettableDataSource)
+
+	if err := resettable.ResetData([]mockapi.Book{{ID: 5, Title: "Only Book", Author: "Solo Author"}}); err != nil {
+		t.Fatalf("ResetData failed: %v", err)
+	}
+	count, _ := ds.GetBooksCount(mockapi.BookQuery{})
+	authors, _ := ds.GetAuthors()
+	reviews, _ := ds.GetReviews("5")
+	if count != 1 || len(authors) != 1 || len(reviews) != 2 {
+		t.Errorf("Expected 1 book, 1 author and 2 reviews, got %d, %d and %d", count, len(authors), len(reviews))
+	}
+
+	resettable.ResetData([]mockapi.Book{})
+	if count, _ := ds.GetBooksCount(mockapi.BookQuery{}); count != 0 {
+		t.Errorf("Expected no books, got %d", count)
+	}
+
+	resettable.ResetData(nil)
+	if count, _ := ds.GetBooksCount(mockapi.BookQuery{}); count != 50 {
+		t.Errorf("Expected the 50 built-in books, got %d", count)
+	}
+
+	seeded := CreateWithSeed(setupTestDB(t), []mockapi.Book{{ID: 1, Title: "Seed Book", Author: "Seed Author"}}).(mockapi.ResettableDataSource)
+	seeded.PopulateData()
+	seeded.DeleteBook("1")
+	seeded.ResetData(nil)
+	if book, err := seeded.GetBookByID("1"); err != nil || book.Title != "Seed Book" {
+		t.Errorf("Expected reset to restore the seed, got %+v, %v", book, err)
+	}
+}
+
+func TestExportImportData(t *testing.T) {
+	db := setupTestDB(t)
+	ds := Create(db).(mockapi.SnapshotDataSource)
+	if err := ds.PopulateData(); err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+
+	ds.CreateReview(mockapi.Review{BookID: 1, Reviewer: "Tester", Rating: 4})
+	data, err := ds.ExportData()
+	if err != nil {
+		t.Fatalf("ExportData failed: %v", err)
+	}
+	if len(data.Books) != 50 || len(data.Authors) == 0 || len(data.Reviews) != 101 {
+		t.Errorf("Expected 50 books, authors and 101 reviews, got %d, %d and %d", len(data.Books), len(data.Authors), len(data.Reviews))
+	}
+
+	// Simulate drift from edits made straight to the database
+	db.Exec("DELETE FROM books WHERE id = 1")
+	if err := ds.ImportData(data); err != nil {
+		t.Fatalf("ImportData failed: %v", err)
+	}
+	if _, err := ds.GetBookByID("1"); err != nil {
+		t.Errorf("Expected book 1 to be restored, got %v", err)
+	}
+	if reviews, _ := ds.GetReviews("1"); len(reviews) != 3 {
+		t.Errorf("Expected 3 reviews for book 1, got %d", len(reviews))
+	}
+
+	if err := ds.ImportData(mockapi.Dataset{Books: []mockapi.Book{{ID: 1}}}); err == nil {
+		t.Error("Expected error for an invalid dataset")
+	}
+	if count, _ := ds.GetBooksCount(mockapi.BookQuery{}); count != 50 {
+		t.Errorf("Expected an invalid import to leave the data alone, got %d books", count)
+	}
+
+	if err := ds.ImportData(mockapi.Dataset{Books: []mockapi.Book{}}); err != nil {
+		t.Fatalf("ImportData failed: %v", err)
+	}
+	if data, _ := ds.ExportData(); len(data.Books) != 0 || len(data.Authors) != 0 || len(data.Reviews) != 0 {
+		t.Errorf("Expected an empty dataset, got %+v", data)
+	}
+}
+
 func TestGetBookByID_Success(t *testing.T) {
 	db := setupTestDB(t)
 	ds := Create(db)
@@ -199,7 +340,7 @@ func TestGetBooks_FirstPage(t *testing.T) {
 		t.Fatalf("PopulateData failed: %v", err)
 	}
 
-	books, err := ds.GetBooks(1, 10, "")
+	books, err := ds.GetBooks(mockapi.BookQuery{Page: 1, PageSize: 10})
 	if err != nil {
 		t.Fatalf("GetBooks failed: %v", err)
 	}
@@ -218,7 +359,7 @@ func TestGetBooks_SecondPage(t *testing.T) {
 		t.Fatalf("PopulateData failed: %v", err)
 	}
 
-	books, err := ds.GetBooks(2, 10, "")
+	books, err := ds.GetBooks(mockapi.BookQuery{Page: 2, PageSize: 10})
 	if err != nil {
 		t.Fatalf("GetBooks failed: %v", err)
 	}
@@ -237,7 +378,7 @@ func TestGetBooks_WithSearch_Title(t *testing.T) {
 		t.Fatalf("PopulateData failed: %v", err)
 	}
 
-	books, err := ds.GetBooks(1, 10, "Go")
+	books, err := ds.GetBooks(mockapi.BookQuery{Page: 1, PageSize: 10, Search: "Go"})
 	if err != nil {
 		t.Fatalf("GetBooks with search failed: %v", err)
 	}
@@ -268,7 +409,7 @@ func TestGetBooks_WithSearch_Author(t *testing.T) {
 		t.Fatalf("PopulateData failed: %v", err)
 	}
 
-	books, err := ds.GetBooks(1, 10, "Martin")
+	books, err := ds.GetBooks(mockapi.BookQuery{Page: 1, PageSize: 10, Search: "Martin"})
 	if err != nil {
 		t.Fatalf("GetBooks with author search failed: %v", err)
 	}
@@ -278,6 +419,32 @@ func TestGetBooks_WithSearch_Author(t *testing.T) {
 	}
 }
 
+func TestGetBooks_WithSearch_Wildcards(t *testing.T) {
+	db := setupTestDB(t)
+	ds := Create(db)
+
+	if err := ds.PopulateData(); err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+	seed, _ := ds.GetBookByID("1")
+	for _, title := range []string{"100% Go", "1000 Go Tips", "snake_case Go", "snakeXcase Go", `C:\Go`} {
+		if _, err := ds.CreateBook(mockapi.Book{Title: title, Author: seed.Author, Category: seed.Category}); err != nil {
+			t.Fatalf("CreateBook failed: %v", err)
+		}
+	}
+
+	// Wildcards in the search term match themselves, like strings.Contains
+	for search, expected := range map[string]string{"0%": "100% Go", "e_c": "snake_case Go", `:\`: `C:\Go`} {
+		books, err := ds.GetBooks(mockapi.BookQuery{Page: 1, PageSize: 10, Search: search})
+		if err != nil {
+			t.Fatalf("GetBooks with search %q failed: %v", search, err)
+		}
+		if len(books) != 1 || books[0].Title != expected {
+			t.Errorf("Expected only %q for search %q, got %v", expected, search, books)
+		}
+	}
+}
+
 func TestGetBooksCount_NoSearch(t *testing.T) {
 	db := setupTestDB(t)
 	ds := Create(db)
@@ -287,7 +454,7 @@ func TestGetBooksCount_NoSearch(t *testing.T) {
 		t.Fatalf("PopulateData failed: %v", err)
 	}
 
-	count, err := ds.GetBooksCount("")
+	count, err := ds.GetBooksCount(mockapi.BookQuery{})
 	if err != nil {
 		t.Fatalf("GetBooksCount failed: %v", err)
 	}
@@ -307,7 +474,7 @@ func TestGetBooksCount_WithSearch(t *testing.T) {
 		t.Fatalf("PopulateData failed: %v", err)
 	}
 
-	count, err := ds.GetBooksCount("Go")
+	count, err := ds.GetBooksCount(mockapi.BookQuery{Search: "Go"})
 	if err != nil {
 		t.Fatalf("GetBooksCount with search failed: %v", err)
 	}
@@ -317,12 +484,238 @@ func TestGetBooksCount_WithSearch(t *testing.T) {
 	}
 
 	// Count should be less than total
-	totalCount, _ := ds.GetBooksCount("")
+	totalCount, _ := ds.GetBooksCount(mockapi.BookQuery{})
 	if count >= totalCount {
 		t.Errorf("Expected search count %d to be less than total %d", count, totalCount)
 	}
 }
 
+func TestGetBooks_SortByTitleDesc(t *testing.T) {
+	db := setupTestDB(t)
+	ds := Create(db)
+
+	err := ds.PopulateData()
+	if err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+
+	books, err := ds.GetBooks(mockapi.BookQuery{Page: 1, PageSize: 50, Sort: "title", Order: mockapi.OrderDesc})
+	if err != nil {
+		t.Fatalf("GetBooks with sort failed: %v", err)
+	}
+
+	for i := 1; i < len(books); i++ {
+		if books[i-1].Title < books[i].Title {
+			t.Fatalf("Expected books sorted by title desc, got %s before %s", books[i-1].Title, books[i].Title)
+		}
+	}
+}
+
+func TestGetBooks_FilterByCategory(t *testing.T) {
+	db := setupTestDB(t)
+	ds := Create(db)
+
+	err := ds.PopulateData()
+	if err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+
+	query := mockapi.BookQuery{Page: 1, PageSize: 50, Category: []string{"DevOps", "Database"}}
+	books, err := ds.GetBooks(query)
+	if err != nil {
+		t.Fatalf("GetBooks with category filter failed: %v", err)
+	}
+
+	if len(books) != 9 {
+		t.Errorf("Expected 9 books, got %d", len(books))
+	}
+	for _, book := range books {
+		if book.Category != "DevOps" && book.Category != "Database" {
+			t.Errorf("Unexpected category %s", book.Category)
+		}
+	}
+
+	count, _ := ds.GetBooksCount(query)
+	if count != 9 {
+		t.Errorf("Expected count 9, got %d", count)
+	}
+}
+
+func TestGetBooks_FilterByAuthorAndSearch(t *testing.T) {
+	db := setupTestDB(t)
+	ds := Create(db)
+
+	err := ds.PopulateData()
+	if err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+
+	query := mockapi.BookQuery{Page: 1, PageSize: 10, Search: "Martin", Author: []string{"Martin Fowler"}}
+	books, err := ds.GetBooks(query)
+	if err != nil {
+		t.Fatalf("GetBooks with author filter failed: %v", err)
+	}
+
+	if len(books) != 1 || books[0].Title != "Refactoring" {
+		t.Errorf("Expected only Refactoring, got %+v", books)
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && (s[:len(substr)] == substr || contains(s[1:], substr)))
 }
+
+func TestCreateBook(t *testing.T) {
+	db := setupTestDB(t)
+	ds := Create(db)
+
+	err := ds.PopulateData()
+	if err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+
+	book, err := ds.CreateBook(mockapi.Book{Title: "New Book", Author: "New Author"})
+	if err != nil {
+		t.Fatalf("CreateBook failed: %v", err)
+	}
+	if book.ID != 51 {
+		t.Errorf("Expected new book ID 51, got %d", book.ID)
+	}
+
+	count, _ := ds.GetBooksCount(mockapi.BookQuery{})
+	if count != 51 {
+		t.Errorf("Expected count 51, got %d", count)
+	}
+}
+
+func TestUpdateBook(t *testing.T) {
+	db := setupTestDB(t)
+	ds := Create(db)
+
+	err := ds.PopulateData()
+	if err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+
+	_, err = ds.UpdateBook("1", mockapi.Book{Title: "Updated", Author: "Someone"})
+	if err != nil {
+		t.Fatalf("UpdateBook failed: %v", err)
+	}
+
+	book, _ := ds.GetBookByID("1")
+	if book.Title != "Updated" {
+		t.Errorf("Expected title Updated, got %s", book.Title)
+	}
+	if book.Category != "" {
+		t.Errorf("Expected category to be cleared by full update, got %s", book.Category)
+	}
+}
+
+func TestUpdateBook_NotFound(t *testing.T) {
+	db := setupTestDB(t)
+	ds := Create(db)
+
+	err := ds.PopulateData()
+	if err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+
+	_, err = ds.UpdateBook("9999", mockapi.Book{Title: "Updated", Author: "Someone"})
+	if _, ok := err.(*mockapi.NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %v", err)
+	}
+}
+
+func TestPatchBook(t *testing.T) {
+	db := setupTestDB(t)
+	ds := Create(db)
+
+	err := ds.PopulateData()
+	if err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+
+	desc := "Patched description"
+	book, err := ds.PatchBook("1", mockapi.BookPatch{Desc: &desc})
+	if err != nil {
+		t.Fatalf("PatchBook failed: %v", err)
+	}
+	if book.Desc != desc {
+		t.Errorf("Expected desc %s, got %s", desc, book.Desc)
+	}
+	if book.Title != "The Go Programming Language" {
+		t.Errorf("Expected title to stay unchanged, got %s", book.Title)
+	}
+}
+
+func TestDeleteBook(t *testing.T) {
+	db := setupTestDB(t)
+	ds := Create(db)
+
+	err := ds.PopulateData()
+	if err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+
+	if err := ds.DeleteBook("1"); err != nil {
+		t.Fatalf("DeleteBook failed: %v", err)
+	}
+	if _, err := ds.GetBookByID("1"); err == nil {
+		t.Error("Expected error for deleted book")
+	}
+
+	err = ds.DeleteBook("1")
+	if _, ok := err.(*mockapi.NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %v", err)
+	}
+}
+
+func TestGetBooksByCursor_StableUnderInsertAndDelete(t *testing.T) {
+	db := setupTestDB(t)
+	ds := Create(db)
+
+	err := ds.PopulateData()
+	if err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+
+	query := mockapi.BookQuery{PageSize: 5, Sort: "title", Order: mockapi.OrderAsc}
+	first, err := ds.GetBooksByCursor(query, nil)
+	if err != nil {
+		t.Fatalf("GetBooksByCursor failed: %v", err)
+	}
+	if len(first) != 5 {
+		t.Fatalf("Expected 5 books, got %d", len(first))
+	}
+
+	// Changes before the cursor must not shift the next page
+	if err := ds.DeleteBook(strconv.Itoa(first[0].ID)); err != nil {
+		t.Fatalf("DeleteBook failed: %v", err)
+	}
+	if _, err := ds.CreateBook(mockapi.Book{Title: "AAA First", Author: "Someone"}); err != nil {
+		t.Fatalf("CreateBook failed: %v", err)
+	}
+
+	cursor := mockapi.NewBookCursor(query, first[4], false)
+	next, err := ds.GetBooksByCursor(query, &cursor)
+	if err != nil {
+		t.Fatalf("GetBooksByCursor with cursor failed: %v", err)
+	}
+	all, _ := ds.GetBooks(mockapi.BookQuery{Page: 1, PageSize: 100, Sort: "title"})
+	for i := range all {
+		if all[i].ID == first[4].ID {
+			if next[0].ID != all[i+1].ID {
+				t.Errorf("Expected next page to start at %s, got %s", all[i+1].Title, next[0].Title)
+			}
+		}
+	}
+
+	before := mockapi.NewBookCursor(query, next[0], true)
+	prev, err := ds.GetBooksByCursor(query, &before)
+	if err != nil {
+		t.Fatalf("GetBooksByCursor backwards failed: %v", err)
+	}
+	if prev[0].ID != first[4].ID {
+		t.Errorf("Expected paging back to start at %s, got %s", first[4].Title, prev[0].Title)
+	}
+}
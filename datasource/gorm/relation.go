@@ -0,0 +1,210 @@
+package gormsql
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/anggaaryas/go-mockapi"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// authorRecord is the authors table. Books keep their author as a plain
+// name, so the association joins books.author to authors.name. It has no
+// foreign key constraint, as books are written before their author.
+type authorRecord struct {
+	ID    int
+	Name  string         `gorm:"uniqueIndex"`
+	Books []mockapi.Book `gorm:"foreignKey:Author;references:Name;constraint:-"`
+}
+
+func (authorRecord) TableName() string {
+	return "authors"
+}
+
+func (r authorRecord) toAuthor() mockapi.Author {
+	return mockapi.Author{ID: r.ID, Name: r.Name}
+}
+
+// categoryRecord is the categories table, joined to books by name like authorRecord.
+type categoryRecord struct {
+	ID    int
+	Name  string         `gorm:"uniqueIndex"`
+	Books []mockapi.Book `gorm:"foreignKey:Category;references:Name;constraint:-"`
+}
+
+func (categoryRecord) TableName() string {
+	return "categories"
+}
+
+func (r categoryRecord) toCategory() mockapi.Category {
+	return mockapi.Category{ID: r.ID, Name: r.Name}
+}
+
+// reviewRecord is the reviews table. Each review belongs to a book.
+type reviewRecord struct {
+	ID       int
+	BookID   int          `gorm:"index"`
+	Book     mockapi.Book `gorm:"constraint:OnDelete:CASCADE"`
+	Reviewer string
+	Rating   int
+	Comment  string
+}
+
+func (reviewRecord) TableName() string {
+	return "reviews"
+}
+
+func newReviewRecord(review mockapi.Review) reviewRecord {
+	return reviewRecord{
+		ID:       review.ID,
+		BookID:   review.BookID,
+		Reviewer: review.Reviewer,
+		Rating:   review.Rating,
+		Comment:  review.Comment,
+	}
+}
+
+func (r reviewRecord) toReview() mockapi.Review {
+	return mockapi.Review{
+		ID:       r.ID,
+		BookID:   r.BookID,
+		Reviewer: r.Reviewer,
+		Rating:   r.Rating,
+		Comment:  r.Comment,
+	}
+}
+
+// populateRelated fills the authors, categories and reviews tables from the
+// books when they are empty, which also upgrades databases created before
+// those tables existed.
+func populateRelated(tx *gorm.DB) error {
+	var books []mockapi.Book
+	if err := tx.Order("id").Find(&books).Error; err != nil {
+		return err
+	}
+
+	var authors []authorRecord
+	for _, author := range mockapi.GetInitialAuthors(books) {
+		authors = append(authors, authorRecord{ID: author.ID, Name: author.Name})
+	}
+	var categories []categoryRecord
+	for _, category := range mockapi.GetInitialCategories(books) {
+		categories = append(categories, categoryRecord{ID: category.ID, Name: category.Name})
+	}
+	var reviews []reviewRecord
+	for _, review := range mockapi.GetInitialReviews(books) {
+		reviews = append(reviews, newReviewRecord(review))
+	}
+
+	if err := createIfEmpty(tx, &authorRecord{}, &authors, len(authors)); err != nil {
+		return err
+	}
+	if err := createIfEmpty(tx, &categoryRecord{}, &categories, len(categories)); err != nil {
+		return err
+	}
+	return createIfEmpty(tx, &reviewRecord{}, &reviews, len(reviews))
+}
+
+func createIfEmpty(tx *gorm.DB, model any, records any, n int) error {
+	if n == 0 {
+		return nil
+	}
+	var count int64
+	if err := tx.Model(model).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	return tx.Omit(clause.Associations).CreateInBatches(records, insertBatchSize).Error
+}
+
+// addRelated adds the author and category of book when they are new.
+func addRelated(tx *gorm.DB, book mockapi.Book) error {
+	if book.Author != "" {
+		if err := tx.Where(authorRecord{Name: book.Author}).FirstOrCreate(&authorRecord{}).Error; err != nil {
+			return err
+		}
+	}
+	if book.Category != "" {
+		if err := tx.Where(categoryRecord{Name: book.Category}).FirstOrCreate(&categoryRecord{}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ds *dataSource) GetAuthors() ([]mockapi.Author, error) {
+	var records []authorRecord
+	if err := ds.db.Order("id").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	authors := make([]mockapi.Author, 0, len(records))
+	for _, record := range records {
+		authors = append(authors, record.toAuthor())
+	}
+	return authors, nil
+}
+
+func (ds *dataSource) GetAuthorByID(id string) (mockapi.Author, error) {
+	var record authorRecord
+	if err := ds.db.First(&record, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return mockapi.Author{}, mockapi.NewAuthorNotFoundError(id)
+		}
+		return mockapi.Author{}, err
+	}
+	return record.toAuthor(), nil
+}
+
+func (ds *dataSource) GetCategories() ([]mockapi.Category, error) {
+	var records []categoryRecord
+	if err := ds.db.Order("id").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	categories := make([]mockapi.Category, 0, len(records))
+	for _, record := range records {
+		categories = append(categories, record.toCategory())
+	}
+	return categories, nil
+}
+
+func (ds *dataSource) GetCategoryByID(id string) (mockapi.Category, error) {
+	var record categoryRecord
+	if err := ds.db.First(&record, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return mockapi.Category{}, mockapi.NewCategoryNotFoundError(id)
+		}
+		return mockapi.Category{}, err
+	}
+	return record.toCategory(), nil
+}
+
+func (ds *dataSource) GetReviews(bookID string) ([]mockapi.Review, error) {
+	var records []reviewRecord
+	if err := ds.db.Where("book_id = ?", bookID).Order("id").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	reviews := make([]mockapi.Review, 0, len(records))
+	for _, record := range records {
+		reviews = append(reviews, record.toReview())
+	}
+	return reviews, nil
+}
+
+func (ds *dataSource) CreateReview(review mockapi.Review) (mockapi.Review, error) {
+	var count int64
+	if err := ds.db.Model(&mockapi.Book{}).Where("id = ?", review.BookID).Count(&count).Error; err != nil {
+		return mockapi.Review{}, err
+	}
+	if count == 0 {
+		return mockapi.Review{}, mockapi.NewBookNotFoundError(strconv.Itoa(review.BookID))
+	}
+
+	record := newReviewRecord(review)
+	if err := ds.db.Omit(clause.Associations).Create(&record).Error; err != nil {
+		return mockapi.Review{}, err
+	}
+	return record.toReview(), nil
+}
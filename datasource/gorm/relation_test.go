@@ -0,0 +1,222 @@
+package gormsql
+
+import (
+	"testing"
+
+	"github.com/anggaaryas/go-mockapi"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupPopulatedDataSource(t *testing.T) mockapi.DataSource {
+	ds := Create(setupTestDB(t))
+	if err := ds.PopulateData(); err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+	return ds
+}
+
+func TestPopulateData_Related(t *testing.T) {
+	db := setupTestDB(t)
+	ds := Create(db)
+	if err := ds.PopulateData(); err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+
+	books := getInitialBooks()
+	authors, _ := ds.GetAuthors()
+	if len(authors) != len(mockapi.GetInitialAuthors(books)) {
+		t.Errorf("Expected %d authors, got %d", len(mockapi.GetInitialAuthors(books)), len(authors))
+	}
+	categories, _ := ds.GetCategories()
+	if len(categories) != len(mockapi.GetInitialCategories(books)) {
+		t.Errorf("Expected %d categories, got %d", len(mockapi.GetInitialCategories(books)), len(categories))
+	}
+
+	var reviewCount int64
+	db.Model(&reviewRecord{}).Count(&reviewCount)
+	if reviewCount != 100 {
+		t.Errorf("Expected 100 reviews, got %d", reviewCount)
+	}
+
+	// Populating again does not duplicate anything
+	if err := ds.PopulateData(); err != nil {
+		t.Fatalf("Second PopulateData failed: %v", err)
+	}
+	db.Model(&reviewRecord{}).Count(&reviewCount)
+	if reviewCount != 100 {
+		t.Errorf("Expected 100 reviews after second PopulateData, got %d", reviewCount)
+	}
+}
+
+func TestAuthorBooksAssociation(t *testing.T) {
+	db := setupTestDB(t)
+	if err := Create(db).PopulateData(); err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+
+	var author authorRecord
+	if err := db.Preload("Books").First(&author, "name = ?", "Martin Fowler").Error; err != nil {
+		t.Fatalf("Failed to load author: %v", err)
+	}
+	if len(author.Books) != 1 || author.Books[0].Title != "Refactoring" {
+		t.Errorf("Expected Martin Fowler to have written Refactoring, got %+v", author.Books)
+	}
+
+	var category categoryRecord
+	if err := db.Preload("Books").First(&category, "name = ?", "DevOps").Error; err != nil {
+		t.Fatalf("Failed to load category: %v", err)
+	}
+	if len(category.Books) != 5 {
+		t.Errorf("Expected 5 DevOps books, got %d", len(category.Books))
+	}
+}
+
+func TestGetAuthorByID(t *testing.T) {
+	ds := setupPopulatedDataSource(t)
+
+	author, err := ds.GetAuthorByID("1")
+	if err != nil {
+		t.Fatalf("GetAuthorByID failed: %v", err)
+	}
+	if author.Name != "Alan A. A. Donovan" {
+		t.Errorf("Expected 'Alan A. A. Donovan', got '%s'", author.Name)
+	}
+
+	_, err = ds.GetAuthorByID("9999")
+	if _, ok := err.(*mockapi.NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %v", err)
+	}
+	_, err = ds.GetCategoryByID("9999")
+	if _, ok := err.(*mockapi.NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %v", err)
+	}
+}
+
+func TestCreateBook_AddsRelated(t *testing.T) {
+	ds := setupPopulatedDataSource(t)
+	authors, _ := ds.GetAuthors()
+
+	if _, err := ds.CreateBook(mockapi.Book{Title: "New Book", Author: "New Author", Category: "New Category"}); err != nil {
+		t.Fatalf("CreateBook failed: %v", err)
+	}
+	if _, err := ds.PatchBook("1", mockapi.BookPatch{}); err != nil {
+		t.Fatalf("PatchBook failed: %v", err)
+	}
+
+	authorsAfter, _ := ds.GetAuthors()
+	if len(authorsAfter) != len(authors)+1 || authorsAfter[len(authorsAfter)-1].Name != "New Author" {
+		t.Errorf("Expected only New Author to be added, got %d authors", len(authorsAfter))
+	}
+	categories, _ := ds.GetCategories()
+	if categories[len(categories)-1].Name != "New Category" {
+		t.Errorf("Expected New Category to be added, got %+v", categories[len(categories)-1])
+	}
+}
+
+func TestReviews(t *testing.T) {
+	ds := setupPopulatedDataSource(t)
+
+	reviews, err := ds.GetReviews("1")
+	if err != nil {
+		t.Fatalf("GetReviews failed: %v", err)
+	}
+	if len(reviews) != 2 {
+		t.Fatalf("Expected 2 seeded reviews, got %d", len(reviews))
+	}
+
+	review, err := ds.CreateReview(mockapi.Review{BookID: 1, Reviewer: "Tester", Rating: 5, Comment: "Great"})
+	if err != nil {
+		t.Fatalf("CreateReview failed: %v", err)
+	}
+	if review.ID != 101 {
+		t.Errorf("Expected review ID 101, got %d", review.ID)
+	}
+	_, err = ds.CreateReview(mockapi.Review{BookID: 9999, Reviewer: "Tester", Rating: 5})
+	if _, ok := err.(*mockapi.NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %v", err)
+	}
+
+	// Deleting a book deletes its reviews
+	if err := ds.DeleteBook("1"); err != nil {
+		t.Fatalf("DeleteBook failed: %v", err)
+	}
+	reviews, _ = ds.GetReviews("1")
+	if len(reviews) != 0 {
+		t.Errorf("Expected no reviews after deleting the book, got %d", len(reviews))
+	}
+	if err := ds.DeleteBook("1"); err == nil {
+		t.Error("Expected error deleting a missing book")
+	}
+}
+
+// setupForeignKeyTestDB returns a database that enforces foreign keys, like
+// Postgres and MySQL do by default.
+func setupForeignKeyTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:?_foreign_keys=on"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	return db
+}
+
+func TestForeignKeys(t *testing.T) {
+	ds := Create(setupForeignKeyTestDB(t))
+	if err := ds.PopulateData(); err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+
+	if _, err := ds.CreateBook(mockapi.Book{ID: 100, Title: "New Book", Author: "New Author", Category: "New Category"}); err != nil {
+		t.Errorf("CreateBook failed: %v", err)
+	}
+	if _, err := ds.UpdateBook("100", mockapi.Book{ID: 100, Title: "New Book", Author: "Other Author", Category: "Other Category"}); err != nil {
+		t.Errorf("UpdateBook failed: %v", err)
+	}
+	if _, err := ds.CreateReview(mockapi.Review{BookID: 100, Reviewer: "Reviewer", Rating: 5}); err != nil {
+		t.Errorf("CreateReview failed: %v", err)
+	}
+	if err := ds.DeleteBook("100"); err != nil {
+		t.Errorf("DeleteBook failed: %v", err)
+	}
+
+	snapshot := ds.(mockapi.SnapshotDataSource)
+	data, err := snapshot.ExportData()
+	if err != nil {
+		t.Fatalf("ExportData failed: %v", err)
+	}
+	if err := snapshot.ResetData([]mockapi.Book{{ID: 1, Title: "Only Book", Author: "Solo Author", Category: "Solo Category"}}); err != nil {
+		t.Errorf("ResetData failed: %v", err)
+	}
+	if err := snapshot.ImportData(data); err != nil {
+		t.Errorf("ImportData failed: %v", err)
+	}
+}
+
+// legacyAuthorRecord is authorRecord as earlier versions migrated it, with a
+// foreign key constraint from books to authors.
+type legacyAuthorRecord struct {
+	ID    int
+	Name  string         `gorm:"uniqueIndex"`
+	Books []mockapi.Book `gorm:"foreignKey:Author;references:Name"`
+}
+
+func (legacyAuthorRecord) TableName() string {
+	return "authors"
+}
+
+func TestPopulateData_DropsNameConstraints(t *testing.T) {
+	db := setupForeignKeyTestDB(t)
+	if err := db.AutoMigrate(&mockapi.Book{}, &legacyAuthorRecord{}); err != nil {
+		t.Fatalf("AutoMigrate failed: %v", err)
+	}
+	if !db.Migrator().HasConstraint(&mockapi.Book{}, "fk_authors_books") {
+		t.Fatal("Expected the legacy schema to have fk_authors_books")
+	}
+
+	if err := Create(db).PopulateData(); err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+	if db.Migrator().HasConstraint(&mockapi.Book{}, "fk_authors_books") {
+		t.Error("Expected fk_authors_books to be dropped")
+	}
+}
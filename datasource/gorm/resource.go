@@ -0,0 +1,115 @@
+package gormsql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anggaaryas/go-mockapi"
+	"gorm.io/gorm"
+)
+
+type resourceDataSource[T any] struct {
+	db      *gorm.DB
+	schema  *mockapi.ResourceSchema
+	columns map[string]string
+}
+
+// CreateResource returns a ResourceDataSource storing a generic resource in
+// the table GORM derives from T.
+func CreateResource[T any](db *gorm.DB) mockapi.ResourceDataSource[T] {
+	return &resourceDataSource[T]{
+		db: db,
+	}
+}
+
+func (ds *resourceDataSource[T]) PopulateData(schema *mockapi.ResourceSchema, seed []T) error {
+	if err := ds.db.AutoMigrate(new(T)); err != nil {
+		return err
+	}
+
+	// Queries use JSON field names, so map them to the columns GORM chose.
+	stmt := &gorm.Statement{DB: ds.db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return err
+	}
+	columns := map[string]string{}
+	for _, field := range schema.Fields {
+		if f := stmt.Schema.LookUpField(field.GoName); f != nil && f.DBName != "" {
+			columns[field.Name] = f.DBName
+		}
+	}
+	if _, ok := columns["id"]; !ok {
+		return fmt.Errorf("resource %s: id is not stored in a column", schema.Name)
+	}
+	ds.schema = schema
+	ds.columns = columns
+
+	return ds.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(new(T)).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 || len(seed) == 0 {
+			return nil
+		}
+		return tx.Create(&seed).Error
+	})
+}
+
+func (ds *resourceDataSource[T]) GetByID(id string) (T, error) {
+	var item T
+	if err := ds.db.Where(ds.columns["id"]+" = ?", id).First(&item).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return item, mockapi.NewResourceNotFoundError(ds.schema.Name, id)
+		}
+		return item, err
+	}
+	return item, nil
+}
+
+func (ds *resourceDataSource[T]) filter(db *gorm.DB, query mockapi.ResourceQuery) *gorm.DB {
+	if query.Search == "" {
+		return db
+	}
+	var conditions []string
+	var args []any
+	for _, field := range ds.schema.SearchFields {
+		if column, ok := ds.columns[field]; ok {
+			conditions = append(conditions, column+` LIKE ? ESCAPE '\'`)
+			args = append(args, containsPattern(query.Search))
+		}
+	}
+	if len(conditions) == 0 {
+		return db
+	}
+	return db.Where(strings.Join(conditions, " OR "), args...)
+}
+
+func (ds *resourceDataSource[T]) List(query mockapi.ResourceQuery) ([]T, error) {
+	query = query.WithDefaults()
+	column, ok := ds.columns[query.Sort]
+	if !ok || !ds.schema.IsSortField(query.Sort) || !mockapi.IsSortOrder(query.Order) {
+		return nil, mockapi.NewUnknownSortFieldError(query.Sort)
+	}
+
+	db := ds.filter(ds.db.Model(new(T)), query).Order(column + " " + query.Order)
+	if query.Sort != "id" {
+		db = db.Order(ds.columns["id"] + " " + mockapi.OrderAsc)
+	}
+
+	items := []T{}
+	offset := (query.Page - 1) * query.PageSize
+	if err := db.Offset(offset).Limit(query.PageSize).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (ds *resourceDataSource[T]) Count(query mockapi.ResourceQuery) (int64, error) {
+	var count int64
+	if err := ds.filter(ds.db.Model(new(T)), query).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,95 @@
+package gormsql
+
+import (
+	"testing"
+
+	"github.com/anggaaryas/go-mockapi"
+)
+
+type product struct {
+	SKU   string  `json:"id" gorm:"primaryKey"`
+	Name  string  `json:"name"`
+	Brand string  `json:"brand"`
+	Price float64 `json:"price"`
+}
+
+func setupTestResource(t *testing.T) *mockapi.Resource[product] {
+	resource, err := mockapi.NewResource(mockapi.ResourceConfig[product]{
+		Name:       "products",
+		DataSource: CreateResource[product](setupTestDB(t)),
+		Seed: []product{
+			{SKU: "KB-1", Name: "Mechanical Keyboard", Brand: "Keychron", Price: 89},
+			{SKU: "MS-1", Name: "Wireless Mouse", Brand: "Logitech", Price: 25},
+			{SKU: "HB-1", Name: "USB-C Hub", Brand: "Anker", Price: 25},
+			{SKU: "MA-1", Name: "Monitor Arm", Brand: "Ergotron", Price: 120},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewResource failed: %v", err)
+	}
+	if err := resource.PopulateData(); err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+	return resource
+}
+
+func TestResource_PopulateData_AlreadyPopulated(t *testing.T) {
+	resource := setupTestResource(t)
+
+	if err := resource.PopulateData(); err != nil {
+		t.Fatalf("Second PopulateData failed: %v", err)
+	}
+	result, _ := resource.List(mockapi.ResourceQuery{PageSize: 10})
+	if result.TotalItems != 4 {
+		t.Errorf("Expected 4 products, got %d", result.TotalItems)
+	}
+}
+
+func TestResource_GetByID(t *testing.T) {
+	resource := setupTestResource(t)
+
+	item, err := resource.Get("MS-1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if item.Name != "Wireless Mouse" {
+		t.Errorf("Expected 'Wireless Mouse', got '%s'", item.Name)
+	}
+
+	_, err = resource.Get("XX-9")
+	if _, ok := err.(*mockapi.NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %v", err)
+	}
+}
+
+func TestResource_SearchSortAndPaginate(t *testing.T) {
+	resource := setupTestResource(t)
+
+	result, err := resource.List(mockapi.ResourceQuery{PageSize: 10, Search: "mo"})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if result.TotalItems != 2 {
+		t.Errorf("Expected 2 matches for 'mo', got %d", result.TotalItems)
+	}
+
+	result, _ = resource.List(mockapi.ResourceQuery{PageSize: 10, Search: "_"})
+	if result.TotalItems != 0 {
+		t.Errorf("Expected no matches for '_', got %d", result.TotalItems)
+	}
+
+	// Ties on price are broken by ID
+	result, _ = resource.List(mockapi.ResourceQuery{PageSize: 3, Sort: "price", Order: mockapi.OrderDesc})
+	ids := []string{}
+	for _, item := range result.Data {
+		ids = append(ids, item.SKU)
+	}
+	if len(ids) != 3 || ids[0] != "MA-1" || ids[1] != "KB-1" || ids[2] != "HB-1" {
+		t.Errorf("Expected IDs [MA-1 KB-1 HB-1], got %v", ids)
+	}
+
+	result, _ = resource.List(mockapi.ResourceQuery{Page: 2, PageSize: 3})
+	if len(result.Data) != 1 || result.Data[0].SKU != "MS-1" || result.TotalPages != 2 {
+		t.Errorf("Unexpected second page %+v", result)
+	}
+}
@@ -0,0 +1,5 @@
+module github.com/anggaaryas/go-mockapi/datasource/memory
+
+go 1.25.1
+
+require github.com/anggaaryas/go-mockapi v0.1.3
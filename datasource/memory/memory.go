@@ -0,0 +1,449 @@
+package memory
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/anggaaryas/go-mockapi"
+)
+
+type dataSource struct {
+	mu         sync.RWMutex
+	books      []mockapi.Book
+	authors    []mockapi.Author
+	categories []mockapi.Category
+	reviews    []mockapi.Review
+	seed       []mockapi.Book
+}
+
+func Create() mockapi.DataSource {
+	return &dataSource{}
+}
+
+// CreateWithSeed returns a DataSource whose PopulateData loads seed, e.g.
+// books read with mockapi.LoadBooksFile, instead of the built-in dataset.
+func CreateWithSeed(seed []mockapi.Book) mockapi.DataSource {
+	return &dataSource{
+		seed: seed,
+	}
+}
+
+// foldASCII lowercases ASCII letters only, matching the case-insensitivity of SQLite's LIKE.
+func foldASCII(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'A' && r <= 'Z' {
+			return r + ('a' - 'A')
+		}
+		return r
+	}, s)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func matches(book mockapi.Book, query mockapi.BookQuery) bool {
+	if len(query.Category) > 0 && !contains(query.Category, book.Category) {
+		return false
+	}
+	if len(query.Author) > 0 && !contains(query.Author, book.Author) {
+		return false
+	}
+	if query.Search == "" {
+		return true
+	}
+	pattern := foldASCII(query.Search)
+	return strings.Contains(foldASCII(book.Title), pattern) || strings.Contains(foldASCII(book.Author), pattern)
+}
+
+// compareBooks orders two books by the given sort field and order, breaking
+// ties by ascending ID like the GORM data source.
+func compareBooks(a mockapi.Book, b mockapi.Book, field string, order string) int {
+	if field != "id" {
+		c := strings.Compare(mockapi.BookSortValue(a, field), mockapi.BookSortValue(b, field))
+		if c != 0 {
+			if order == mockapi.OrderDesc {
+				return -c
+			}
+			return c
+		}
+		return a.ID - b.ID
+	}
+	if order == mockapi.OrderDesc {
+		return b.ID - a.ID
+	}
+	return a.ID - b.ID
+}
+
+func sortBooks(books []mockapi.Book, query mockapi.BookQuery) {
+	query = query.WithDefaults()
+	if !mockapi.IsBookSortField(query.Sort) || !mockapi.IsSortOrder(query.Order) {
+		return
+	}
+	sort.SliceStable(books, func(i, j int) bool {
+		return compareBooks(books[i], books[j], query.Sort, query.Order) < 0
+	})
+}
+
+// cursorBook rebuilds enough of the book at the cursor position to compare other books against it.
+func cursorBook(cursor mockapi.BookCursor) mockapi.Book {
+	book := mockapi.Book{ID: cursor.ID}
+	switch cursor.Sort {
+	case "title":
+		book.Title = cursor.Value
+	case "author":
+		book.Author = cursor.Value
+	case "category":
+		book.Category = cursor.Value
+	}
+	return book
+}
+
+func (ds *dataSource) indexOf(id string) int {
+	bookID, err := strconv.Atoi(id)
+	if err != nil {
+		return -1
+	}
+	i := sort.Search(len(ds.books), func(i int) bool {
+		return ds.books[i].ID >= bookID
+	})
+	if i < len(ds.books) && ds.books[i].ID == bookID {
+		return i
+	}
+	return -1
+}
+
+func (ds *dataSource) PopulateData() error {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if len(ds.books) > 0 {
+		return nil
+	}
+	ds.load(ds.seed)
+	return nil
+}
+
+// ResetData replaces all books and related records with seed, or with the
+// seed the data source was created with when seed is nil.
+func (ds *dataSource) ResetData(seed []mockapi.Book) error {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if seed == nil {
+		seed = ds.seed
+	}
+	ds.load(seed)
+	return nil
+}
+
+// ExportData returns a copy of every record.
+func (ds *dataSource) ExportData() (mockapi.Dataset, error) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	return mockapi.Dataset{
+		Books:      slices.Clone(ds.books),
+		Authors:    slices.Clone(ds.authors),
+		Categories: slices.Clone(ds.categories),
+		Reviews:    slices.Clone(ds.reviews),
+	}, nil
+}
+
+// ImportData replaces every record with data.
+func (ds *dataSource) ImportData(data mockapi.Dataset) error {
+	if err := mockapi.ValidateDataset(&data); err != nil {
+		return err
+	}
+
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	ds.books = slices.Clone(data.Books)
+	sort.Slice(ds.books, func(i, j int) bool {
+		return ds.books[i].ID < ds.books[j].ID
+	})
+	ds.authors = slices.Clone(data.Authors)
+	ds.categories = slices.Clone(data.Categories)
+	ds.reviews = slices.Clone(data.Reviews)
+	sort.Slice(ds.reviews, func(i, j int) bool {
+		return ds.reviews[i].ID < ds.reviews[j].ID
+	})
+	return nil
+}
+
+// load replaces the data with seed. It must be called with ds.mu held.
+func (ds *dataSource) load(seed []mockapi.Book) {
+	books := mockapi.GetInitialBooks()
+	if seed != nil {
+		books = append([]mockapi.Book(nil), seed...)
+	}
+	sort.Slice(books, func(i, j int) bool {
+		return books[i].ID < books[j].ID
+	})
+	ds.books = books
+	ds.authors = mockapi.GetInitialAuthors(books)
+	ds.categories = mockapi.GetInitialCategories(books)
+	ds.reviews = mockapi.GetInitialReviews(books)
+}
+
+// nextID returns the ID after the highest one in records, which may have
+// been imported out of order.
+func nextID[T any](records []T, id func(T) int) int {
+	next := 1
+	for _, record := range records {
+		next = max(next, id(record)+1)
+	}
+	return next
+}
+
+// addRelated adds the author and category of book when they are new, so
+// they can be found through the author and category endpoints.
+func (ds *dataSource) addRelated(book mockapi.Book) {
+	if book.Author != "" && !slices.ContainsFunc(ds.authors, func(a mockapi.Author) bool { return a.Name == book.Author }) {
+		ds.authors = append(ds.authors, mockapi.Author{ID: nextID(ds.authors, func(a mockapi.Author) int { return a.ID }), Name: book.Author})
+	}
+	if book.Category != "" && !slices.ContainsFunc(ds.categories, func(c mockapi.Category) bool { return c.Name == book.Category }) {
+		ds.categories = append(ds.categories, mockapi.Category{ID: nextID(ds.categories, func(c mockapi.Category) int { return c.ID }), Name: book.Category})
+	}
+}
+
+func (ds *dataSource) GetBookByID(id string) (mockapi.Book, error) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	i := ds.indexOf(id)
+	if i < 0 {
+		return mockapi.Book{}, mockapi.NewBookNotFoundError(id)
+	}
+	return ds.books[i], nil
+}
+
+func (ds *dataSource) GetBooks(query mockapi.BookQuery) ([]mockapi.Book, error) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	books := []mockapi.Book{}
+	for _, book := range ds.books {
+		if matches(book, query) {
+			books = append(books, book)
+		}
+	}
+	sortBooks(books, query)
+
+	offset := (query.Page - 1) * query.PageSize
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(books) {
+		offset = len(books)
+	}
+	books = books[offset:]
+	if query.PageSize >= 0 && query.PageSize < len(books) {
+		books = books[:query.PageSize]
+	}
+	return books, nil
+}
+
+func (ds *dataSource) GetBooksCount(query mockapi.BookQuery) (int64, error) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	var count int64
+	for _, book := range ds.books {
+		if matches(book, query) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (ds *dataSource) GetBooksByCursor(query mockapi.BookQuery, cursor *mockapi.BookCursor) ([]mockapi.Book, error) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	books := []mockapi.Book{}
+	for _, book := range ds.books {
+		if matches(book, query) {
+			books = append(books, book)
+		}
+	}
+	sortBooks(books, query)
+
+	if cursor != nil {
+		if !mockapi.IsBookSortField(cursor.Sort) || !mockapi.IsSortOrder(cursor.Order) {
+			return nil, mockapi.NewInvalidCursorError()
+		}
+		position := cursorBook(*cursor)
+		if cursor.Before {
+			// Books before the cursor, nearest first.
+			j := sort.Search(len(books), func(j int) bool {
+				return compareBooks(books[j], position, cursor.Sort, cursor.Order) >= 0
+			})
+			before := books[:j]
+			books = make([]mockapi.Book, 0, len(before))
+			for k := len(before) - 1; k >= 0; k-- {
+				books = append(books, before[k])
+			}
+		} else {
+			i := sort.Search(len(books), func(i int) bool {
+				return compareBooks(books[i], position, cursor.Sort, cursor.Order) > 0
+			})
+			books = books[i:]
+		}
+	}
+
+	if query.PageSize >= 0 && query.PageSize < len(books) {
+		books = books[:query.PageSize]
+	}
+	return books, nil
+}
+
+func (ds *dataSource) CreateBook(book mockapi.Book) (mockapi.Book, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if book.ID == 0 {
+		book.ID = 1
+		if len(ds.books) > 0 {
+			book.ID = ds.books[len(ds.books)-1].ID + 1
+		}
+		ds.books = append(ds.books, book)
+		ds.addRelated(book)
+		return book, nil
+	}
+
+	i := sort.Search(len(ds.books), func(i int) bool {
+		return ds.books[i].ID >= book.ID
+	})
+	if i < len(ds.books) && ds.books[i].ID == book.ID {
+		return mockapi.Book{}, mockapi.NewBookAlreadyExistsError(strconv.Itoa(book.ID))
+	}
+	ds.books = append(ds.books, mockapi.Book{})
+	copy(ds.books[i+1:], ds.books[i:])
+	ds.books[i] = book
+	ds.addRelated(book)
+	return book, nil
+}
+
+func (ds *dataSource) UpdateBook(id string, book mockapi.Book) (mockapi.Book, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	i := ds.indexOf(id)
+	if i < 0 {
+		return mockapi.Book{}, mockapi.NewBookNotFoundError(id)
+	}
+	book.ID = ds.books[i].ID
+	ds.books[i] = book
+	ds.addRelated(book)
+	return book, nil
+}
+
+func (ds *dataSource) PatchBook(id string, patch mockapi.BookPatch) (mockapi.Book, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	i := ds.indexOf(id)
+	if i < 0 {
+		return mockapi.Book{}, mockapi.NewBookNotFoundError(id)
+	}
+	patch.Apply(&ds.books[i])
+	ds.addRelated(ds.books[i])
+	return ds.books[i], nil
+}
+
+func (ds *dataSource) DeleteBook(id string) error {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	i := ds.indexOf(id)
+	if i < 0 {
+		return mockapi.NewBookNotFoundError(id)
+	}
+	bookID := ds.books[i].ID
+	ds.books = append(ds.books[:i], ds.books[i+1:]...)
+	ds.reviews = slices.DeleteFunc(ds.reviews, func(r mockapi.Review) bool {
+		return r.BookID == bookID
+	})
+	return nil
+}
+
+func (ds *dataSource) GetAuthors() ([]mockapi.Author, error) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	return slices.Clone(ds.authors), nil
+}
+
+func (ds *dataSource) GetAuthorByID(id string) (mockapi.Author, error) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	// Imported authors may have any IDs, so they are looked up rather than indexed.
+	authorID, err := strconv.Atoi(id)
+	i := slices.IndexFunc(ds.authors, func(a mockapi.Author) bool { return a.ID == authorID })
+	if err != nil || i < 0 {
+		return mockapi.Author{}, mockapi.NewAuthorNotFoundError(id)
+	}
+	return ds.authors[i], nil
+}
+
+func (ds *dataSource) GetCategories() ([]mockapi.Category, error) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	return slices.Clone(ds.categories), nil
+}
+
+func (ds *dataSource) GetCategoryByID(id string) (mockapi.Category, error) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	categoryID, err := strconv.Atoi(id)
+	i := slices.IndexFunc(ds.categories, func(c mockapi.Category) bool { return c.ID == categoryID })
+	if err != nil || i < 0 {
+		return mockapi.Category{}, mockapi.NewCategoryNotFoundError(id)
+	}
+	return ds.categories[i], nil
+}
+
+func (ds *dataSource) GetReviews(bookID string) ([]mockapi.Review, error) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	id, err := strconv.Atoi(bookID)
+	if err != nil {
+		return nil, mockapi.NewBookNotFoundError(bookID)
+	}
+	reviews := []mockapi.Review{}
+	for _, review := range ds.reviews {
+		if review.BookID == id {
+			reviews = append(reviews, review)
+		}
+	}
+	return reviews, nil
+}
+
+func (ds *dataSource) CreateReview(review mockapi.Review) (mockapi.Review, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.indexOf(strconv.Itoa(review.BookID)) < 0 {
+		return mockapi.Review{}, mockapi.NewBookNotFoundError(strconv.Itoa(review.BookID))
+	}
+	review.ID = 1
+	if len(ds.reviews) > 0 {
+		review.ID = ds.reviews[len(ds.reviews)-1].ID + 1
+	}
+	ds.reviews = append(ds.reviews, review)
+	return review, nil
+}
@@ -0,0 +1,447 @@
+package memory
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/anggaaryas/go-mockapi"
+)
+
+func setupTestDataSource(t *testing.T) mockapi.DataSource {
+	ds := Create()
+	if err := ds.PopulateData(); err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+	return ds
+}
+
+func TestPopulateData_AlreadyPopulated(t *testing.T) {
+	ds := setupTestDataSource(t)
+
+	if _, err := ds.CreateBook(mockapi.Book{Title: "New Book", Author: "New Author"}); err != nil {
+		t.Fatalf("CreateBook failed: %v", err)
+	}
+
+	// Second population should not reset the data
+	if err := ds.PopulateData(); err != nil {
+		t.Fatalf("Second PopulateData failed: %v", err)
+	}
+
+	count, _ := ds.GetBooksCount(mockapi.BookQuery{})
+	if count != 51 {
+		t.Errorf("Expected count 51, got %d", count)
+	}
+}
+
+func TestPopulateData_WithSeed(t *testing.T) {
+	ds := CreateWithSeed([]mockapi.Book{
+		{ID: 9, Title: "Seed Two", Author: "Author Two"},
+		{ID: 3, Title: "Seed One", Author: "Author One"},
+	})
+	if err := ds.PopulateData(); err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+
+	books, _ := ds.GetBooks(mockapi.BookQuery{Page: 1, PageSize: 10})
+	if len(books) != 2 || books[0].ID != 3 || books[1].ID != 9 {
+		t.Fatalf("Expected seeded books 3 and 9, got %+v", books)
+	}
+
+	book, err := ds.CreateBook(mockapi.Book{Title: "New Book", Author: "New Author"})
+	if err != nil {
+		t.Fatalf("CreateBook failed: %v", err)
+	}
+	if book.ID != 10 {
+		t.Errorf("Expected new book ID 10, got %d", book.ID)
+	}
+}
+
+func TestResetData(t *testing.T) {
+	ds := setupTestDataSource(t).(mockapi.ResettableDataSource)
+
+	if err := ds.ResetData([]mockapi.Book{{ID: 5, Title: "Only Book", Author: "Solo Author"}}); err != nil {
+		t.Fatalf("ResetData failed: %v", err)
+	}
+	count, _ := ds.GetBooksCount(mockapi.BookQuery{})
+	authors, _ := ds.GetAuthors()
+	reviews, _ := ds.GetReviews("5")
+	if count != 1 || len(authors) != 1 || len(reviews) != 2 {
+		t.Errorf("Expected 1 book, 1 author and 2 reviews, got %d, %d and %d", count, len(authors), len(reviews))
+	}
+
+	ds.ResetData([]mockapi.Book{})
+	if count, _ := ds.GetBooksCount(mockapi.BookQuery{}); count != 0 {
+		t.Errorf("Expected no books, got %d", count)
+	}
+
+	ds.ResetData(nil)
+	if count, _ := ds.GetBooksCount(mockapi.BookQuery{}); count != 50 {
+		t.Errorf("Expected the 50 built-in books, got %d", count)
+	}
+
+	seeded := CreateWithSeed([]mockapi.Book{{ID: 1, Title: "Seed Book", Author: "Seed Author"}}).(mockapi.ResettableDataSource)
+	seeded.PopulateData()
+	seeded.DeleteBook("1")
+	seeded.ResetData(nil)
+	if book, err := seeded.GetBookByID("1"); err != nil || book.Title != "Seed Book" {
+		t.Errorf("Expected reset to restore the seed, got %+v, %v", book, err)
+	}
+}
+
+func TestExportImportData(t *testing.T) {
+	ds := setupTestDataSource(t).(mockapi.SnapshotDataSource)
+
+	ds.CreateReview(mockapi.Review{BookID: 1, Reviewer: "Tester", Rating: 4})
+	data, err := ds.ExportData()
+	if err != nil {
+		t.Fatalf("ExportData failed: %v", err)
+	}
+	if len(data.Books) != 50 || len(data.Reviews) != 101 {
+		t.Errorf("Expected 50 books and 101 reviews, got %d and %d", len(data.Books), len(data.Reviews))
+	}
+
+	ds.DeleteBook("1")
+	if err := ds.ImportData(data); err != nil {
+		t.Fatalf("ImportData failed: %v", err)
+	}
+	if _, err := ds.GetBookByID("1"); err != nil {
+		t.Errorf("Expected book 1 to be restored, got %v", err)
+	}
+	if reviews, _ := ds.GetReviews("1"); len(reviews) != 3 {
+		t.Errorf("Expected 3 reviews for book 1, got %d", len(reviews))
+	}
+
+	err = ds.ImportData(mockapi.Dataset{
+		Books:   []mockapi.Book{{ID: 7, Title: "Imported", Author: "Importer"}},
+		Authors: []mockapi.Author{{ID: 9, Name: "Importer"}},
+	})
+	if err != nil {
+		t.Fatalf("ImportData failed: %v", err)
+	}
+	ds.CreateBook(mockapi.Book{Title: "New", Author: "Newcomer"})
+	if author, err := ds.GetAuthorByID("10"); err != nil || author.Name != "Newcomer" {
+		t.Errorf("Expected new author to get ID 10, got %+v, %v", author, err)
+	}
+
+	if err := ds.ImportData(mockapi.Dataset{Books: []mockapi.Book{{ID: 1}}}); err == nil {
+		t.Error("Expected error for an invalid dataset")
+	}
+	if count, _ := ds.GetBooksCount(mockapi.BookQuery{}); count != 2 {
+		t.Errorf("Expected an invalid import to leave the data alone, got %d books", count)
+	}
+}
+
+func TestGetBookByID(t *testing.T) {
+	ds := setupTestDataSource(t)
+
+	book, err := ds.GetBookByID("1")
+	if err != nil {
+		t.Fatalf("GetBookByID failed: %v", err)
+	}
+	if book.Title != "The Go Programming Language" {
+		t.Errorf("Expected title The Go Programming Language, got %s", book.Title)
+	}
+
+	for _, id := range []string{"9999", "abc"} {
+		_, err = ds.GetBookByID(id)
+		if _, ok := err.(*mockapi.NotFoundError); !ok {
+			t.Errorf("Expected NotFoundError for id %s, got %v", id, err)
+		}
+	}
+}
+
+func TestGetBooks_Pagination(t *testing.T) {
+	ds := setupTestDataSource(t)
+
+	books, err := ds.GetBooks(mockapi.BookQuery{Page: 2, PageSize: 10})
+	if err != nil {
+		t.Fatalf("GetBooks failed: %v", err)
+	}
+	if len(books) != 10 {
+		t.Fatalf("Expected 10 books, got %d", len(books))
+	}
+	if books[0].ID != 11 {
+		t.Errorf("Expected first book of page 2 to have ID 11, got %d", books[0].ID)
+	}
+
+	books, _ = ds.GetBooks(mockapi.BookQuery{Page: 6, PageSize: 10})
+	if len(books) != 0 {
+		t.Errorf("Expected empty page past the end, got %d books", len(books))
+	}
+}
+
+func TestGetBooks_WithSearch(t *testing.T) {
+	ds := setupTestDataSource(t)
+
+	// Search is case-insensitive and matches both title and author
+	books, err := ds.GetBooks(mockapi.BookQuery{Page: 1, PageSize: 50, Search: "martin"})
+	if err != nil {
+		t.Fatalf("GetBooks with search failed: %v", err)
+	}
+	if len(books) != 3 {
+		t.Fatalf("Expected 3 books, got %d", len(books))
+	}
+
+	count, _ := ds.GetBooksCount(mockapi.BookQuery{Search: "martin"})
+	if count != 3 {
+		t.Errorf("Expected count 3, got %d", count)
+	}
+}
+
+func TestGetBooks_SortAndFilter(t *testing.T) {
+	ds := setupTestDataSource(t)
+
+	query := mockapi.BookQuery{Page: 1, PageSize: 3, Sort: "title", Order: mockapi.OrderDesc, Category: []string{"DevOps", "Database"}}
+	books, err := ds.GetBooks(query)
+	if err != nil {
+		t.Fatalf("GetBooks with sort and filter failed: %v", err)
+	}
+
+	expected := []string{"The DevOps Handbook", "Site Reliability Engineering", "SQL Performance Explained"}
+	if len(books) != len(expected) {
+		t.Fatalf("Expected %d books, got %d", len(expected), len(books))
+	}
+	for i, title := range expected {
+		if books[i].Title != title {
+			t.Errorf("Expected book %d to be %s, got %s", i, title, books[i].Title)
+		}
+	}
+
+	count, _ := ds.GetBooksCount(query)
+	if count != 9 {
+		t.Errorf("Expected count 9, got %d", count)
+	}
+}
+
+func TestGetBooks_SortTiesByID(t *testing.T) {
+	ds := setupTestDataSource(t)
+
+	books, _ := ds.GetBooks(mockapi.BookQuery{Page: 1, PageSize: 50, Sort: "category", Order: mockapi.OrderDesc})
+	for i := 1; i < len(books); i++ {
+		if books[i-1].Category == books[i].Category && books[i-1].ID > books[i].ID {
+			t.Fatalf("Expected ties to be ordered by ID, got %d before %d", books[i-1].ID, books[i].ID)
+		}
+	}
+}
+
+func TestCreateBook(t *testing.T) {
+	ds := setupTestDataSource(t)
+
+	book, err := ds.CreateBook(mockapi.Book{Title: "New Book", Author: "New Author"})
+	if err != nil {
+		t.Fatalf("CreateBook failed: %v", err)
+	}
+	if book.ID != 51 {
+		t.Errorf("Expected new book ID 51, got %d", book.ID)
+	}
+
+	_, err = ds.CreateBook(mockapi.Book{ID: 1, Title: "Duplicate", Author: "Someone"})
+	if _, ok := err.(*mockapi.ConflictError); !ok {
+		t.Errorf("Expected ConflictError, got %v", err)
+	}
+}
+
+func TestUpdateBook(t *testing.T) {
+	ds := setupTestDataSource(t)
+
+	_, err := ds.UpdateBook("1", mockapi.Book{Title: "Updated", Author: "Someone"})
+	if err != nil {
+		t.Fatalf("UpdateBook failed: %v", err)
+	}
+
+	book, _ := ds.GetBookByID("1")
+	if book.Title != "Updated" {
+		t.Errorf("Expected title Updated, got %s", book.Title)
+	}
+	if book.Category != "" {
+		t.Errorf("Expected category to be cleared by full update, got %s", book.Category)
+	}
+
+	_, err = ds.UpdateBook("9999", mockapi.Book{Title: "Updated", Author: "Someone"})
+	if _, ok := err.(*mockapi.NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %v", err)
+	}
+}
+
+func TestPatchBook(t *testing.T) {
+	ds := setupTestDataSource(t)
+
+	desc := "Patched description"
+	book, err := ds.PatchBook("1", mockapi.BookPatch{Desc: &desc})
+	if err != nil {
+		t.Fatalf("PatchBook failed: %v", err)
+	}
+	if book.Desc != desc {
+		t.Errorf("Expected desc %s, got %s", desc, book.Desc)
+	}
+	if book.Title != "The Go Programming Language" {
+		t.Errorf("Expected title to stay unchanged, got %s", book.Title)
+	}
+}
+
+func TestDeleteBook(t *testing.T) {
+	ds := setupTestDataSource(t)
+
+	if err := ds.DeleteBook("1"); err != nil {
+		t.Fatalf("DeleteBook failed: %v", err)
+	}
+	if _, err := ds.GetBookByID("1"); err == nil {
+		t.Error("Expected error for deleted book")
+	}
+
+	err := ds.DeleteBook("1")
+	if _, ok := err.(*mockapi.NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %v", err)
+	}
+}
+
+func TestAuthorsAndCategories(t *testing.T) {
+	ds := setupTestDataSource(t)
+
+	authors, _ := ds.GetAuthors()
+	if len(authors) != len(mockapi.GetInitialAuthors(mockapi.GetInitialBooks())) {
+		t.Errorf("Expected one author per distinct name, got %d", len(authors))
+	}
+	author, err := ds.GetAuthorByID("1")
+	if err != nil {
+		t.Fatalf("GetAuthorByID failed: %v", err)
+	}
+	if author.Name != "Alan A. A. Donovan" {
+		t.Errorf("Expected first author 'Alan A. A. Donovan', got '%s'", author.Name)
+	}
+	if _, err := ds.GetAuthorByID("9999"); err == nil {
+		t.Error("Expected error for missing author")
+	}
+
+	// A book with a new author and category adds both
+	if _, err := ds.CreateBook(mockapi.Book{Title: "New Book", Author: "New Author", Category: "New Category"}); err != nil {
+		t.Fatalf("CreateBook failed: %v", err)
+	}
+	authorsAfter, _ := ds.GetAuthors()
+	if len(authorsAfter) != len(authors)+1 || authorsAfter[len(authorsAfter)-1].Name != "New Author" {
+		t.Errorf("Expected New Author to be added, got %+v", authorsAfter[len(authorsAfter)-1])
+	}
+	categories, _ := ds.GetCategories()
+	category, err := ds.GetCategoryByID(strconv.Itoa(len(categories)))
+	if err != nil || category.Name != "New Category" {
+		t.Errorf("Expected New Category to be added, got %+v, %v", category, err)
+	}
+}
+
+func TestReviews(t *testing.T) {
+	ds := setupTestDataSource(t)
+
+	reviews, err := ds.GetReviews("1")
+	if err != nil {
+		t.Fatalf("GetReviews failed: %v", err)
+	}
+	if len(reviews) != 2 {
+		t.Fatalf("Expected 2 seeded reviews, got %d", len(reviews))
+	}
+
+	review, err := ds.CreateReview(mockapi.Review{BookID: 1, Reviewer: "Tester", Rating: 5})
+	if err != nil {
+		t.Fatalf("CreateReview failed: %v", err)
+	}
+	if review.ID != 101 {
+		t.Errorf("Expected review ID 101, got %d", review.ID)
+	}
+	if _, err := ds.CreateReview(mockapi.Review{BookID: 9999, Reviewer: "Tester", Rating: 5}); err == nil {
+		t.Error("Expected error for review of missing book")
+	}
+
+	// Deleting a book deletes its reviews
+	if err := ds.DeleteBook("1"); err != nil {
+		t.Fatalf("DeleteBook failed: %v", err)
+	}
+	reviews, _ = ds.GetReviews("1")
+	if len(reviews) != 0 {
+		t.Errorf("Expected no reviews after deleting the book, got %d", len(reviews))
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	ds := setupTestDataSource(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			ds.CreateBook(mockapi.Book{Title: "Concurrent", Author: "Writer"})
+		}()
+		go func(id int) {
+			defer wg.Done()
+			ds.GetBookByID(strconv.Itoa(id))
+			ds.GetBooks(mockapi.BookQuery{Page: 1, PageSize: 10, Search: "Concurrent"})
+		}(i + 1)
+	}
+	wg.Wait()
+
+	count, _ := ds.GetBooksCount(mockapi.BookQuery{Search: "Concurrent"})
+	if count != 20 {
+		t.Errorf("Expected 20 created books, got %d", count)
+	}
+}
+
+func TestGetBooksByCursor_MatchesOffsetPagination(t *testing.T) {
+	ds := setupTestDataSource(t)
+
+	for _, query := range []mockapi.BookQuery{
+		{PageSize: 7},
+		{PageSize: 7, Sort: "id", Order: mockapi.OrderDesc},
+		{PageSize: 7, Sort: "category", Order: mockapi.OrderAsc},
+		{PageSize: 7, Sort: "author", Order: mockapi.OrderDesc, Category: []string{"Programming"}},
+	} {
+		all, _ := ds.GetBooks(mockapi.BookQuery{Page: 1, PageSize: 100, Sort: query.Sort, Order: query.Order, Category: query.Category})
+
+		var walked []mockapi.Book
+		var cursor *mockapi.BookCursor
+		for {
+			page, err := ds.GetBooksByCursor(query, cursor)
+			if err != nil {
+				t.Fatalf("GetBooksByCursor failed: %v", err)
+			}
+			if len(page) == 0 {
+				break
+			}
+			walked = append(walked, page...)
+			next := mockapi.NewBookCursor(query, page[len(page)-1], false)
+			cursor = &next
+		}
+
+		if len(walked) != len(all) {
+			t.Fatalf("Expected to walk %d books, got %d", len(all), len(walked))
+		}
+		for i := range all {
+			if walked[i].ID != all[i].ID {
+				t.Fatalf("Expected book %d to be %d, got %d (sort %s %s)", i, all[i].ID, walked[i].ID, query.Sort, query.Order)
+			}
+		}
+
+		// Paging backwards from the end returns the last books, nearest first
+		before := mockapi.NewBookCursor(query, all[len(all)-1], true)
+		back, _ := ds.GetBooksByCursor(query, &before)
+		if back[0].ID != all[len(all)-2].ID {
+			t.Errorf("Expected nearest previous book %d, got %d", all[len(all)-2].ID, back[0].ID)
+		}
+	}
+}
+
+func TestGetBooksByCursor_CursorBookDeleted(t *testing.T) {
+	ds := setupTestDataSource(t)
+
+	query := mockapi.BookQuery{PageSize: 5}
+	first, _ := ds.GetBooksByCursor(query, nil)
+	cursor := mockapi.NewBookCursor(query, first[4], false)
+
+	if err := ds.DeleteBook("5"); err != nil {
+		t.Fatalf("DeleteBook failed: %v", err)
+	}
+
+	next, _ := ds.GetBooksByCursor(query, &cursor)
+	if next[0].ID != 6 {
+		t.Errorf("Expected next page to start at 6, got %d", next[0].ID)
+	}
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/anggaaryas/go-mockapi"
+)
+
+type resourceDataSource[T any] struct {
+	mu     sync.RWMutex
+	schema *mockapi.ResourceSchema
+	items  []T
+}
+
+// CreateResource returns an in-memory ResourceDataSource for a generic resource.
+func CreateResource[T any]() mockapi.ResourceDataSource[T] {
+	return &resourceDataSource[T]{}
+}
+
+// compareValues orders two values of the same string, number or bool kind.
+func compareValues(a reflect.Value, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.String:
+		return strings.Compare(a.String(), b.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.Bool:
+		if a.Bool() == b.Bool() {
+			return 0
+		}
+		if a.Bool() {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
+
+func (ds *resourceDataSource[T]) matches(item T, search string) bool {
+	if search == "" {
+		return true
+	}
+	pattern := foldASCII(search)
+	for _, field := range ds.schema.SearchFields {
+		if strings.Contains(foldASCII(ds.schema.Value(item, field).String()), pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// find returns the matching items sorted by the query's sort field, breaking
+// ties by ascending ID like the book listing.
+func (ds *resourceDataSource[T]) find(query mockapi.ResourceQuery) []T {
+	query = query.WithDefaults()
+	items := []T{}
+	for _, item := range ds.items {
+		if ds.matches(item, query.Search) {
+			items = append(items, item)
+		}
+	}
+	slices.SortStableFunc(items, func(a T, b T) int {
+		c := compareValues(ds.schema.Value(a, query.Sort), ds.schema.Value(b, query.Sort))
+		if query.Order == mockapi.OrderDesc {
+			c = -c
+		}
+		if c == 0 && query.Sort != "id" {
+			c = compareValues(ds.schema.Value(a, "id"), ds.schema.Value(b, "id"))
+		}
+		return c
+	})
+	return items
+}
+
+func (ds *resourceDataSource[T]) PopulateData(schema *mockapi.ResourceSchema, seed []T) error {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	ds.schema = schema
+	if len(ds.items) > 0 {
+		return nil
+	}
+	ds.items = slices.Clone(seed)
+	return nil
+}
+
+func (ds *resourceDataSource[T]) GetByID(id string) (T, error) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	for _, item := range ds.items {
+		if ds.schema.ID(item) == id {
+			return item, nil
+		}
+	}
+	var zero T
+	return zero, mockapi.NewResourceNotFoundError(ds.schema.Name, id)
+}
+
+func (ds *resourceDataSource[T]) List(query mockapi.ResourceQuery) ([]T, error) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	items := ds.find(query)
+	offset := (query.Page - 1) * query.PageSize
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(items) {
+		offset = len(items)
+	}
+	items = items[offset:]
+	if query.PageSize >= 0 && query.PageSize < len(items) {
+		items = items[:query.PageSize]
+	}
+	return items, nil
+}
+
+func (ds *resourceDataSource[T]) Count(query mockapi.ResourceQuery) (int64, error) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	var count int64
+	for _, item := range ds.items {
+		if ds.matches(item, query.Search) {
+			count++
+		}
+	}
+	return count, nil
+}
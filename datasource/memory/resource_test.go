@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/anggaaryas/go-mockapi"
+)
+
+type product struct {
+	ID    int     `json:"id"`
+	Name  string  `json:"name"`
+	Brand string  `json:"brand"`
+	Price float64 `json:"price"`
+}
+
+func setupTestResource(t *testing.T) *mockapi.Resource[product] {
+	resource, err := mockapi.NewResource(mockapi.ResourceConfig[product]{
+		Name:       "products",
+		DataSource: CreateResource[product](),
+		Seed: []product{
+			{ID: 1, Name: "Mechanical Keyboard", Brand: "Keychron", Price: 89},
+			{ID: 2, Name: "Wireless Mouse", Brand: "Logitech", Price: 25},
+			{ID: 3, Name: "USB-C Hub", Brand: "Anker", Price: 25},
+			{ID: 4, Name: "Monitor Arm", Brand: "Ergotron", Price: 120},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewResource failed: %v", err)
+	}
+	if err := resource.PopulateData(); err != nil {
+		t.Fatalf("PopulateData failed: %v", err)
+	}
+	return resource
+}
+
+func TestResource_GetByID(t *testing.T) {
+	resource := setupTestResource(t)
+
+	item, err := resource.Get("2")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if item.Name != "Wireless Mouse" {
+		t.Errorf("Expected 'Wireless Mouse', got '%s'", item.Name)
+	}
+
+	_, err = resource.Get("99")
+	if _, ok := err.(*mockapi.NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %v", err)
+	}
+}
+
+func TestResource_SearchSortAndPaginate(t *testing.T) {
+	resource := setupTestResource(t)
+
+	result, err := resource.List(mockapi.ResourceQuery{PageSize: 10, Search: "mo"})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if result.TotalItems != 2 {
+		t.Errorf("Expected 2 matches for 'mo', got %d", result.TotalItems)
+	}
+
+	// Ties on price are broken by ID
+	result, _ = resource.List(mockapi.ResourceQuery{PageSize: 3, Sort: "price", Order: mockapi.OrderDesc})
+	ids := []int{}
+	for _, item := range result.Data {
+		ids = append(ids, item.ID)
+	}
+	if len(ids) != 3 || ids[0] != 4 || ids[1] != 1 || ids[2] != 2 {
+		t.Errorf("Expected IDs [4 1 2], got %v", ids)
+	}
+
+	result, _ = resource.List(mockapi.ResourceQuery{Page: 2, PageSize: 3})
+	if len(result.Data) != 1 || result.Data[0].ID != 4 || result.TotalPages != 2 {
+		t.Errorf("Unexpected second page %+v", result)
+	}
+}
@@ -1,14 +1,51 @@
 package mockapi
 
 type PaginatedBooks struct {
-	Data       []Book `json:"data"`
-	Page       int    `json:"page"`
-	PageSize   int    `json:"page_size"`
-	TotalItems int64  `json:"total_items"`
-	TotalPages int    `json:"total_pages"`
+	Data       []Book `json:"data" xml:"data>book"`
+	Page       int    `json:"page" xml:"page"`
+	PageSize   int    `json:"page_size" xml:"page_size"`
+	TotalItems int64  `json:"total_items" xml:"total_items"`
+	TotalPages int    `json:"total_pages" xml:"total_pages"`
+}
+
+// CursorBooks is a page of books in cursor pagination mode. NextCursor and
+// PrevCursor are empty when there is no page in that direction.
+type CursorBooks struct {
+	Data       []Book `json:"data" xml:"data>book"`
+	PageSize   int    `json:"page_size" xml:"page_size"`
+	NextCursor string `json:"next_cursor,omitempty" xml:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty" xml:"prev_cursor,omitempty"`
 }
 
 type APIError struct {
-	StatusCode int    `json:"code"`
-	Message    string `json:"message"`
-}
\ No newline at end of file
+	StatusCode int    `json:"code" xml:"code"`
+	Message    string `json:"message" xml:"message"`
+}
+
+// BookPatch holds the fields of a partial book update. Nil fields are left untouched.
+type BookPatch struct {
+	Title    *string `json:"title"`
+	Author   *string `json:"author"`
+	Category *string `json:"category"`
+	Desc     *string `json:"desc"`
+	CoverURL *string `json:"cover_url"`
+}
+
+// Apply copies every non-nil field of the patch onto book.
+func (p BookPatch) Apply(book *Book) {
+	if p.Title != nil {
+		book.Title = *p.Title
+	}
+	if p.Author != nil {
+		book.Author = *p.Author
+	}
+	if p.Category != nil {
+		book.Category = *p.Category
+	}
+	if p.Desc != nil {
+		book.Desc = *p.Desc
+	}
+	if p.CoverURL != nil {
+		book.CoverURL = *p.CoverURL
+	}
+}
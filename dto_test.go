@@ -132,3 +132,21 @@ func TestAPIError_DifferentStatusCodes(t *testing.T) {
 		})
 	}
 }
+
+func TestBookPatch_Apply(t *testing.T) {
+	title := "New Title"
+	category := "New Category"
+	book := Book{ID: 1, Title: "Old Title", Author: "Author", Category: "Old Category"}
+
+	BookPatch{Title: &title, Category: &category}.Apply(&book)
+
+	if book.Title != title {
+		t.Errorf("Expected title %s, got %s", title, book.Title)
+	}
+	if book.Category != category {
+		t.Errorf("Expected category %s, got %s", category, book.Category)
+	}
+	if book.Author != "Author" {
+		t.Errorf("Expected author to stay unchanged, got %s", book.Author)
+	}
+}
@@ -0,0 +1,163 @@
+package mockapi
+
+import (
+	"fmt"
+)
+
+// ValidationError represents an invalid book payload.
+type ValidationError struct {
+	Message string `json:"message"`
+}
+
+// NotFoundError represents a missing resource.
+type NotFoundError struct {
+	Message string `json:"message"`
+}
+
+// ConflictError represents a resource that already exists.
+type ConflictError struct {
+	Message string `json:"message"`
+}
+
+// NewRequiredFieldError creates a new ValidationError for a missing field.
+func NewRequiredFieldError(field string) *ValidationError {
+	return &ValidationError{
+		Message: fmt.Sprintf("validation error: %s is required", field),
+	}
+}
+
+// NewUnknownSortFieldError creates a new ValidationError for an unsupported sort field.
+func NewUnknownSortFieldError(field string) *ValidationError {
+	return &ValidationError{
+		Message: fmt.Sprintf("validation error: unknown sort field %s", field),
+	}
+}
+
+// NewInvalidSortOrderError creates a new ValidationError for a sort order other than asc or desc.
+func NewInvalidSortOrderError(order string) *ValidationError {
+	return &ValidationError{
+		Message: fmt.Sprintf("validation error: order should be asc or desc, got %s", order),
+	}
+}
+
+// NewInvalidPageError creates a new ValidationError for a page below one.
+func NewInvalidPageError() *ValidationError {
+	return &ValidationError{
+		Message: "validation error: page should be at least 1",
+	}
+}
+
+// NewInvalidPageSizeError creates a new ValidationError for a page size below one.
+func NewInvalidPageSizeError() *ValidationError {
+	return &ValidationError{
+		Message: "validation error: page_size should be at least 1",
+	}
+}
+
+// NewInvalidCursorError creates a new ValidationError for a malformed pagination cursor.
+func NewInvalidCursorError() *ValidationError {
+	return &ValidationError{
+		Message: "validation error: invalid cursor",
+	}
+}
+
+// NewCursorSortMismatchError creates a new ValidationError for a cursor created with a different sort.
+func NewCursorSortMismatchError() *ValidationError {
+	return &ValidationError{
+		Message: "validation error: cursor was created with a different sort or order",
+	}
+}
+
+// NewBookNotFoundError creates a new NotFoundError for the given book ID.
+func NewBookNotFoundError(id string) *NotFoundError {
+	return &NotFoundError{
+		Message: fmt.Sprintf("not found: book with id %s does not exist", id),
+	}
+}
+
+// NewBookAlreadyExistsError creates a new ConflictError for the given book ID.
+func NewBookAlreadyExistsError(id string) *ConflictError {
+	return &ConflictError{
+		Message: fmt.Sprintf("conflict: book with id %s already exists", id),
+	}
+}
+
+// NewInvalidRatingError creates a new ValidationError for a review rating outside 1 to 5.
+func NewInvalidRatingError(rating int) *ValidationError {
+	return &ValidationError{
+		Message: fmt.Sprintf("validation error: rating should be between 1 and 5, got %d", rating),
+	}
+}
+
+// NewAuthorNotFoundError creates a new NotFoundError for the given author ID.
+func NewAuthorNotFoundError(id string) *NotFoundError {
+	return &NotFoundError{
+		Message: fmt.Sprintf("not found: author with id %s does not exist", id),
+	}
+}
+
+// NewCategoryNotFoundError creates a new NotFoundError for the given category ID.
+func NewCategoryNotFoundError(id string) *NotFoundError {
+	return &NotFoundError{
+		Message: fmt.Sprintf("not found: category with id %s does not exist", id),
+	}
+}
+
+// NewResourceNotFoundError creates a new NotFoundError for an item of a generic resource.
+func NewResourceNotFoundError(resource string, id string) *NotFoundError {
+	return &NotFoundError{
+		Message: fmt.Sprintf("not found: %s with id %s does not exist", resource, id),
+	}
+}
+
+// NewInvalidParamError creates a new ValidationError for a request parameter of the wrong type.
+func NewInvalidParamError(name string, paramType string, value string) *ValidationError {
+	return &ValidationError{
+		Message: fmt.Sprintf("validation error: %s should be of type %s, got %s", name, paramType, value),
+	}
+}
+
+// NewImageNotFoundError creates a new NotFoundError for an embedded image that does not exist.
+func NewImageNotFoundError(name string) *NotFoundError {
+	return &NotFoundError{
+		Message: fmt.Sprintf("not found: image %s does not exist", name),
+	}
+}
+
+// NewInvalidImageOptionError creates a new ValidationError for an image size or format that cannot be rendered.
+func NewInvalidImageOptionError(reason string) *ValidationError {
+	return &ValidationError{
+		Message: fmt.Sprintf("validation error: %s", reason),
+	}
+}
+
+// NewInvalidResponseStyleError creates a new ValidationError for an unknown response style.
+func NewInvalidResponseStyleError(style string) *ValidationError {
+	return &ValidationError{
+		Message: fmt.Sprintf("validation error: unknown response style %s, expected plain, jsonapi or hal", style),
+	}
+}
+
+func (e *ValidationError) StatusCode() int {
+	return 400
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+func (e *NotFoundError) StatusCode() int {
+	return 404
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Message
+}
+
+func (e *ConflictError) StatusCode() int {
+	return 409
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,69 @@
+package mockapi
+
+import "testing"
+
+func TestNewRequiredFieldError(t *testing.T) {
+	err := NewRequiredFieldError("title")
+
+	expected := "validation error: title is required"
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+	if err.StatusCode() != 400 {
+		t.Errorf("Expected status code 400, got %d", err.StatusCode())
+	}
+}
+
+func TestNewBookNotFoundError(t *testing.T) {
+	err := NewBookNotFoundError("42")
+
+	expected := "not found: book with id 42 does not exist"
+	if err.Error() != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Error())
+	}
+	if err.StatusCode() != 404 {
+		t.Errorf("Expected status code 404, got %d", err.StatusCode())
+	}
+}
+
+func TestNewBookAlreadyExistsError(t *testing.T) {
+	err := NewBookAlreadyExistsError("7")
+
+	expected := "conflict: book with id 7 already exists"
+	if err.Error() != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Error())
+	}
+	if err.StatusCode() != 409 {
+		t.Errorf("Expected status code 409, got %d", err.StatusCode())
+	}
+}
+
+func TestNewAuthorNotFoundError(t *testing.T) {
+	err := NewAuthorNotFoundError("3")
+
+	expected := "not found: author with id 3 does not exist"
+	if err.Error() != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Error())
+	}
+	if err.StatusCode() != 404 {
+		t.Errorf("Expected status code 404, got %d", err.StatusCode())
+	}
+}
+
+func TestNewInvalidRatingError(t *testing.T) {
+	err := NewInvalidRatingError(6)
+
+	expected := "validation error: rating should be between 1 and 5, got 6"
+	if err.Error() != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Error())
+	}
+	if err.StatusCode() != 400 {
+		t.Errorf("Expected status code 400, got %d", err.StatusCode())
+	}
+}
+
+func TestErrors_ImplementError(t *testing.T) {
+	var _ error = &ValidationError{}
+	var _ error = &NotFoundError{}
+	var _ error = &ConflictError{}
+}
@@ -0,0 +1,108 @@
+package mockapi
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// generatedTopics are the subjects of generated titles, by category.
+var generatedTopics = map[string][]string{
+	"Programming":             {"Go", "Rust", "Python", "Java", "Kotlin", "TypeScript", "C++", "Swift", "Elixir", "Haskell", "Concurrency", "Functional Programming", "Unit Testing", "Clean Architecture"},
+	"Computer Science":        {"Algorithms", "Data Structures", "Compilers", "Operating Systems", "Graph Theory", "Computation", "Cryptography", "Type Systems"},
+	"Software Engineering":    {"Code Review", "Technical Debt", "Domain-Driven Design", "Legacy Code", "Software Estimation", "Team Topologies", "Requirements"},
+	"Database":                {"PostgreSQL", "SQL", "Query Optimization", "Redis", "MongoDB", "Data Modeling", "Indexing", "Transactions"},
+	"DevOps":                  {"Kubernetes", "Docker", "Terraform", "Observability", "Continuous Delivery", "Incident Response", "Linux Administration"},
+	"Machine Learning":        {"Deep Learning", "Neural Networks", "Reinforcement Learning", "Feature Engineering", "Computer Vision", "Recommender Systems"},
+	"System Design":           {"Distributed Systems", "Microservices", "Event Sourcing", "Caching", "Scalability", "API Design", "Message Queues"},
+	"Web Development":         {"React", "Vue", "Svelte", "Node.js", "CSS", "Web Performance", "Accessibility", "GraphQL"},
+	"Artificial Intelligence": {"Large Language Models", "Knowledge Graphs", "Search and Planning", "Natural Language Processing", "AI Safety"},
+}
+
+// generatedCategories fixes the category order so generation is deterministic.
+var generatedCategories = []string{
+	"Programming", "Computer Science", "Software Engineering", "Database", "DevOps",
+	"Machine Learning", "System Design", "Web Development", "Artificial Intelligence",
+}
+
+var generatedTitleFormats = []string{
+	"%s in Action",
+	"Learning %s",
+	"Mastering %s",
+	"Practical %s",
+	"Effective %s",
+	"The Art of %s",
+	"%s from Scratch",
+	"%s Cookbook",
+	"Programming %s",
+	"Head First %s",
+	"%s: The Definitive Guide",
+	"%s for Professionals",
+	"Modern %s",
+	"%s Design Patterns",
+	"Understanding %s",
+}
+
+var generatedEditions = []string{"", "", "", "", " (2nd Edition)", " (3rd Edition)", ", Volume 2"}
+
+var generatedFirstNames = []string{
+	"Ada", "Alan", "Amara", "Bao", "Carlos", "Chioma", "Daniel", "Dewi", "Elena", "Farah",
+	"Grace", "Hiro", "Ingrid", "Jamal", "Kavya", "Lars", "Lucia", "Mei", "Nadia", "Omar",
+	"Priya", "Rafael", "Sakura", "Sven", "Tariq", "Uma", "Victor", "Wen", "Yusuf", "Zara",
+}
+
+var generatedLastNames = []string{
+	"Anderson", "Bakshi", "Costa", "Dubois", "Eriksen", "Fischer", "Garcia", "Haddad", "Ivanova", "Jensen",
+	"Kowalski", "Lim", "Moreau", "Nakamura", "Okafor", "Petrov", "Quinn", "Rossi", "Santoso", "Tanaka",
+	"Usman", "Varga", "Wijaya", "Xu", "Yamamoto", "Zhang", "Nguyen", "Schmidt", "Silva", "Kim",
+}
+
+var generatedDescFormats = []string{
+	"A hands-on introduction to %s for %s",
+	"Practical techniques for %s, written for %s",
+	"Everything %s need to know about %s",
+	"A complete guide to %s with real-world examples for %s",
+	"Patterns, pitfalls and best practices of %s for %s",
+}
+
+var generatedAudiences = []string{"beginners", "working developers", "students", "senior engineers", "team leads", "data scientists"}
+
+// GenerateBooks returns n synthetic books with IDs 1 to n, e.g. to test
+// pagination, virtualized lists or search on a large dataset. The books only
+// depend on n and seed, so the same seed always gives the same books. Every
+// book uses its placeholder cover, see GetPlaceholderURL.
+func GenerateBooks(n int, seed uint64) []Book {
+	rng := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+	pick := func(values []string) string {
+		return values[rng.IntN(len(values))]
+	}
+
+	books := make([]Book, 0, max(n, 0))
+	for i := 1; i <= n; i++ {
+		category := pick(generatedCategories)
+		topic := pick(generatedTopics[category])
+
+		author := pick(generatedFirstNames) + " " + pick(generatedLastNames)
+		if rng.IntN(4) == 0 {
+			author = pick(generatedFirstNames) + " " + string(rune('A'+rng.IntN(26))) + ". " + pick(generatedLastNames)
+		}
+
+		desc := pick(generatedDescFormats)
+		audience := pick(generatedAudiences)
+		if strings.HasPrefix(desc, "Everything") {
+			desc = fmt.Sprintf(desc, audience, topic)
+		} else {
+			desc = fmt.Sprintf(desc, topic, audience)
+		}
+
+		books = append(books, Book{
+			ID:       i,
+			Title:    fmt.Sprintf(pick(generatedTitleFormats), topic) + pick(generatedEditions),
+			Author:   author,
+			Category: category,
+			Desc:     desc,
+			CoverURL: GetPlaceholderURL(i),
+		})
+	}
+	return books
+}
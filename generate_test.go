@@ -0,0 +1,41 @@
+package mockapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenerateBooks(t *testing.T) {
+	books := GenerateBooks(1000, 42)
+
+	if len(books) != 1000 {
+		t.Fatalf("Expected 1000 books, got %d", len(books))
+	}
+	if err := ValidateBooks(books); err != nil {
+		t.Errorf("Expected generated books to be valid, got %v", err)
+	}
+	for i, book := range books {
+		if book.ID != i+1 {
+			t.Errorf("Expected book at index %d to have ID %d, got %d", i, i+1, book.ID)
+		}
+		if book.Category == "" || book.Desc == "" || book.CoverURL != GetPlaceholderURL(book.ID) {
+			t.Errorf("Expected a complete book, got %+v", book)
+		}
+	}
+	if authors := GetInitialAuthors(books); len(authors) < 100 {
+		t.Errorf("Expected many different authors, got %d", len(authors))
+	}
+	if categories := GetInitialCategories(books); len(categories) != len(generatedCategories) {
+		t.Errorf("Expected %d categories, got %d", len(generatedCategories), len(categories))
+	}
+
+	if !reflect.DeepEqual(books[:10], GenerateBooks(10, 42)) {
+		t.Error("Expected the same seed to generate the same books")
+	}
+	if reflect.DeepEqual(books[:10], GenerateBooks(10, 43)) {
+		t.Error("Expected a different seed to generate different books")
+	}
+	if books := GenerateBooks(0, 42); len(books) != 0 {
+		t.Errorf("Expected no books, got %d", len(books))
+	}
+}
@@ -1,3 +1,8 @@
 module github.com/anggaaryas/go-mockapi
 
 go 1.25.1
+
+require (
+	golang.org/x/image v0.25.0
+	gopkg.in/yaml.v3 v3.0.1
+)
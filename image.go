@@ -0,0 +1,288 @@
+package mockapi
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/draw"
+)
+
+// Image formats understood by ImageOptions.
+const (
+	ImageFormatJPEG = "jpeg"
+	ImageFormatPNG  = "png"
+	ImageFormatWebP = "webp"
+)
+
+const (
+	// MaxImageDimension is the largest width or height an image is rendered at.
+	MaxImageDimension = 2048
+	// DefaultImageCacheSize is the number of rendered images kept by default.
+	DefaultImageCacheSize = 128
+	// ImageCacheControl is the Cache-Control header of rendered images. The
+	// embedded images never change while the server runs.
+	ImageCacheControl = "public, max-age=86400"
+
+	imageJPEGQuality = 85
+)
+
+var imageContentTypes = map[string]string{
+	ImageFormatJPEG: "image/jpeg",
+	ImageFormatPNG:  "image/png",
+	ImageFormatWebP: "image/webp",
+}
+
+var imageExtFormats = map[string]string{
+	".jpg":  ImageFormatJPEG,
+	".jpeg": ImageFormatJPEG,
+	".png":  ImageFormatPNG,
+}
+
+// ImageOptions selects how an embedded image is rendered. When both Width
+// and Height are set the image is scaled to cover that size and cropped
+// around its center, and when only one is set the other follows the aspect
+// ratio. Format defaults to the format of the file.
+type ImageOptions struct {
+	Width  int
+	Height int
+	Format string
+}
+
+func (o ImageOptions) isZero() bool {
+	return o == ImageOptions{}
+}
+
+// ParseImageOptions reads the w, h and fmt query parameters of the image
+// endpoint. Empty values are left at their defaults.
+func ParseImageOptions(width string, height string, format string) (ImageOptions, error) {
+	var opts ImageOptions
+	for _, dim := range []struct {
+		name  string
+		value string
+		dest  *int
+	}{{"w", width, &opts.Width}, {"h", height, &opts.Height}} {
+		if dim.value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(dim.value)
+		if err != nil {
+			return ImageOptions{}, NewInvalidParamError(dim.name, ParamTypeInteger, dim.value)
+		}
+		if n < 1 || n > MaxImageDimension {
+			return ImageOptions{}, NewInvalidImageOptionError(fmt.Sprintf("%s should be between 1 and %d", dim.name, MaxImageDimension))
+		}
+		*dim.dest = n
+	}
+
+	opts.Format = strings.ToLower(format)
+	if opts.Format == "jpg" {
+		opts.Format = ImageFormatJPEG
+	}
+	if _, ok := imageContentTypes[opts.Format]; opts.Format != "" && !ok {
+		return ImageOptions{}, NewInvalidImageOptionError(fmt.Sprintf("fmt should be jpeg, png or webp, got %s", format))
+	}
+	return opts, nil
+}
+
+// RenderedImage is an encoded image with the headers to serve it with.
+type RenderedImage struct {
+	Data        []byte
+	ContentType string
+	ETag        string
+}
+
+// NotModified reports whether an If-None-Match header matches the image, in
+// which case a 304 Not Modified can be sent instead.
+func (img RenderedImage) NotModified(ifNoneMatch string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == img.ETag {
+			return true
+		}
+	}
+	return false
+}
+
+type imageCacheEntry struct {
+	key   string
+	image RenderedImage
+}
+
+// ImageRenderer resizes and re-encodes the embedded images. The most recently
+// used renderings are kept in memory.
+type ImageRenderer struct {
+	fsys     fs.FS
+	mu       sync.Mutex
+	capacity int
+	entries  *list.List
+	index    map[string]*list.Element
+}
+
+// NewImageRenderer returns an ImageRenderer for the images served under
+// GetMockapiStaticImagePath that caches up to cacheSize renderings, or
+// DefaultImageCacheSize when cacheSize is below one.
+func NewImageRenderer(cacheSize int) *ImageRenderer {
+	if cacheSize < 1 {
+		cacheSize = DefaultImageCacheSize
+	}
+	sub, err := fs.Sub(GetStaticFS(), "image")
+	if err != nil {
+		panic(err)
+	}
+	return &ImageRenderer{
+		fsys:     sub,
+		capacity: cacheSize,
+		entries:  list.New(),
+		index:    map[string]*list.Element{},
+	}
+}
+
+func (r *ImageRenderer) cached(key string) (RenderedImage, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	element, ok := r.index[key]
+	if !ok {
+		return RenderedImage{}, false
+	}
+	r.entries.MoveToFront(element)
+	return element.Value.(*imageCacheEntry).image, true
+}
+
+func (r *ImageRenderer) store(key string, img RenderedImage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if element, ok := r.index[key]; ok {
+		r.entries.MoveToFront(element)
+		return
+	}
+	r.index[key] = r.entries.PushFront(&imageCacheEntry{key: key, image: img})
+	for r.entries.Len() > r.capacity {
+		oldest := r.entries.Back()
+		r.entries.Remove(oldest)
+		delete(r.index, oldest.Value.(*imageCacheEntry).key)
+	}
+}
+
+// Render returns the embedded image name rendered with opts. Images that are
+// not JPEG or PNG, such as SVG, can only be served as they are.
+func (r *ImageRenderer) Render(name string, opts ImageOptions) (RenderedImage, error) {
+	if strings.Contains(name, "/") || !fs.ValidPath(name) {
+		return RenderedImage{}, NewImageNotFoundError(name)
+	}
+	key := fmt.Sprintf("%s|%dx%d|%s", name, opts.Width, opts.Height, opts.Format)
+	if img, ok := r.cached(key); ok {
+		return img, nil
+	}
+
+	data, err := fs.ReadFile(r.fsys, name)
+	if err != nil {
+		return RenderedImage{}, NewImageNotFoundError(name)
+	}
+
+	ext := strings.ToLower(path.Ext(name))
+	sourceFormat, ok := imageExtFormats[ext]
+	if !ok {
+		if !opts.isZero() {
+			return RenderedImage{}, NewInvalidImageOptionError(fmt.Sprintf("%s images cannot be resized or converted", strings.TrimPrefix(ext, ".")))
+		}
+		img := newRenderedImage(data, imageContentType(ext))
+		r.store(key, img)
+		return img, nil
+	}
+
+	format := opts.Format
+	if format == "" {
+		format = sourceFormat
+	}
+	if opts.Width > 0 || opts.Height > 0 || format != sourceFormat {
+		src, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return RenderedImage{}, fmt.Errorf("image: decode %s: %w", name, err)
+		}
+		if data, err = encodeImage(resizeImage(src, opts.Width, opts.Height), format); err != nil {
+			return RenderedImage{}, fmt.Errorf("image: encode %s: %w", name, err)
+		}
+	}
+
+	img := newRenderedImage(data, imageContentTypes[format])
+	r.store(key, img)
+	return img, nil
+}
+
+func newRenderedImage(data []byte, contentType string) RenderedImage {
+	sum := sha256.Sum256(data)
+	return RenderedImage{
+		Data:        data,
+		ContentType: contentType,
+		ETag:        `"` + hex.EncodeToString(sum[:8]) + `"`,
+	}
+}
+
+func imageContentType(ext string) string {
+	if ext == ".svg" {
+		return "image/svg+xml"
+	}
+	return "application/octet-stream"
+}
+
+// resizeImage scales src to width by height, cropping around the center
+// to keep the aspect ratio. A zero width or height follows the aspect ratio,
+// and when both are zero src is returned as it is.
+func resizeImage(src image.Image, width int, height int) image.Image {
+	bounds := src.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+	if width == 0 && height == 0 {
+		return src
+	}
+	if width == 0 {
+		width = max(1, sw*height/sh)
+	}
+	if height == 0 {
+		height = max(1, sh*width/sw)
+	}
+
+	// Crop the largest centered part of src with the target aspect ratio.
+	crop := bounds
+	if sw*height > sh*width {
+		cw := sh * width / height
+		crop.Min.X += (sw - cw) / 2
+		crop.Max.X = crop.Min.X + cw
+	} else {
+		ch := sw * height / width
+		crop.Min.Y += (sh - ch) / 2
+		crop.Max.Y = crop.Min.Y + ch
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, crop, draw.Src, nil)
+	return dst
+}
+
+func encodeImage(img image.Image, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case ImageFormatJPEG:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: imageJPEGQuality})
+	case ImageFormatPNG:
+		err = png.Encode(&buf, img)
+	case ImageFormatWebP:
+		err = encodeWebP(&buf, img)
+	default:
+		err = fmt.Errorf("unsupported format %s", format)
+	}
+	return buf.Bytes(), err
+}
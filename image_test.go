@@ -0,0 +1,135 @@
+package mockapi
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+	"testing/fstest"
+
+	"golang.org/x/image/webp"
+)
+
+func TestParseImageOptions(t *testing.T) {
+	opts, err := ParseImageOptions("200", "", "JPG")
+	if err != nil {
+		t.Fatalf("ParseImageOptions failed: %v", err)
+	}
+	if opts != (ImageOptions{Width: 200, Format: ImageFormatJPEG}) {
+		t.Errorf("Unexpected options %+v", opts)
+	}
+
+	invalid := [][3]string{
+		{"wide", "", ""},
+		{"0", "", ""},
+		{"", "5000", ""},
+		{"", "", "gif"},
+	}
+	for _, args := range invalid {
+		if _, err := ParseImageOptions(args[0], args[1], args[2]); err == nil {
+			t.Errorf("Expected error for %v", args)
+		} else if _, ok := err.(*ValidationError); !ok {
+			t.Errorf("Expected ValidationError for %v, got %v", args, err)
+		}
+	}
+}
+
+func TestImageRenderer_Render(t *testing.T) {
+	renderer := NewImageRenderer(0)
+
+	original, err := renderer.Render("clean-code.jpg", ImageOptions{})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if original.ContentType != "image/jpeg" || original.ETag == "" {
+		t.Errorf("Unexpected image %s %s", original.ContentType, original.ETag)
+	}
+	source, _ := jpeg.Decode(bytes.NewReader(original.Data))
+
+	tests := []struct {
+		opts        ImageOptions
+		contentType string
+		decode      func(data []byte) (image.Image, error)
+		width       int
+		height      int
+	}{
+		{ImageOptions{Width: 100, Height: 100}, "image/jpeg", func(data []byte) (image.Image, error) { return jpeg.Decode(bytes.NewReader(data)) }, 100, 100},
+		{ImageOptions{Width: 120, Format: ImageFormatPNG}, "image/png", func(data []byte) (image.Image, error) { return png.Decode(bytes.NewReader(data)) }, 120, 120 * source.Bounds().Dy() / source.Bounds().Dx()},
+		{ImageOptions{Height: 64, Format: ImageFormatWebP}, "image/webp", func(data []byte) (image.Image, error) { return webp.Decode(bytes.NewReader(data)) }, 64 * source.Bounds().Dx() / source.Bounds().Dy(), 64},
+	}
+	for _, tt := range tests {
+		img, err := renderer.Render("clean-code.jpg", tt.opts)
+		if err != nil {
+			t.Fatalf("Render(%+v) failed: %v", tt.opts, err)
+		}
+		if img.ContentType != tt.contentType {
+			t.Errorf("Expected content type %s, got %s", tt.contentType, img.ContentType)
+		}
+		decoded, err := tt.decode(img.Data)
+		if err != nil {
+			t.Fatalf("Decode(%+v) failed: %v", tt.opts, err)
+		}
+		if decoded.Bounds().Dx() != tt.width || decoded.Bounds().Dy() != tt.height {
+			t.Errorf("Expected %dx%d for %+v, got %v", tt.width, tt.height, tt.opts, decoded.Bounds())
+		}
+		if img.ETag == original.ETag {
+			t.Errorf("Expected a different ETag for %+v", tt.opts)
+		}
+	}
+}
+
+func TestImageRenderer_Errors(t *testing.T) {
+	renderer := NewImageRenderer(0)
+
+	for _, name := range []string{"missing.jpg", "../image/clean-code.jpg", "x/clean-code.jpg"} {
+		if _, err := renderer.Render(name, ImageOptions{}); err == nil {
+			t.Errorf("Expected error for %s", name)
+		} else if _, ok := err.(*NotFoundError); !ok {
+			t.Errorf("Expected NotFoundError for %s, got %v", name, err)
+		}
+	}
+
+	renderer.fsys = fstest.MapFS{"cover.svg": {Data: []byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`)}}
+	svg, err := renderer.Render("cover.svg", ImageOptions{})
+	if err != nil || svg.ContentType != "image/svg+xml" {
+		t.Errorf("Expected the SVG as it is, got %s, %v", svg.ContentType, err)
+	}
+	if _, err := renderer.Render("cover.svg", ImageOptions{Width: 10}); err == nil {
+		t.Error("Expected error for resizing an SVG")
+	}
+}
+
+func TestImageRenderer_Cache(t *testing.T) {
+	renderer := NewImageRenderer(2)
+
+	for _, width := range []int{10, 20, 30} {
+		if _, err := renderer.Render("clean-code.jpg", ImageOptions{Width: width}); err != nil {
+			t.Fatalf("Render failed: %v", err)
+		}
+	}
+	if renderer.entries.Len() != 2 {
+		t.Errorf("Expected 2 cached images, got %d", renderer.entries.Len())
+	}
+	if _, ok := renderer.cached("clean-code.jpg|10x0|"); ok {
+		t.Error("Expected the least recently used image to be evicted")
+	}
+	if _, ok := renderer.cached("clean-code.jpg|30x0|"); !ok {
+		t.Error("Expected the most recently used image to be cached")
+	}
+}
+
+func TestRenderedImage_NotModified(t *testing.T) {
+	img := RenderedImage{ETag: `"abc"`}
+
+	for _, header := range []string{`"abc"`, `W/"abc"`, `"x", "abc"`, "*"} {
+		if !img.NotModified(header) {
+			t.Errorf("Expected %s to match", header)
+		}
+	}
+	for _, header := range []string{"", `"abcd"`, "abc"} {
+		if img.NotModified(header) {
+			t.Errorf("Expected %s not to match", header)
+		}
+	}
+}
@@ -2,13 +2,17 @@ package mockapi
 
 import (
 	"embed"
+	"io/fs"
 )
 
 //go:embed static/*
 var staticFiles embed.FS
 
 var mockapiPath = "/mockapi"
-var mockapiStaticImagePath = mockapiPath + "/static/image/"
+var mockapiStaticPath = mockapiPath + "/static"
+var mockapiStaticImagePath = mockapiStaticPath + "/image/"
+var mockapiImagePath = mockapiPath + "/image"
+var mockapiPlaceholderPath = mockapiPath + "/placeholder"
 
 func GetStaticFiles() embed.FS {
 	return staticFiles
@@ -18,15 +22,69 @@ func GetMockapiPath() string {
 	return mockapiPath
 }
 
+func GetMockapiStaticPath() string {
+	return mockapiStaticPath
+}
+
 func GetMockapiStaticImagePath() string {
 	return mockapiStaticImagePath
 }
 
+// GetMockapiImagePath returns the path of the endpoint that resizes and
+// converts the embedded images, as in GetMockapiImagePath()+"/clean-code.jpg?w=200".
+func GetMockapiImagePath() string {
+	return mockapiImagePath
+}
+
+// GetMockapiPlaceholderPath returns the path of the endpoint that renders the
+// placeholder cover of a book, as in GetMockapiPlaceholderPath()+"/7".
+func GetMockapiPlaceholderPath() string {
+	return mockapiPlaceholderPath
+}
+
+// GetStaticFS returns the embedded static directory, as served under GetMockapiStaticPath.
+func GetStaticFS() fs.FS {
+	sub, err := fs.Sub(staticFiles, "static")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+
 type DataSource interface {
 	PopulateData() error
 	GetBookByID(id string) (Book, error)
-	GetBooks(page int, pageSize int, search string) ([]Book, error)
-	GetBooksCount(search string) (int64, error)
+	GetBooks(query BookQuery) ([]Book, error)
+	GetBooksCount(query BookQuery) (int64, error)
+	GetBooksByCursor(query BookQuery, cursor *BookCursor) ([]Book, error)
+	CreateBook(book Book) (Book, error)
+	UpdateBook(id string, book Book) (Book, error)
+	PatchBook(id string, patch BookPatch) (Book, error)
+	DeleteBook(id string) error
+	GetAuthors() ([]Author, error)
+	GetAuthorByID(id string) (Author, error)
+	GetCategories() ([]Category, error)
+	GetCategoryByID(id string) (Category, error)
+	GetReviews(bookID string) ([]Review, error)
+	CreateReview(review Review) (Review, error)
+}
+
+// ResettableDataSource is implemented by data sources that can replace all
+// their data, e.g. to switch between test scenarios. A nil seed restores the
+// data source's own seed, which is the built-in dataset unless one was given
+// when it was created, and an empty one leaves the data source empty.
+type ResettableDataSource interface {
+	DataSource
+	ResetData(seed []Book) error
+}
+
+// SnapshotDataSource is implemented by data sources that can export all their
+// records and import them again, e.g. to snapshot and restore them. Imported
+// data is checked with ValidateDataset first.
+type SnapshotDataSource interface {
+	ResettableDataSource
+	ExportData() (Dataset, error)
+	ImportData(data Dataset) error
 }
 
 type Router interface {
@@ -42,4 +100,4 @@ func Use(ds DataSource, r Router) {
 	if err := r.SetupMockApiRoute(service); err != nil {
 		panic(err)
 	}
-}
\ No newline at end of file
+}
@@ -3,6 +3,7 @@ package mockapi
 import (
 	"embed"
 	"errors"
+	"io/fs"
 	"testing"
 )
 
@@ -24,6 +25,12 @@ func TestGetStaticFiles(t *testing.T) {
 	}
 }
 
+func TestGetStaticFS(t *testing.T) {
+	if _, err := fs.Stat(GetStaticFS(), "image/clean-code.jpg"); err != nil {
+		t.Errorf("Expected image/clean-code.jpg in static FS, got error: %v", err)
+	}
+}
+
 type mockRouter struct {
 	setupMockApiRouteFunc func(service Service) error
 }
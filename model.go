@@ -1,10 +1,40 @@
 package mockapi
 
 type Book struct {
+	ID       int    `json:"id" xml:"id"`
+	Title    string `json:"title" xml:"title"`
+	Author   string `json:"author" xml:"author"`
+	Category string `json:"category" xml:"category"`
+	Desc     string `json:"desc" xml:"desc"`
+	CoverURL string `json:"cover_url" xml:"cover_url"`
+}
+
+// Author is a book author. Books refer to their author by Name.
+type Author struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Category is a book category. Books refer to their category by Name.
+type Category struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Review is a reader review of a book.
+type Review struct {
 	ID       int    `json:"id"`
-	Title    string `json:"title"`
-	Author   string `json:"author"`
-	Category string `json:"category"`
-	Desc     string `json:"desc"`
-	CoverURL string `json:"cover_url"`
+	BookID   int    `json:"book_id"`
+	Reviewer string `json:"reviewer"`
+	Rating   int    `json:"rating"`
+	Comment  string `json:"comment"`
+}
+
+// Dataset is every record of a data source, as exported and imported through
+// SnapshotDataSource.
+type Dataset struct {
+	Books      []Book     `json:"books"`
+	Authors    []Author   `json:"authors"`
+	Categories []Category `json:"categories"`
+	Reviews    []Review   `json:"reviews"`
 }
@@ -0,0 +1,255 @@
+package mockapi
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// ImageFormatSVG renders placeholder covers as SVG.
+const ImageFormatSVG = "svg"
+
+const (
+	// PlaceholderWidth and PlaceholderHeight are the size of placeholder covers.
+	PlaceholderWidth  = 300
+	PlaceholderHeight = 450
+	// PlaceholderCacheControl is the Cache-Control header of placeholder
+	// covers. They change with the title and author, so clients revalidate
+	// them with the ETag.
+	PlaceholderCacheControl = "no-cache"
+
+	placeholderMargin       = 24
+	placeholderTitleSize    = 28
+	placeholderTitleLines   = 7
+	placeholderTitleHeight  = 36
+	placeholderAuthorSize   = 18
+	placeholderAuthorLines  = 2
+	placeholderAuthorHeight = 24
+	placeholderTitleTop     = 96
+	placeholderAuthorBottom = PlaceholderHeight - 40
+	placeholderFontFamily   = "Go, Helvetica, Arial, sans-serif"
+)
+
+var placeholderContentTypes = map[string]string{
+	ImageFormatPNG: "image/png",
+	ImageFormatSVG: "image/svg+xml",
+}
+
+var placeholderFonts = sync.OnceValues(func() ([2]*opentype.Font, error) {
+	bold, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return [2]*opentype.Font{}, err
+	}
+	regular, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return [2]*opentype.Font{}, err
+	}
+	return [2]*opentype.Font{bold, regular}, nil
+})
+
+// placeholderLine is a line of text on a placeholder cover, centered
+// horizontally with its baseline at y.
+type placeholderLine struct {
+	text string
+	y    int
+	size float64
+	bold bool
+	face font.Face
+}
+
+// PlaceholderColor returns the background color of the placeholder cover of
+// book id. Neighbouring IDs get clearly different hues.
+func PlaceholderColor(id int) color.RGBA {
+	// Step around the color wheel by the golden angle.
+	hue := math.Mod(float64(id)*137.508, 360)
+	if hue < 0 {
+		hue += 360
+	}
+	return hslColor(hue, 0.55, 0.35)
+}
+
+func hslColor(h float64, s float64, l float64) color.RGBA {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	return color.RGBA{
+		R: uint8(math.Round((r + m) * 255)),
+		G: uint8(math.Round((g + m) * 255)),
+		B: uint8(math.Round((b + m) * 255)),
+		A: 0xff,
+	}
+}
+
+// RenderPlaceholder draws the placeholder cover of book: its title and author
+// on a background color derived from its ID, as a PNG or an SVG. The cover
+// only depends on those fields, so the same book always gives the same image.
+// Format defaults to PNG.
+func RenderPlaceholder(book Book, format string) (RenderedImage, error) {
+	format = strings.ToLower(format)
+	if format == "" {
+		format = ImageFormatPNG
+	}
+	contentType, ok := placeholderContentTypes[format]
+	if !ok {
+		return RenderedImage{}, NewInvalidImageOptionError(fmt.Sprintf("fmt should be png or svg, got %s", format))
+	}
+
+	fonts, err := placeholderFonts()
+	if err != nil {
+		return RenderedImage{}, fmt.Errorf("placeholder: %w", err)
+	}
+	titleFace, err := opentype.NewFace(fonts[0], &opentype.FaceOptions{Size: placeholderTitleSize, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return RenderedImage{}, fmt.Errorf("placeholder: %w", err)
+	}
+	defer titleFace.Close()
+	authorFace, err := opentype.NewFace(fonts[1], &opentype.FaceOptions{Size: placeholderAuthorSize, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return RenderedImage{}, fmt.Errorf("placeholder: %w", err)
+	}
+	defer authorFace.Close()
+	lines := placeholderLayout(book, titleFace, authorFace)
+
+	background := PlaceholderColor(book.ID)
+	var data []byte
+	if format == ImageFormatSVG {
+		data = placeholderSVG(background, lines)
+	} else if data, err = placeholderPNG(background, lines); err != nil {
+		return RenderedImage{}, fmt.Errorf("placeholder: %w", err)
+	}
+	return newRenderedImage(data, contentType), nil
+}
+
+// placeholderLayout wraps the title from the top and the author from the
+// bottom of the cover.
+func placeholderLayout(book Book, titleFace font.Face, authorFace font.Face) []placeholderLine {
+	var lines []placeholderLine
+
+	for i, text := range wrapText(titleFace, book.Title, PlaceholderWidth-2*placeholderMargin, placeholderTitleLines) {
+		lines = append(lines, placeholderLine{
+			text: text,
+			y:    placeholderTitleTop + i*placeholderTitleHeight,
+			size: placeholderTitleSize,
+			bold: true,
+			face: titleFace,
+		})
+	}
+
+	author := wrapText(authorFace, book.Author, PlaceholderWidth-2*placeholderMargin, placeholderAuthorLines)
+	for i, text := range author {
+		lines = append(lines, placeholderLine{
+			text: text,
+			y:    placeholderAuthorBottom - (len(author)-1-i)*placeholderAuthorHeight,
+			size: placeholderAuthorSize,
+			face: authorFace,
+		})
+	}
+	return lines
+}
+
+// wrapText breaks text into at most maxLines lines no wider than width,
+// breaking words that are too long on their own and ending the last line
+// with an ellipsis when text does not fit.
+func wrapText(face font.Face, text string, width int, maxLines int) []string {
+	maxWidth := fixed.I(width)
+	fits := func(s string) bool {
+		return font.MeasureString(face, s) <= maxWidth
+	}
+
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && fits(line+" "+word) {
+			line += " " + word
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		line = ""
+		for _, r := range word {
+			if line != "" && !fits(line+string(r)) {
+				lines = append(lines, line)
+				line = ""
+			}
+			line += string(r)
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		last := []rune(lines[maxLines-1])
+		for len(last) > 0 && !fits(string(last)+"…") {
+			last = last[:len(last)-1]
+		}
+		lines[maxLines-1] = strings.TrimRight(string(last), " ") + "…"
+	}
+	return lines
+}
+
+func placeholderPNG(background color.RGBA, lines []placeholderLine) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, PlaceholderWidth, PlaceholderHeight))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+	for _, line := range lines {
+		d := &font.Drawer{Dst: img, Src: image.White, Face: line.face}
+		x := (fixed.I(PlaceholderWidth) - d.MeasureString(line.text)) / 2
+		d.Dot = fixed.Point26_6{X: x, Y: fixed.I(line.y)}
+		d.DrawString(line.text)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func placeholderSVG(background color.RGBA, lines []placeholderLine) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		PlaceholderWidth, PlaceholderHeight, PlaceholderWidth, PlaceholderHeight)
+	fmt.Fprintf(&buf, `<rect width="100%%" height="100%%" fill="#%02x%02x%02x"/>`+"\n", background.R, background.G, background.B)
+	for _, line := range lines {
+		weight := "normal"
+		if line.bold {
+			weight = "bold"
+		}
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" fill="#ffffff" font-family="%s" font-size="%g" font-weight="%s" text-anchor="middle">%s</text>`+"\n",
+			PlaceholderWidth/2, line.y, placeholderFontFamily, line.size, weight, html.EscapeString(line.text))
+	}
+	buf.WriteString("</svg>\n")
+	return buf.Bytes()
+}
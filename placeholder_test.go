@@ -0,0 +1,93 @@
+package mockapi
+
+import (
+	"bytes"
+	"errors"
+	"image/png"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/font/basicfont"
+)
+
+func TestRenderPlaceholder_PNG(t *testing.T) {
+	book := Book{ID: 3, Title: "A Book Without a Cover", Author: "Jane Doe"}
+
+	rendered, err := RenderPlaceholder(book, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rendered.ContentType != "image/png" {
+		t.Errorf("Expected content type image/png, got %s", rendered.ContentType)
+	}
+	img, err := png.Decode(bytes.NewReader(rendered.Data))
+	if err != nil {
+		t.Fatalf("Expected a PNG, got error: %v", err)
+	}
+	if size := img.Bounds().Size(); size.X != PlaceholderWidth || size.Y != PlaceholderHeight {
+		t.Errorf("Expected a %dx%d image, got %v", PlaceholderWidth, PlaceholderHeight, size)
+	}
+	if r, g, b, _ := img.At(2, 2).RGBA(); r>>8 != uint32(PlaceholderColor(3).R) || g>>8 != uint32(PlaceholderColor(3).G) || b>>8 != uint32(PlaceholderColor(3).B) {
+		t.Errorf("Expected background %v, got %v", PlaceholderColor(3), img.At(2, 2))
+	}
+
+	again, _ := RenderPlaceholder(book, "png")
+	if again.ETag != rendered.ETag {
+		t.Error("Expected the same book to render the same placeholder")
+	}
+	book.Title = "Another Title"
+	if other, _ := RenderPlaceholder(book, "png"); other.ETag == rendered.ETag {
+		t.Error("Expected a different title to render a different placeholder")
+	}
+}
+
+func TestRenderPlaceholder_SVG(t *testing.T) {
+	rendered, err := RenderPlaceholder(Book{ID: 4, Title: "Tom & Jerry <Go>", Author: "Jane Doe"}, "svg")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rendered.ContentType != "image/svg+xml" {
+		t.Errorf("Expected content type image/svg+xml, got %s", rendered.ContentType)
+	}
+	svg := string(rendered.Data)
+	if !strings.Contains(svg, "Tom &amp; Jerry &lt;Go&gt;") || !strings.Contains(svg, "Jane Doe") {
+		t.Errorf("Expected escaped title and author in SVG, got %s", svg)
+	}
+}
+
+func TestRenderPlaceholder_InvalidFormat(t *testing.T) {
+	_, err := RenderPlaceholder(Book{ID: 1, Title: "T", Author: "A"}, "webp")
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Expected ValidationError, got %v", err)
+	}
+}
+
+func TestPlaceholderColor(t *testing.T) {
+	if PlaceholderColor(1) == PlaceholderColor(2) {
+		t.Error("Expected neighbouring IDs to get different colors")
+	}
+	if PlaceholderColor(5) != PlaceholderColor(5) {
+		t.Error("Expected the same ID to get the same color")
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	face := basicfont.Face7x13
+
+	lines := wrapText(face, "the quick brown fox", 70, 5)
+	if len(lines) != 2 || lines[0] != "the quick" || lines[1] != "brown fox" {
+		t.Errorf("Expected two lines, got %q", lines)
+	}
+
+	lines = wrapText(face, "abcdefghijklmnopqrstuvwxyz", 70, 5)
+	if len(lines) != 3 || lines[0] != "abcdefghij" {
+		t.Errorf("Expected a long word to be broken, got %q", lines)
+	}
+
+	lines = wrapText(face, "one two three four five six", 35, 2)
+	if len(lines) != 2 || !strings.HasSuffix(lines[1], "…") {
+		t.Errorf("Expected two lines ending with an ellipsis, got %q", lines)
+	}
+}
@@ -0,0 +1,71 @@
+package mockapi
+
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+var bookSortFields = []string{"id", "title", "author", "category"}
+
+// BookQuery describes which books to list and in which order. Category and
+// Author are exact-match filters; a book matches when it equals any of the
+// given values.
+type BookQuery struct {
+	Page     int
+	PageSize int
+	Search   string
+	Sort     string
+	Order    string
+	Category []string
+	Author   []string
+}
+
+// BookSortFields returns the fields books can be sorted by.
+func BookSortFields() []string {
+	return append([]string(nil), bookSortFields...)
+}
+
+// IsBookSortField reports whether books can be sorted by the given field.
+func IsBookSortField(field string) bool {
+	for _, f := range bookSortFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSortOrder reports whether order is either OrderAsc or OrderDesc.
+func IsSortOrder(order string) bool {
+	return order == OrderAsc || order == OrderDesc
+}
+
+// WithDefaults returns a copy of the query sorted by ID in ascending order
+// when no sort field or order is set.
+func (q BookQuery) WithDefaults() BookQuery {
+	if q.Sort == "" {
+		q.Sort = "id"
+	}
+	if q.Order == "" {
+		q.Order = OrderAsc
+	}
+	return q
+}
+
+// Validate returns a ValidationError when the page or page size is below one,
+// or the sort field or order is not supported.
+func (q BookQuery) Validate() error {
+	if q.Page < 1 {
+		return NewInvalidPageError()
+	}
+	if q.PageSize < 1 {
+		return NewInvalidPageSizeError()
+	}
+	if q.Sort != "" && !IsBookSortField(q.Sort) {
+		return NewUnknownSortFieldError(q.Sort)
+	}
+	if q.Order != "" && !IsSortOrder(q.Order) {
+		return NewInvalidSortOrderError(q.Order)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package mockapi
+
+import "testing"
+
+func TestIsBookSortField(t *testing.T) {
+	for _, field := range []string{"id", "title", "author", "category"} {
+		if !IsBookSortField(field) {
+			t.Errorf("Expected %s to be a sort field", field)
+		}
+	}
+	for _, field := range []string{"", "desc", "cover_url", "Title"} {
+		if IsBookSortField(field) {
+			t.Errorf("Expected %s not to be a sort field", field)
+		}
+	}
+}
+
+func TestBookSortFields_ReturnsCopy(t *testing.T) {
+	fields := BookSortFields()
+	fields[0] = "price"
+
+	if !IsBookSortField("id") {
+		t.Error("Expected modifying the returned slice not to affect sort fields")
+	}
+}
+
+func TestBookQuery_WithDefaults(t *testing.T) {
+	q := BookQuery{}.WithDefaults()
+	if q.Sort != "id" || q.Order != OrderAsc {
+		t.Errorf("Expected id asc, got %s %s", q.Sort, q.Order)
+	}
+
+	q = BookQuery{Sort: "title", Order: OrderDesc}.WithDefaults()
+	if q.Sort != "title" || q.Order != OrderDesc {
+		t.Errorf("Expected title desc, got %s %s", q.Sort, q.Order)
+	}
+}
+
+func TestBookQuery_Validate(t *testing.T) {
+	if err := (BookQuery{Page: 1, PageSize: 10}).Validate(); err != nil {
+		t.Errorf("Expected first page to be valid, got %v", err)
+	}
+	if err := (BookQuery{Page: 1, PageSize: 10, Sort: "title", Order: OrderDesc}).Validate(); err != nil {
+		t.Errorf("Expected title desc to be valid, got %v", err)
+	}
+
+	err := BookQuery{Page: 1, PageSize: 10, Sort: "price"}.Validate()
+	if err == nil || err.Error() != "validation error: unknown sort field price" {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	err = BookQuery{Page: 1, PageSize: 10, Order: "up"}.Validate()
+	if err == nil || err.Error() != "validation error: order should be asc or desc, got up" {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	err = BookQuery{Page: 0, PageSize: 10}.Validate()
+	if err == nil || err.Error() != "validation error: page should be at least 1" {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	err = BookQuery{Page: 1, PageSize: 0}.Validate()
+	if err == nil || err.Error() != "validation error: page_size should be at least 1" {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
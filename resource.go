@@ -0,0 +1,298 @@
+package mockapi
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ResourceQuery describes which items of a generic resource to list and in
+// which order. Search matches the resource's search fields.
+type ResourceQuery struct {
+	Page     int
+	PageSize int
+	Search   string
+	Sort     string
+	Order    string
+}
+
+// WithDefaults returns a copy of the query on the first page, sorted by ID in
+// ascending order when no page, sort field or order is set.
+func (q ResourceQuery) WithDefaults() ResourceQuery {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.Sort == "" {
+		q.Sort = "id"
+	}
+	if q.Order == "" {
+		q.Order = OrderAsc
+	}
+	return q
+}
+
+// PaginatedResources is a page of a generic resource, shaped like PaginatedBooks.
+type PaginatedResources[T any] struct {
+	Data       []T   `json:"data"`
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	TotalItems int64 `json:"total_items"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// ResourceField is a field of a generic resource, named after its JSON key.
+type ResourceField struct {
+	Name   string
+	GoName string
+	Kind   reflect.Kind
+	index  []int
+}
+
+// ResourceSchema describes a generic resource type: its JSON fields and which
+// of them can be searched and sorted. Every resource has an "id" field.
+type ResourceSchema struct {
+	Name         string
+	Type         reflect.Type
+	Fields       []ResourceField
+	SearchFields []string
+	SortFields   []string
+}
+
+var resourceNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// reservedResourceNames are served by SetupMockApiRoute already.
+var reservedResourceNames = []string{"books", "authors", "categories"}
+
+func isScalarKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func newResourceSchema(name string, t reflect.Type, searchFields []string, sortFields []string) (*ResourceSchema, error) {
+	if !resourceNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("resource name %q should only contain lowercase letters, digits and dashes", name)
+	}
+	if slices.Contains(reservedResourceNames, name) {
+		return nil, fmt.Errorf("resource name %q is already used by the book routes", name)
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("resource %s: %s is not a struct", name, t)
+	}
+
+	schema := &ResourceSchema{Name: name, Type: t}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if jsonName == "-" || !field.IsExported() {
+			continue
+		}
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		schema.Fields = append(schema.Fields, ResourceField{
+			Name:   jsonName,
+			GoName: field.Name,
+			Kind:   field.Type.Kind(),
+			index:  field.Index,
+		})
+	}
+
+	id, ok := schema.Field("id")
+	if !ok {
+		return nil, fmt.Errorf("resource %s: %s has no field with JSON name id", name, t)
+	}
+	if id.Kind != reflect.String && !isScalarKind(id.Kind) {
+		return nil, fmt.Errorf("resource %s: id should be a string or a number", name)
+	}
+
+	if searchFields == nil {
+		for _, field := range schema.Fields {
+			if field.Kind == reflect.String && field.Name != "id" {
+				searchFields = append(searchFields, field.Name)
+			}
+		}
+	}
+	if sortFields == nil {
+		for _, field := range schema.Fields {
+			if isScalarKind(field.Kind) {
+				sortFields = append(sortFields, field.Name)
+			}
+		}
+	}
+	for _, name := range searchFields {
+		field, ok := schema.Field(name)
+		if !ok || field.Kind != reflect.String {
+			return nil, fmt.Errorf("resource %s: search field %q is not a string field", schema.Name, name)
+		}
+	}
+	for _, name := range sortFields {
+		field, ok := schema.Field(name)
+		if !ok || !isScalarKind(field.Kind) {
+			return nil, fmt.Errorf("resource %s: sort field %q is not a string or number field", schema.Name, name)
+		}
+	}
+	if !slices.Contains(sortFields, "id") {
+		sortFields = append([]string{"id"}, sortFields...)
+	}
+	schema.SearchFields = searchFields
+	schema.SortFields = sortFields
+	return schema, nil
+}
+
+// Field returns the field with the given JSON name.
+func (s *ResourceSchema) Field(name string) (ResourceField, bool) {
+	for _, field := range s.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return ResourceField{}, false
+}
+
+// IsSortField reports whether items can be sorted by the given field.
+func (s *ResourceSchema) IsSortField(name string) bool {
+	return slices.Contains(s.SortFields, name)
+}
+
+// Value returns the named field of item, which is a value of or pointer to
+// the schema's type.
+func (s *ResourceSchema) Value(item any, name string) reflect.Value {
+	field, ok := s.Field(name)
+	if !ok {
+		return reflect.Value{}
+	}
+	return reflect.Indirect(reflect.ValueOf(item)).FieldByIndex(field.index)
+}
+
+// ID returns the ID of item formatted as a string.
+func (s *ResourceSchema) ID(item any) string {
+	return fmt.Sprint(s.Value(item, "id").Interface())
+}
+
+// ResourceDataSource stores the items of a generic resource. PopulateData
+// receives the resource's schema and seed before any other method is called.
+type ResourceDataSource[T any] interface {
+	PopulateData(schema *ResourceSchema, seed []T) error
+	GetByID(id string) (T, error)
+	List(query ResourceQuery) ([]T, error)
+	Count(query ResourceQuery) (int64, error)
+}
+
+// ResourceService is the untyped view of a Resource that routers serve.
+type ResourceService interface {
+	Schema() *ResourceSchema
+	PopulateData() error
+	GetResource(id string) (any, error)
+	GetResources(query ResourceQuery) (any, error)
+}
+
+// ResourceRouter is implemented by routers that can serve generic resources.
+type ResourceRouter interface {
+	SetupResourceRoute(resource ResourceService) error
+}
+
+// ResourceConfig registers a generic resource. Name is the path segment
+// under /api. SearchFields defaults to every string field except id, and
+// SortFields to every string and number field.
+type ResourceConfig[T any] struct {
+	Name         string
+	DataSource   ResourceDataSource[T]
+	Seed         []T
+	SearchFields []string
+	SortFields   []string
+}
+
+// Resource is the service of a generic resource of type T, which must be a
+// struct with a field whose JSON name is id.
+type Resource[T any] struct {
+	schema     *ResourceSchema
+	dataSource ResourceDataSource[T]
+	seed       []T
+}
+
+func NewResource[T any](config ResourceConfig[T]) (*Resource[T], error) {
+	if config.DataSource == nil {
+		return nil, fmt.Errorf("resource %s: DataSource is required", config.Name)
+	}
+	schema, err := newResourceSchema(config.Name, reflect.TypeFor[T](), config.SearchFields, config.SortFields)
+	if err != nil {
+		return nil, err
+	}
+	return &Resource[T]{
+		schema:     schema,
+		dataSource: config.DataSource,
+		seed:       config.Seed,
+	}, nil
+}
+
+func (r *Resource[T]) Schema() *ResourceSchema {
+	return r.schema
+}
+
+func (r *Resource[T]) PopulateData() error {
+	return r.dataSource.PopulateData(r.schema, r.seed)
+}
+
+func (r *Resource[T]) Get(id string) (T, error) {
+	return r.dataSource.GetByID(id)
+}
+
+func (r *Resource[T]) List(query ResourceQuery) (PaginatedResources[T], error) {
+	query = query.WithDefaults()
+	if !r.schema.IsSortField(query.Sort) {
+		return PaginatedResources[T]{}, NewUnknownSortFieldError(query.Sort)
+	}
+	if !IsSortOrder(query.Order) {
+		return PaginatedResources[T]{}, NewInvalidSortOrderError(query.Order)
+	}
+	if query.PageSize < 1 {
+		return PaginatedResources[T]{}, NewInvalidPageSizeError()
+	}
+
+	items, err := r.dataSource.List(query)
+	if err != nil {
+		return PaginatedResources[T]{}, err
+	}
+	totalCount, err := r.dataSource.Count(query)
+	if err != nil {
+		return PaginatedResources[T]{}, err
+	}
+	return PaginatedResources[T]{
+		Data:       items,
+		TotalItems: totalCount,
+		TotalPages: int((totalCount + int64(query.PageSize) - 1) / int64(query.PageSize)),
+		Page:       query.Page,
+		PageSize:   query.PageSize,
+	}, nil
+}
+
+func (r *Resource[T]) GetResource(id string) (any, error) {
+	return r.Get(id)
+}
+
+func (r *Resource[T]) GetResources(query ResourceQuery) (any, error) {
+	return r.List(query)
+}
+
+// UseResource populates resource and registers its routes on r, which must
+// also implement ResourceRouter.
+func UseResource(resource ResourceService, r Router) {
+	resourceRouter, ok := r.(ResourceRouter)
+	if !ok {
+		panic(fmt.Sprintf("router %T cannot serve generic resources", r))
+	}
+	if err := resource.PopulateData(); err != nil {
+		panic(err)
+	}
+	if err := resourceRouter.SetupResourceRoute(resource); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,205 @@
+package mockapi
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type testProduct struct {
+	SKU   string  `json:"id"`
+	Name  string  `json:"name"`
+	Brand string  `json:"brand"`
+	Price float64 `json:"price"`
+	Tags  []string
+	note  string
+}
+
+type mockResourceDataSource struct {
+	populateDataFunc func(schema *ResourceSchema, seed []testProduct) error
+	getByIDFunc      func(id string) (testProduct, error)
+	listFunc         func(query ResourceQuery) ([]testProduct, error)
+	countFunc        func(query ResourceQuery) (int64, error)
+}
+
+func (m *mockResourceDataSource) PopulateData(schema *ResourceSchema, seed []testProduct) error {
+	if m.populateDataFunc != nil {
+		return m.populateDataFunc(schema, seed)
+	}
+	return nil
+}
+
+func (m *mockResourceDataSource) GetByID(id string) (testProduct, error) {
+	if m.getByIDFunc != nil {
+		return m.getByIDFunc(id)
+	}
+	return testProduct{}, nil
+}
+
+func (m *mockResourceDataSource) List(query ResourceQuery) ([]testProduct, error) {
+	if m.listFunc != nil {
+		return m.listFunc(query)
+	}
+	return []testProduct{}, nil
+}
+
+func (m *mockResourceDataSource) Count(query ResourceQuery) (int64, error) {
+	if m.countFunc != nil {
+		return m.countFunc(query)
+	}
+	return 0, nil
+}
+
+func TestNewResource_Schema(t *testing.T) {
+	resource, err := NewResource(ResourceConfig[testProduct]{
+		Name:       "products",
+		DataSource: &mockResourceDataSource{},
+	})
+	if err != nil {
+		t.Fatalf("NewResource failed: %v", err)
+	}
+
+	schema := resource.Schema()
+	if len(schema.Fields) != 5 {
+		t.Errorf("Expected 5 exported fields, got %d", len(schema.Fields))
+	}
+	if !slices.Equal(schema.SearchFields, []string{"name", "brand"}) {
+		t.Errorf("Expected search fields [name brand], got %v", schema.SearchFields)
+	}
+	if !slices.Equal(schema.SortFields, []string{"id", "name", "brand", "price"}) {
+		t.Errorf("Expected sort fields [id name brand price], got %v", schema.SortFields)
+	}
+
+	product := testProduct{SKU: "P-1", Price: 9.5}
+	if schema.ID(product) != "P-1" || schema.ID(&product) != "P-1" {
+		t.Errorf("Expected ID P-1, got %s", schema.ID(product))
+	}
+	if schema.Value(product, "price").Float() != 9.5 {
+		t.Errorf("Expected price 9.5, got %v", schema.Value(product, "price"))
+	}
+}
+
+func TestNewResource_InvalidConfig(t *testing.T) {
+	type noID struct {
+		Name string `json:"name"`
+	}
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"missing data source", func() error {
+			_, err := NewResource(ResourceConfig[testProduct]{Name: "products"})
+			return err
+		}()},
+		{"invalid name", func() error {
+			_, err := NewResource(ResourceConfig[testProduct]{Name: "Products", DataSource: &mockResourceDataSource{}})
+			return err
+		}()},
+		{"reserved name", func() error {
+			_, err := NewResource(ResourceConfig[testProduct]{Name: "books", DataSource: &mockResourceDataSource{}})
+			return err
+		}()},
+		{"no id field", func() error {
+			_, err := newResourceSchema("things", reflect.TypeFor[noID](), nil, nil)
+			return err
+		}()},
+		{"non-string search field", func() error {
+			_, err := NewResource(ResourceConfig[testProduct]{Name: "products", DataSource: &mockResourceDataSource{}, SearchFields: []string{"price"}})
+			return err
+		}()},
+		{"unknown sort field", func() error {
+			_, err := NewResource(ResourceConfig[testProduct]{Name: "products", DataSource: &mockResourceDataSource{}, SortFields: []string{"Tags"}})
+			return err
+		}()},
+	}
+
+	for _, tt := range tests {
+		if tt.err == nil {
+			t.Errorf("Expected error for %s", tt.name)
+		}
+	}
+}
+
+func TestResource_List(t *testing.T) {
+	ds := &mockResourceDataSource{
+		listFunc: func(query ResourceQuery) ([]testProduct, error) {
+			if query.Page != 1 || query.Sort != "id" || query.Order != OrderAsc {
+				t.Errorf("Expected defaults to be applied, got %+v", query)
+			}
+			return []testProduct{{SKU: "P-1"}, {SKU: "P-2"}}, nil
+		},
+		countFunc: func(query ResourceQuery) (int64, error) {
+			return 5, nil
+		},
+	}
+	resource, _ := NewResource(ResourceConfig[testProduct]{Name: "products", DataSource: ds})
+
+	result, err := resource.List(ResourceQuery{PageSize: 2})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(result.Data) != 2 || result.TotalItems != 5 || result.TotalPages != 3 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	for _, query := range []ResourceQuery{
+		{PageSize: 2, Sort: "Tags"},
+		{PageSize: 2, Order: "up"},
+		{PageSize: 0},
+	} {
+		_, err := resource.List(query)
+		if _, ok := err.(*ValidationError); !ok {
+			t.Errorf("Expected ValidationError for %+v, got %v", query, err)
+		}
+	}
+}
+
+type mockResourceRouter struct {
+	mockRouter
+	setupResourceRouteFunc func(resource ResourceService) error
+}
+
+func (m *mockResourceRouter) SetupResourceRoute(resource ResourceService) error {
+	if m.setupResourceRouteFunc != nil {
+		return m.setupResourceRouteFunc(resource)
+	}
+	return nil
+}
+
+func TestUseResource(t *testing.T) {
+	var populated []testProduct
+	ds := &mockResourceDataSource{
+		populateDataFunc: func(schema *ResourceSchema, seed []testProduct) error {
+			populated = seed
+			return nil
+		},
+	}
+	resource, _ := NewResource(ResourceConfig[testProduct]{
+		Name:       "products",
+		DataSource: ds,
+		Seed:       []testProduct{{SKU: "P-1"}},
+	})
+
+	var registered ResourceService
+	UseResource(resource, &mockResourceRouter{
+		setupResourceRouteFunc: func(resource ResourceService) error {
+			registered = resource
+			return nil
+		},
+	})
+
+	if len(populated) != 1 {
+		t.Errorf("Expected seed to be populated, got %v", populated)
+	}
+	if registered == nil || registered.Schema().Name != "products" {
+		t.Errorf("Expected products to be registered, got %v", registered)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected UseResource to panic for a router without resource support")
+		}
+	}()
+	UseResource(resource, &mockRouter{})
+}
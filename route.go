@@ -0,0 +1,451 @@
+package mockapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Parameter types understood by RouteParam.
+const (
+	ParamTypeString  = "string"
+	ParamTypeInteger = "integer"
+	ParamTypeNumber  = "number"
+	ParamTypeBoolean = "boolean"
+)
+
+// RouteParam declares a path or query parameter of a RouteDefinition. Path
+// parameters that are not declared are required strings.
+type RouteParam struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+	Default  string `json:"default"`
+}
+
+// RouteDefinition describes a mock endpoint without code. Path uses {name}
+// placeholders for path parameters. Body and header values are text/template
+// templates, see MockRoute.Respond for the data and functions they can use.
+type RouteDefinition struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Params  []RouteParam      `json:"params"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers"`
+	DelayMS int               `json:"delay_ms"`
+	Body    string            `json:"body"`
+}
+
+// RouteResponse is the rendered response of a MockRoute. Routers wait for
+// Delay before writing it.
+type RouteResponse struct {
+	Status  int
+	Headers map[string]string
+	Delay   time.Duration
+	Body    []byte
+}
+
+// MockRoute is a validated RouteDefinition whose templates read records
+// through a Service.
+type MockRoute struct {
+	Method  string
+	Path    string
+	Params  []RouteParam
+	status  int
+	delay   time.Duration
+	body    *template.Template
+	headers map[string]*template.Template
+}
+
+// MockRouteRouter is implemented by routers that can serve routes loaded
+// from a definition file.
+type MockRouteRouter interface {
+	SetupMockRoute(route *MockRoute) error
+}
+
+type routeFile struct {
+	Routes []RouteDefinition `json:"routes"`
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^{}]*)\}`)
+var paramNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// LoadRoutes reads route definitions in the JSON or YAML format, which is an
+// object with a routes list. Definitions are checked by NewMockRoutes.
+func LoadRoutes(r io.Reader, format string) ([]RouteDefinition, error) {
+	var data []byte
+	var err error
+	switch format {
+	case SeedFormatJSON:
+		data, err = io.ReadAll(r)
+	case SeedFormatYAML:
+		// Decode YAML generically and re-encode it so both formats share the
+		// JSON field names and unknown field checks.
+		var raw map[string]any
+		if err := yaml.NewDecoder(r).Decode(&raw); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("routes: %w", err)
+		}
+		data, err = json.Marshal(raw)
+	default:
+		return nil, fmt.Errorf("routes: unsupported format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("routes: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var file routeFile
+	if err := dec.Decode(&file); err != nil {
+		return nil, fmt.Errorf("routes: %w", err)
+	}
+	return file.Routes, nil
+}
+
+// LoadRoutesFile reads route definitions from a file on disk, picking the
+// format from its extension.
+func LoadRoutesFile(name string) ([]RouteDefinition, error) {
+	return LoadRoutesFS(os.DirFS(filepath.Dir(name)), filepath.Base(name))
+}
+
+// LoadRoutesFS reads route definitions from a file in fsys, picking the
+// format from its extension.
+func LoadRoutesFS(fsys fs.FS, name string) ([]RouteDefinition, error) {
+	format, err := SeedFormatFromName(name)
+	if err != nil || format == SeedFormatCSV {
+		return nil, fmt.Errorf("routes: unsupported file %q, expected .json, .yaml or .yml", name)
+	}
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadRoutes(f, format)
+}
+
+// templateFuncs gives templates read access to the records of service.
+func templateFuncs(service Service) template.FuncMap {
+	return template.FuncMap{
+		"book":       service.GetBookByID,
+		"books":      func(query string) (PaginatedBooks, error) { return service.GetBooks(parseBookQueryString(query)) },
+		"reviews":    service.GetBookReviews,
+		"author":     service.GetAuthorByID,
+		"authors":    service.GetAuthors,
+		"category":   service.GetCategoryByID,
+		"categories": service.GetCategories,
+		"json": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+		"default": func(defaultValue string, value string) string {
+			if value == "" {
+				return defaultValue
+			}
+			return value
+		},
+	}
+}
+
+// parseBookQueryString reads a book query written like the query string of
+// GET /api/books, e.g. "author=Martin Fowler&sort=title".
+func parseBookQueryString(query string) BookQuery {
+	values, _ := url.ParseQuery(query)
+	page, err := strconv.Atoi(values.Get("page"))
+	if err != nil {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(values.Get("page_size"))
+	if err != nil {
+		pageSize = 10
+	}
+	return BookQuery{
+		Page:     page,
+		PageSize: pageSize,
+		Search:   values.Get("search"),
+		Sort:     values.Get("sort"),
+		Order:    strings.ToLower(values.Get("order")),
+		Category: values["category"],
+		Author:   values["author"],
+	}
+}
+
+// NewMockRoutes validates the definitions and parses their templates.
+func NewMockRoutes(definitions []RouteDefinition, service Service) ([]*MockRoute, error) {
+	funcs := templateFuncs(service)
+	seen := map[string]bool{}
+	routes := make([]*MockRoute, 0, len(definitions))
+	for _, def := range definitions {
+		route, err := newMockRoute(def, funcs)
+		if err != nil {
+			return nil, fmt.Errorf("routes: %s %s: %w", def.Method, def.Path, err)
+		}
+		key := route.Method + " " + route.Path
+		if seen[key] {
+			return nil, fmt.Errorf("routes: %s is defined more than once", key)
+		}
+		seen[key] = true
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+func newMockRoute(def RouteDefinition, funcs template.FuncMap) (*MockRoute, error) {
+	route := &MockRoute{
+		Method:  strings.ToUpper(def.Method),
+		Path:    def.Path,
+		status:  def.Status,
+		delay:   time.Duration(def.DelayMS) * time.Millisecond,
+		headers: map[string]*template.Template{},
+	}
+	switch route.Method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return nil, fmt.Errorf("unsupported method %q", def.Method)
+	}
+	if !strings.HasPrefix(route.Path, "/") {
+		return nil, errors.New("path should start with /")
+	}
+	if route.status == 0 {
+		route.status = 200
+	}
+	if route.status < 100 || route.status > 599 {
+		return nil, fmt.Errorf("invalid status %d", def.Status)
+	}
+	if def.DelayMS < 0 {
+		return nil, errors.New("delay_ms should not be negative")
+	}
+
+	declared := map[string]RouteParam{}
+	for _, param := range def.Params {
+		if param.In == "" {
+			param.In = "query"
+		}
+		if param.Type == "" {
+			param.Type = ParamTypeString
+		}
+		if !paramNamePattern.MatchString(param.Name) {
+			return nil, fmt.Errorf("invalid parameter name %q", param.Name)
+		}
+		if param.In != "path" && param.In != "query" {
+			return nil, fmt.Errorf("parameter %s: in should be path or query, got %q", param.Name, param.In)
+		}
+		switch param.Type {
+		case ParamTypeString, ParamTypeInteger, ParamTypeNumber, ParamTypeBoolean:
+		default:
+			return nil, fmt.Errorf("parameter %s: unsupported type %q", param.Name, param.Type)
+		}
+		if param.Default != "" {
+			if _, err := parseParam(param, param.Default); err != nil {
+				return nil, fmt.Errorf("parameter %s: default %w", param.Name, err)
+			}
+		}
+		if _, ok := declared[param.In+" "+param.Name]; ok {
+			return nil, fmt.Errorf("parameter %s is declared more than once", param.Name)
+		}
+		declared[param.In+" "+param.Name] = param
+	}
+
+	inPath := map[string]bool{}
+	for _, match := range pathParamPattern.FindAllStringSubmatch(route.Path, -1) {
+		name := match[1]
+		if !paramNamePattern.MatchString(name) || inPath[name] {
+			return nil, fmt.Errorf("invalid path parameter {%s}", name)
+		}
+		inPath[name] = true
+		param, ok := declared["path "+name]
+		if !ok {
+			param = RouteParam{Name: name, In: "path", Type: ParamTypeString}
+		}
+		param.Required = true
+		route.Params = append(route.Params, param)
+	}
+	for _, param := range def.Params {
+		if param.In == "path" && !inPath[param.Name] {
+			return nil, fmt.Errorf("path parameter %s does not appear in the path", param.Name)
+		}
+		if param.In == "" || param.In == "query" {
+			route.Params = append(route.Params, declared["query "+param.Name])
+		}
+	}
+
+	body, err := template.New("body").Funcs(funcs).Parse(def.Body)
+	if err != nil {
+		return nil, err
+	}
+	route.body = body
+	for name, value := range def.Headers {
+		header, err := template.New(name).Funcs(funcs).Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		route.headers[http.CanonicalHeaderKey(name)] = header
+	}
+	return route, nil
+}
+
+// parseParam checks that value has the type of param and converts it for
+// use in templates.
+func parseParam(param RouteParam, value string) (any, error) {
+	switch param.Type {
+	case ParamTypeInteger:
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n, nil
+		}
+	case ParamTypeNumber:
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n, nil
+		}
+	case ParamTypeBoolean:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b, nil
+		}
+	default:
+		return value, nil
+	}
+	return nil, NewInvalidParamError(param.Name, param.Type, value)
+}
+
+// unwrapTemplateError returns the ValidationError, NotFoundError or
+// ConflictError raised by a template function so routers can map it to a
+// status code.
+func unwrapTemplateError(err error) error {
+	var validationErr *ValidationError
+	var notFoundErr *NotFoundError
+	var conflictErr *ConflictError
+	switch {
+	case errors.As(err, &validationErr):
+		return validationErr
+	case errors.As(err, &notFoundErr):
+		return notFoundErr
+	case errors.As(err, &conflictErr):
+		return conflictErr
+	}
+	return err
+}
+
+// Respond checks the request parameters and renders the response. Templates
+// get the request as .Method, .Path, .Params (path parameters) and .Query,
+// where declared parameters are converted to their type and take their
+// defaults. They can call book, books, reviews, author, authors, category
+// and categories to read records, json to encode a value and default to
+// replace an empty string.
+func (r *MockRoute) Respond(pathParams map[string]string, query url.Values) (RouteResponse, error) {
+	data := struct {
+		Method string
+		Path   string
+		Params map[string]any
+		Query  map[string]any
+	}{
+		Method: r.Method,
+		Path:   r.Path,
+		Params: map[string]any{},
+		Query:  map[string]any{},
+	}
+	for name, values := range query {
+		data.Query[name] = values[0]
+	}
+	for _, param := range r.Params {
+		value := query.Get(param.Name)
+		target := data.Query
+		if param.In == "path" {
+			value = pathParams[param.Name]
+			target = data.Params
+		}
+		if value == "" {
+			value = param.Default
+		}
+		if value == "" {
+			if param.Required {
+				return RouteResponse{}, NewRequiredFieldError(param.Name)
+			}
+			continue
+		}
+		parsed, err := parseParam(param, value)
+		if err != nil {
+			return RouteResponse{}, err
+		}
+		target[param.Name] = parsed
+	}
+
+	var body bytes.Buffer
+	if err := r.body.Execute(&body, data); err != nil {
+		return RouteResponse{}, unwrapTemplateError(err)
+	}
+	headers := map[string]string{}
+	for name, tmpl := range r.headers {
+		var value strings.Builder
+		if err := tmpl.Execute(&value, data); err != nil {
+			return RouteResponse{}, unwrapTemplateError(err)
+		}
+		headers[name] = value.String()
+	}
+	if _, ok := headers["Content-Type"]; !ok && body.Len() > 0 {
+		headers["Content-Type"] = "application/json; charset=utf-8"
+	}
+
+	return RouteResponse{
+		Status:  r.status,
+		Headers: headers,
+		Delay:   r.delay,
+		Body:    body.Bytes(),
+	}, nil
+}
+
+// RespondFor is Respond with templates that read records through service
+// instead of the service the route was created with, e.g. the service of a
+// session. A nil service is the same as Respond.
+func (r *MockRoute) RespondFor(service Service, pathParams map[string]string, query url.Values) (RouteResponse, error) {
+	if service == nil {
+		return r.Respond(pathParams, query)
+	}
+	funcs := templateFuncs(service)
+	bound := *r
+	body, err := r.body.Clone()
+	if err != nil {
+		return RouteResponse{}, err
+	}
+	bound.body = body.Funcs(funcs)
+	bound.headers = make(map[string]*template.Template, len(r.headers))
+	for name, tmpl := range r.headers {
+		header, err := tmpl.Clone()
+		if err != nil {
+			return RouteResponse{}, err
+		}
+		bound.headers[name] = header.Funcs(funcs)
+	}
+	return bound.Respond(pathParams, query)
+}
+
+// UseRoutes registers the defined routes on r, which must also implement
+// MockRouteRouter. Templates read records from ds, which should already be
+// populated, e.g. by Use.
+func UseRoutes(definitions []RouteDefinition, ds DataSource, r Router) {
+	routeRouter, ok := r.(MockRouteRouter)
+	if !ok {
+		panic(fmt.Sprintf("router %T cannot serve defined routes", r))
+	}
+	routes, err := NewMockRoutes(definitions, NewService(ds))
+	if err != nil {
+		panic(err)
+	}
+	for _, route := range routes {
+		if err := routeRouter.SetupMockRoute(route); err != nil {
+			panic(err)
+		}
+	}
+}
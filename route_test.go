@@ -0,0 +1,239 @@
+package mockapi
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+const testRoutesYAML = `
+routes:
+  - method: get
+    path: /api/orders/{id}
+    params:
+      - name: id
+        in: path
+        type: integer
+      - name: verbose
+        type: boolean
+        default: "false"
+    status: 200
+    delay_ms: 50
+    headers:
+      x-order-id: "{{ .Params.id }}"
+    body: |
+      {"id": {{ .Params.id }}, "verbose": {{ .Query.verbose }}, "book": {{ book "1" | json }}}
+`
+
+func newTestRoutes(t *testing.T, definitions []RouteDefinition, ds *mockDataSource) []*MockRoute {
+	routes, err := NewMockRoutes(definitions, NewService(ds))
+	if err != nil {
+		t.Fatalf("NewMockRoutes failed: %v", err)
+	}
+	return routes
+}
+
+func TestLoadRoutes_YAMLAndJSON(t *testing.T) {
+	fsys := fstest.MapFS{
+		"routes.yaml": {Data: []byte(testRoutesYAML)},
+		"routes.json": {Data: []byte(`{"routes": [{"method": "POST", "path": "/api/orders", "status": 201, "body": "{}"}]}`)},
+		"routes.csv":  {Data: []byte("method,path\n")},
+	}
+
+	definitions, err := LoadRoutesFS(fsys, "routes.yaml")
+	if err != nil {
+		t.Fatalf("LoadRoutesFS failed: %v", err)
+	}
+	if len(definitions) != 1 || definitions[0].Path != "/api/orders/{id}" || len(definitions[0].Params) != 2 || definitions[0].DelayMS != 50 {
+		t.Errorf("Unexpected definitions %+v", definitions)
+	}
+
+	definitions, err = LoadRoutesFS(fsys, "routes.json")
+	if err != nil {
+		t.Fatalf("LoadRoutesFS failed: %v", err)
+	}
+	if len(definitions) != 1 || definitions[0].Status != 201 {
+		t.Errorf("Unexpected definitions %+v", definitions)
+	}
+
+	if _, err := LoadRoutesFS(fsys, "routes.csv"); err == nil {
+		t.Error("Expected error for a CSV route file")
+	}
+	if _, err := LoadRoutes(strings.NewReader(`{"routes": [{"path": "/x", "latency": 5}]}`), SeedFormatJSON); err == nil {
+		t.Error("Expected error for an unknown field")
+	}
+}
+
+func TestNewMockRoutes_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		definitions []RouteDefinition
+	}{
+		{"unsupported method", []RouteDefinition{{Method: "TRACE", Path: "/x"}}},
+		{"relative path", []RouteDefinition{{Method: "GET", Path: "x"}}},
+		{"invalid status", []RouteDefinition{{Method: "GET", Path: "/x", Status: 42}}},
+		{"negative delay", []RouteDefinition{{Method: "GET", Path: "/x", DelayMS: -1}}},
+		{"unknown param type", []RouteDefinition{{Method: "GET", Path: "/x", Params: []RouteParam{{Name: "q", Type: "date"}}}}},
+		{"invalid default", []RouteDefinition{{Method: "GET", Path: "/x", Params: []RouteParam{{Name: "q", Type: ParamTypeInteger, Default: "ten"}}}}},
+		{"path param missing from path", []RouteDefinition{{Method: "GET", Path: "/x", Params: []RouteParam{{Name: "id", In: "path"}}}}},
+		{"invalid template", []RouteDefinition{{Method: "GET", Path: "/x", Body: "{{ .Params.id "}}},
+		{"duplicate route", []RouteDefinition{{Method: "GET", Path: "/x"}, {Method: "get", Path: "/x"}}},
+	}
+
+	for _, tt := range tests {
+		if _, err := NewMockRoutes(tt.definitions, NewService(&mockDataSource{})); err == nil {
+			t.Errorf("Expected error for %s", tt.name)
+		}
+	}
+}
+
+func TestMockRoute_Respond(t *testing.T) {
+	definitions, _ := LoadRoutes(strings.NewReader(testRoutesYAML), SeedFormatYAML)
+	ds := &mockDataSource{
+		getBookByIDFunc: func(id string) (Book, error) {
+			return Book{ID: 1, Title: "Clean Code"}, nil
+		},
+	}
+	route := newTestRoutes(t, definitions, ds)[0]
+
+	if route.Method != "GET" || len(route.Params) != 2 {
+		t.Errorf("Unexpected route %+v", route)
+	}
+
+	response, err := route.Respond(map[string]string{"id": "7"}, url.Values{})
+	if err != nil {
+		t.Fatalf("Respond failed: %v", err)
+	}
+	if response.Status != 200 || response.Delay != 50*time.Millisecond {
+		t.Errorf("Unexpected response %+v", response)
+	}
+	if response.Headers["X-Order-Id"] != "7" {
+		t.Errorf("Expected X-Order-Id header 7, got %v", response.Headers)
+	}
+	if !strings.HasPrefix(response.Headers["Content-Type"], "application/json") {
+		t.Errorf("Expected JSON content type, got %v", response.Headers)
+	}
+	body := string(response.Body)
+	if !strings.Contains(body, `"id": 7, "verbose": false`) || !strings.Contains(body, `"title":"Clean Code"`) {
+		t.Errorf("Unexpected body %s", body)
+	}
+
+	_, err = route.Respond(map[string]string{"id": "seven"}, url.Values{})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Expected ValidationError for a non-integer id, got %v", err)
+	}
+	_, err = route.Respond(map[string]string{"id": "7"}, url.Values{"verbose": {"maybe"}})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Expected ValidationError for a non-boolean query, got %v", err)
+	}
+}
+
+func TestMockRoute_Respond_RecordErrors(t *testing.T) {
+	ds := &mockDataSource{
+		getBookByIDFunc: func(id string) (Book, error) {
+			return Book{}, NewBookNotFoundError(id)
+		},
+	}
+	route := newTestRoutes(t, []RouteDefinition{
+		{Method: "GET", Path: "/api/loans/{book_id}", Body: `{{ book .Params.book_id | json }}`},
+	}, ds)[0]
+
+	_, err := route.Respond(map[string]string{"book_id": "99"}, url.Values{})
+	if _, ok := err.(*NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %v", err)
+	}
+}
+
+func TestMockRoute_RespondFor(t *testing.T) {
+	shared := &mockDataSource{
+		getBookByIDFunc: func(id string) (Book, error) {
+			return Book{ID: 1, Title: "Shared"}, nil
+		},
+	}
+	other := &mockDataSource{
+		getBookByIDFunc: func(id string) (Book, error) {
+			return Book{ID: 1, Title: "Session"}, nil
+		},
+	}
+	route := newTestRoutes(t, []RouteDefinition{
+		{Method: "GET", Path: "/api/loans/{book_id}", Headers: map[string]string{"X-Title": `{{ (book "1").Title }}`}, Body: `{{ (book .Params.book_id).Title }}`},
+	}, shared)[0]
+
+	response, err := route.RespondFor(NewService(other), map[string]string{"book_id": "1"}, url.Values{})
+	if err != nil {
+		t.Fatalf("RespondFor failed: %v", err)
+	}
+	if string(response.Body) != "Session" || response.Headers["X-Title"] != "Session" {
+		t.Errorf("Expected the session's book, got body %s and headers %v", response.Body, response.Headers)
+	}
+
+	response, err = route.RespondFor(nil, map[string]string{"book_id": "1"}, url.Values{})
+	if err != nil {
+		t.Fatalf("RespondFor failed: %v", err)
+	}
+	if string(response.Body) != "Shared" {
+		t.Errorf("Expected the shared book without a service, got %s", response.Body)
+	}
+	response, _ = route.Respond(map[string]string{"book_id": "1"}, url.Values{})
+	if string(response.Body) != "Shared" {
+		t.Errorf("Expected RespondFor to leave the route's templates alone, got %s", response.Body)
+	}
+}
+
+func TestMockRoute_Respond_BooksQuery(t *testing.T) {
+	var received BookQuery
+	ds := &mockDataSource{
+		getBooksFunc: func(query BookQuery) ([]Book, error) {
+			received = query
+			return []Book{{ID: 3}}, nil
+		},
+	}
+	route := newTestRoutes(t, []RouteDefinition{
+		{Method: "GET", Path: "/api/picks", Body: `{{ (books "author=Martin Fowler&sort=title&page_size=3").Data | json }}`},
+	}, ds)[0]
+
+	response, err := route.Respond(nil, url.Values{})
+	if err != nil {
+		t.Fatalf("Respond failed: %v", err)
+	}
+	if received.PageSize != 3 || received.Sort != "title" || len(received.Author) != 1 || received.Author[0] != "Martin Fowler" {
+		t.Errorf("Unexpected book query %+v", received)
+	}
+	if !strings.HasPrefix(string(response.Body), `[{"id":3`) {
+		t.Errorf("Unexpected body %s", response.Body)
+	}
+}
+
+type mockRouteRouter struct {
+	mockRouter
+	setupMockRouteFunc func(route *MockRoute) error
+}
+
+func (m *mockRouteRouter) SetupMockRoute(route *MockRoute) error {
+	if m.setupMockRouteFunc != nil {
+		return m.setupMockRouteFunc(route)
+	}
+	return nil
+}
+
+func TestUseRoutes(t *testing.T) {
+	var registered []string
+	UseRoutes([]RouteDefinition{{Method: "GET", Path: "/a"}, {Method: "POST", Path: "/a"}}, &mockDataSource{}, &mockRouteRouter{
+		setupMockRouteFunc: func(route *MockRoute) error {
+			registered = append(registered, route.Method+" "+route.Path)
+			return nil
+		},
+	})
+	if len(registered) != 2 || registered[0] != "GET /a" || registered[1] != "POST /a" {
+		t.Errorf("Expected both routes to be registered, got %v", registered)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected UseRoutes to panic for a router without defined route support")
+		}
+	}()
+	UseRoutes(nil, &mockDataSource{}, &mockRouter{})
+}
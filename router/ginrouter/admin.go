@@ -0,0 +1,178 @@
+package ginrouter
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/anggaaryas/go-mockapi"
+	"github.com/gin-gonic/gin"
+)
+
+// SnapshotList is the response of the snapshot admin endpoints.
+type SnapshotList struct {
+	Snapshots []string `json:"snapshots"`
+}
+
+// Admin resets, snapshots, restores, exports and imports the data of a data
+// source while the server is running. Snapshots are kept in memory.
+type Admin struct {
+	mu        sync.Mutex
+	ds        mockapi.SnapshotDataSource
+	snapshots map[string]mockapi.Dataset
+}
+
+// NewAdmin returns an Admin for ds, which must implement
+// mockapi.SnapshotDataSource.
+func NewAdmin(ds mockapi.DataSource) (*Admin, error) {
+	snapshotter, ok := ds.(mockapi.SnapshotDataSource)
+	if !ok {
+		return nil, fmt.Errorf("data source %T cannot be snapshotted", ds)
+	}
+	return &Admin{
+		ds:        snapshotter,
+		snapshots: map[string]mockapi.Dataset{},
+	}, nil
+}
+
+// Reset replaces all data with the data source's seed.
+func (a *Admin) Reset() error {
+	return a.ds.ResetData(nil)
+}
+
+// Snapshot stores the current data under name, replacing an older snapshot
+// with the same name.
+func (a *Admin) Snapshot(name string) error {
+	data, err := a.ds.ExportData()
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshots[name] = data
+	return nil
+}
+
+// Restore replaces all data with the named snapshot. The snapshot is kept so
+// it can be restored again.
+func (a *Admin) Restore(name string) error {
+	a.mu.Lock()
+	data, ok := a.snapshots[name]
+	a.mu.Unlock()
+
+	if !ok {
+		return newSnapshotNotFoundError(name)
+	}
+	return a.ds.ImportData(data)
+}
+
+// DeleteSnapshot removes the named snapshot.
+func (a *Admin) DeleteSnapshot(name string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.snapshots[name]; !ok {
+		return newSnapshotNotFoundError(name)
+	}
+	delete(a.snapshots, name)
+	return nil
+}
+
+// Snapshots returns the names of the stored snapshots in sorted order.
+func (a *Admin) Snapshots() SnapshotList {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	list := SnapshotList{Snapshots: []string{}}
+	for name := range a.snapshots {
+		list.Snapshots = append(list.Snapshots, name)
+	}
+	slices.Sort(list.Snapshots)
+	return list
+}
+
+func newSnapshotNotFoundError(name string) *mockapi.NotFoundError {
+	return &mockapi.NotFoundError{Message: fmt.Sprintf("not found: snapshot %s does not exist", name)}
+}
+
+// writeAdminError writes err with its own status code when it has one, or
+// as a 500 otherwise.
+func writeAdminError(c *gin.Context, err error) {
+	var customErr CustomError
+	if errors.As(err, &customErr) {
+		writeCustomError(c, customErr)
+		return
+	}
+	c.JSON(500, mockapi.APIError{StatusCode: 500, Message: err.Error()})
+}
+
+// SetupAdminRoute registers the admin endpoints under /mockapi/admin:
+//
+//	POST   /reset                    reset to the seed
+//	GET    /snapshots                list snapshots
+//	POST   /snapshots/:name          snapshot the current data
+//	POST   /snapshots/:name/restore  restore a snapshot
+//	DELETE /snapshots/:name          delete a snapshot
+//	GET    /export                   export all data as a mockapi.Dataset
+//	PUT    /import                   replace all data with a mockapi.Dataset
+func (a *Admin) SetupAdminRoute(r gin.IRouter) {
+	admin := r.Group(mockapi.GetMockapiPath() + "/admin")
+
+	admin.POST("/reset", func(c *gin.Context) {
+		if err := a.Reset(); err != nil {
+			writeAdminError(c, err)
+			return
+		}
+		c.Status(204)
+	})
+	admin.GET("/snapshots", func(c *gin.Context) {
+		c.JSON(200, a.Snapshots())
+	})
+	admin.POST("/snapshots/:name", func(c *gin.Context) {
+		if err := a.Snapshot(c.Param("name")); err != nil {
+			writeAdminError(c, err)
+			return
+		}
+		c.JSON(201, a.Snapshots())
+	})
+	admin.POST("/snapshots/:name/restore", func(c *gin.Context) {
+		if err := a.Restore(c.Param("name")); err != nil {
+			writeAdminError(c, err)
+			return
+		}
+		c.Status(204)
+	})
+	admin.DELETE("/snapshots/:name", func(c *gin.Context) {
+		if err := a.DeleteSnapshot(c.Param("name")); err != nil {
+			writeAdminError(c, err)
+			return
+		}
+		c.Status(204)
+	})
+	admin.GET("/export", func(c *gin.Context) {
+		data, err := a.ds.ExportData()
+		if err != nil {
+			writeAdminError(c, err)
+			return
+		}
+		c.JSON(200, data)
+	})
+	admin.PUT("/import", func(c *gin.Context) {
+		var data mockapi.Dataset
+		if err := c.ShouldBindJSON(&data); err != nil {
+			writeCustomError(c, NewInvalidBodyError(err.Error()))
+			return
+		}
+		if err := mockapi.ValidateDataset(&data); err != nil {
+			writeCustomError(c, NewInvalidBodyError(err.Error()))
+			return
+		}
+		if err := a.ds.ImportData(data); err != nil {
+			writeAdminError(c, err)
+			return
+		}
+		c.Status(204)
+	})
+}
@@ -0,0 +1,159 @@
+package ginrouter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anggaaryas/go-mockapi"
+	"github.com/gin-gonic/gin"
+)
+
+type mockSnapshotDataSource struct {
+	mockResettableDataSource
+	exportDataFunc func() (mockapi.Dataset, error)
+	importDataFunc func(data mockapi.Dataset) error
+}
+
+func (m *mockSnapshotDataSource) ExportData() (mockapi.Dataset, error) {
+	if m.exportDataFunc != nil {
+		return m.exportDataFunc()
+	}
+	return mockapi.Dataset{}, nil
+}
+
+func (m *mockSnapshotDataSource) ImportData(data mockapi.Dataset) error {
+	if m.importDataFunc != nil {
+		return m.importDataFunc(data)
+	}
+	return nil
+}
+
+// newStoredDataSource returns a mock whose export returns the last import.
+func newStoredDataSource(data mockapi.Dataset) *mockSnapshotDataSource {
+	ds := &mockSnapshotDataSource{}
+	ds.exportDataFunc = func() (mockapi.Dataset, error) {
+		return data, nil
+	}
+	ds.importDataFunc = func(imported mockapi.Dataset) error {
+		data = imported
+		return nil
+	}
+	return ds
+}
+
+func setupAdminRouter(t *testing.T, ds mockapi.DataSource) *gin.Engine {
+	admin, err := NewAdmin(ds)
+	if err != nil {
+		t.Fatalf("NewAdmin failed: %v", err)
+	}
+	r := setupTestRouter()
+	admin.SetupAdminRoute(r)
+	return r
+}
+
+func TestNewAdmin_NotSnapshotDataSource(t *testing.T) {
+	if _, err := NewAdmin(&mockResettableDataSource{}); err == nil {
+		t.Error("Expected error for a data source that cannot be snapshotted")
+	}
+}
+
+func TestAdmin_Reset(t *testing.T) {
+	var seeds [][]mockapi.Book
+	ds := &mockSnapshotDataSource{}
+	ds.resetDataFunc = func(seed []mockapi.Book) error {
+		seeds = append(seeds, seed)
+		return nil
+	}
+	r := setupAdminRouter(t, ds)
+
+	w := serve(r, "POST", mockapi.GetMockapiPath()+"/admin/reset")
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected status code %d, got %d", http.StatusNoContent, w.Code)
+	}
+	if len(seeds) != 1 || seeds[0] != nil {
+		t.Errorf("Expected the data source to be reset to its seed, got %v", seeds)
+	}
+
+	ds.resetDataFunc = func(seed []mockapi.Book) error {
+		return errors.New("database is locked")
+	}
+	if w := serve(r, "POST", mockapi.GetMockapiPath()+"/admin/reset"); w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status code %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+}
+
+func TestAdmin_SnapshotAndRestore(t *testing.T) {
+	ds := newStoredDataSource(mockapi.Dataset{Books: []mockapi.Book{{ID: 1, Title: "Before", Author: "A"}}})
+	r := setupAdminRouter(t, ds)
+	path := mockapi.GetMockapiPath() + "/admin/snapshots"
+
+	w := serve(r, "POST", path+"/before-test")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d", http.StatusCreated, w.Code)
+	}
+	var list SnapshotList
+	json.Unmarshal(w.Body.Bytes(), &list)
+	if len(list.Snapshots) != 1 || list.Snapshots[0] != "before-test" {
+		t.Errorf("Expected snapshot before-test, got %v", list.Snapshots)
+	}
+
+	ds.ImportData(mockapi.Dataset{Books: []mockapi.Book{{ID: 1, Title: "After", Author: "A"}}})
+	if w := serve(r, "POST", path+"/before-test/restore"); w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status code %d, got %d", http.StatusNoContent, w.Code)
+	}
+	if data, _ := ds.ExportData(); data.Books[0].Title != "Before" {
+		t.Errorf("Expected the snapshot to be restored, got %+v", data.Books)
+	}
+
+	if w := serve(r, "POST", path+"/missing/restore"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, w.Code)
+	}
+	if w := serve(r, "DELETE", path+"/before-test"); w.Code != http.StatusNoContent {
+		t.Errorf("Expected status code %d, got %d", http.StatusNoContent, w.Code)
+	}
+	if w := serve(r, "DELETE", path+"/before-test"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	json.Unmarshal(serve(r, "GET", path).Body.Bytes(), &list)
+	if len(list.Snapshots) != 0 {
+		t.Errorf("Expected no snapshots, got %v", list.Snapshots)
+	}
+}
+
+func TestAdmin_ExportImport(t *testing.T) {
+	ds := newStoredDataSource(mockapi.Dataset{Books: []mockapi.Book{{ID: 1, Title: "Exported", Author: "A"}}})
+	r := setupAdminRouter(t, ds)
+
+	w := serve(r, "GET", mockapi.GetMockapiPath()+"/admin/export")
+	var data mockapi.Dataset
+	json.Unmarshal(w.Body.Bytes(), &data)
+	if w.Code != http.StatusOK || len(data.Books) != 1 || data.Books[0].Title != "Exported" {
+		t.Errorf("Unexpected export %d %s", w.Code, w.Body.String())
+	}
+
+	body := []byte(`{"books": [{"title": "Imported", "author": "B", "category": "C"}]}`)
+	req, _ := http.NewRequest("PUT", mockapi.GetMockapiPath()+"/admin/import", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status code %d, got %d", http.StatusNoContent, w.Code)
+	}
+	data, _ = ds.ExportData()
+	if len(data.Books) != 1 || data.Books[0].ID != 1 || data.Books[0].Title != "Imported" || len(data.Authors) != 1 {
+		t.Errorf("Expected the validated dataset to be imported, got %+v", data)
+	}
+
+	for _, body := range []string{`{"books": [{"title": "No author"}]}`, `{"books": 1}`} {
+		req, _ := http.NewRequest("PUT", mockapi.GetMockapiPath()+"/admin/import", bytes.NewReader([]byte(body)))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d for %s, got %d", http.StatusBadRequest, body, w.Code)
+		}
+	}
+}
@@ -0,0 +1,366 @@
+package ginrouter
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 24 * time.Hour
+
+	accessTokenType  = "access"
+	refreshTokenType = "refresh"
+
+	authClaimsKey = "mockapi.auth.claims"
+)
+
+// AuthUser is an account that can log in to the mock API. Name and Email are
+// only used in OpenID Connect claims.
+type AuthUser struct {
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	Roles    []string `json:"roles,omitempty"`
+	Name     string   `json:"name,omitempty"`
+	Email    string   `json:"email,omitempty"`
+}
+
+// DefaultAuthUsers returns the accounts used when AuthConfig has no users.
+func DefaultAuthUsers() []AuthUser {
+	return []AuthUser{
+		{Username: "admin", Password: "admin123", Roles: []string{"admin"}, Name: "Ada Admin", Email: "admin@example.com"},
+		{Username: "reader", Password: "reader123", Roles: []string{"reader"}, Name: "Rey Reader", Email: "reader@example.com"},
+	}
+}
+
+// AuthConfig configures mock authentication. Routes are keyed like
+// ChaosConfig, by method and Gin route path, e.g. "DELETE /api/books/:id".
+// Those routes need a valid access token and, when roles are listed, a user
+// with one of them. TTLs default to 15 minutes and 24 hours.
+type AuthConfig struct {
+	Secret                 string              `json:"secret,omitempty"`
+	Users                  []AuthUser          `json:"users,omitempty"`
+	AccessTokenTTLSeconds  int                 `json:"access_token_ttl_seconds"`
+	RefreshTokenTTLSeconds int                 `json:"refresh_token_ttl_seconds"`
+	Routes                 map[string][]string `json:"routes,omitempty"`
+}
+
+// AuthClaims are the claims of the JWTs issued by Auth.
+type AuthClaims struct {
+	Subject   string   `json:"sub"`
+	Roles     []string `json:"roles,omitempty"`
+	Type      string   `json:"typ"`
+	ID        string   `json:"jti"`
+	IssuedAt  int64    `json:"iat"`
+	ExpiresAt int64    `json:"exp"`
+}
+
+// AuthTokens is the response of the login and refresh endpoints.
+type AuthTokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Auth issues HS256 JWTs for its users and checks them on the configured
+// routes. Refresh tokens are single use: refreshing returns a new pair and
+// revokes the old refresh token.
+type Auth struct {
+	mu         sync.Mutex
+	config     AuthConfig
+	secret     []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	users      map[string]AuthUser
+	refresh    map[string]int64
+	now        func() time.Time
+}
+
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+func NewAuth(config AuthConfig) (*Auth, error) {
+	if config.AccessTokenTTLSeconds < 0 || config.RefreshTokenTTLSeconds < 0 {
+		return nil, NewInvalidAuthConfigError("token TTLs should not be negative")
+	}
+	if len(config.Users) == 0 {
+		config.Users = DefaultAuthUsers()
+	}
+
+	auth := &Auth{
+		config:     config,
+		secret:     []byte(config.Secret),
+		accessTTL:  time.Duration(config.AccessTokenTTLSeconds) * time.Second,
+		refreshTTL: time.Duration(config.RefreshTokenTTLSeconds) * time.Second,
+		users:      map[string]AuthUser{},
+		refresh:    map[string]int64{},
+		now:        time.Now,
+	}
+	if auth.accessTTL == 0 {
+		auth.accessTTL = defaultAccessTokenTTL
+	}
+	if auth.refreshTTL == 0 {
+		auth.refreshTTL = defaultRefreshTokenTTL
+	}
+	if len(auth.secret) == 0 {
+		auth.secret = make([]byte, 32)
+		rand.Read(auth.secret)
+	}
+	for _, user := range config.Users {
+		if user.Username == "" {
+			return nil, NewInvalidAuthConfigError("users need a username")
+		}
+		if _, ok := auth.users[user.Username]; ok {
+			return nil, NewInvalidAuthConfigError(fmt.Sprintf("user %s is defined more than once", user.Username))
+		}
+		auth.users[user.Username] = user
+	}
+	return auth, nil
+}
+
+func (a *Auth) sign(claims AuthClaims) string {
+	payload, _ := json.Marshal(claims)
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// parse verifies the signature, type and expiry of a token.
+func (a *Auth) parse(token string, tokenType string) (AuthClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != jwtHeader {
+		return AuthClaims{}, NewInvalidTokenError()
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return AuthClaims{}, NewInvalidTokenError()
+	}
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return AuthClaims{}, NewInvalidTokenError()
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return AuthClaims{}, NewInvalidTokenError()
+	}
+	var claims AuthClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Type != tokenType {
+		return AuthClaims{}, NewInvalidTokenError()
+	}
+	if a.now().Unix() >= claims.ExpiresAt {
+		return AuthClaims{}, NewTokenExpiredError()
+	}
+	return claims, nil
+}
+
+func newTokenID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// issue creates a token pair for user and records the refresh token as active.
+// It must be called with a.mu held.
+func (a *Auth) issue(user AuthUser) AuthTokens {
+	now := a.now()
+	access := AuthClaims{
+		Subject:   user.Username,
+		Roles:     user.Roles,
+		Type:      accessTokenType,
+		ID:        newTokenID(),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(a.accessTTL).Unix(),
+	}
+	refresh := AuthClaims{
+		Subject:   user.Username,
+		Type:      refreshTokenType,
+		ID:        newTokenID(),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(a.refreshTTL).Unix(),
+	}
+
+	for id, expiresAt := range a.refresh {
+		if now.Unix() >= expiresAt {
+			delete(a.refresh, id)
+		}
+	}
+	a.refresh[refresh.ID] = refresh.ExpiresAt
+
+	return AuthTokens{
+		AccessToken:  a.sign(access),
+		RefreshToken: a.sign(refresh),
+		TokenType:    "Bearer",
+		ExpiresIn:    int(a.accessTTL / time.Second),
+	}
+}
+
+// Login returns a token pair when the username and password match a user.
+func (a *Auth) Login(username string, password string) (AuthTokens, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	user, ok := a.users[username]
+	if !ok || !hmac.Equal([]byte(user.Password), []byte(password)) {
+		return AuthTokens{}, NewInvalidCredentialsError()
+	}
+	return a.issue(user), nil
+}
+
+// Refresh exchanges a refresh token for a new token pair. The old refresh
+// token cannot be used again.
+func (a *Auth) Refresh(refreshToken string) (AuthTokens, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	claims, err := a.parse(refreshToken, refreshTokenType)
+	if err != nil {
+		return AuthTokens{}, err
+	}
+	if _, ok := a.refresh[claims.ID]; !ok {
+		return AuthTokens{}, NewRefreshTokenRevokedError()
+	}
+	delete(a.refresh, claims.ID)
+
+	user, ok := a.users[claims.Subject]
+	if !ok {
+		return AuthTokens{}, NewInvalidTokenError()
+	}
+	return a.issue(user), nil
+}
+
+// Authenticate checks a bearer Authorization header and returns the claims
+// of its access token. When roles are given, the user needs one of them.
+func (a *Auth) Authenticate(authorization string, roles []string) (AuthClaims, error) {
+	scheme, token, ok := strings.Cut(authorization, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return AuthClaims{}, NewMissingTokenError()
+	}
+	claims, err := a.parse(strings.TrimSpace(token), accessTokenType)
+	if err != nil {
+		return AuthClaims{}, err
+	}
+	if len(roles) > 0 && !slices.ContainsFunc(claims.Roles, func(role string) bool { return slices.Contains(roles, role) }) {
+		return AuthClaims{}, NewMissingRoleError(roles)
+	}
+	return claims, nil
+}
+
+// AuthClaimsFromContext returns the claims stored by the auth middleware.
+func AuthClaimsFromContext(c *gin.Context) (AuthClaims, bool) {
+	value, ok := c.Get(authClaimsKey)
+	if !ok {
+		return AuthClaims{}, false
+	}
+	claims, ok := value.(AuthClaims)
+	return claims, ok
+}
+
+// authChallenge returns the WWW-Authenticate challenge for err as described
+// in RFC 6750 section 3.1. Failed logins get no challenge.
+func authChallenge(err error) string {
+	var unauthorized *UnauthorizedError
+	if !errors.As(err, &unauthorized) {
+		return ""
+	}
+	switch unauthorized.Message {
+	case NewMissingTokenError().Message:
+		return "Bearer"
+	case NewInvalidCredentialsError().Message:
+		return ""
+	default:
+		return `Bearer error="invalid_token"`
+	}
+}
+
+func abortWithAuthError(c *gin.Context, err error) {
+	if challenge := authChallenge(err); challenge != "" {
+		c.Header("WWW-Authenticate", challenge)
+	}
+	c.Abort()
+	writeAdminError(c, err)
+}
+
+// Middleware returns the Gin middleware that protects the configured routes.
+// Install it with r.Use before calling SetupMockApiRoute so it wraps the mock
+// routes.
+func (a *Auth) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		roles, ok := a.config.Routes[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			c.Next()
+			return
+		}
+		claims, err := a.Authenticate(c.GetHeader("Authorization"), roles)
+		if err != nil {
+			abortWithAuthError(c, err)
+			return
+		}
+		c.Set(authClaimsKey, claims)
+		c.Next()
+	}
+}
+
+// SetupAuthRoute registers POST /api/auth/login, POST /api/auth/refresh and
+// GET /api/auth/me, which returns the claims of the caller's access token.
+func (a *Auth) SetupAuthRoute(r gin.IRouter) {
+	auth := r.Group("/api/auth")
+
+	auth.POST("/login", func(c *gin.Context) {
+		var req loginRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeCustomError(c, NewInvalidBodyError(err.Error()))
+			return
+		}
+		tokens, err := a.Login(req.Username, req.Password)
+		if err != nil {
+			abortWithAuthError(c, err)
+			return
+		}
+		c.JSON(200, tokens)
+	})
+	auth.POST("/refresh", func(c *gin.Context) {
+		var req refreshRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeCustomError(c, NewInvalidBodyError(err.Error()))
+			return
+		}
+		tokens, err := a.Refresh(req.RefreshToken)
+		if err != nil {
+			abortWithAuthError(c, err)
+			return
+		}
+		c.JSON(200, tokens)
+	})
+	auth.GET("/me", func(c *gin.Context) {
+		claims, err := a.Authenticate(c.GetHeader("Authorization"), nil)
+		if err != nil {
+			abortWithAuthError(c, err)
+			return
+		}
+		c.JSON(200, claims)
+	})
+}
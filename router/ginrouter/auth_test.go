@@ -0,0 +1,225 @@
+package ginrouter
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anggaaryas/go-mockapi"
+	"github.com/gin-gonic/gin"
+)
+
+func setupAuthRouter(t *testing.T, config AuthConfig) (*gin.Engine, *Auth, *time.Time) {
+	auth, err := NewAuth(config)
+	if err != nil {
+		t.Fatalf("NewAuth failed: %v", err)
+	}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	auth.now = func() time.Time { return now }
+
+	r := setupTestRouter()
+	r.Use(auth.Middleware())
+	auth.SetupAuthRoute(r)
+	Create(r).SetupMockApiRoute(&mockService{})
+	return r, auth, &now
+}
+
+func postJSON(r *gin.Engine, path string, body any) *httptest.ResponseRecorder {
+	data, _ := json.Marshal(body)
+	req, _ := http.NewRequest("POST", path, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func login(t *testing.T, r *gin.Engine, username string, password string) AuthTokens {
+	w := postJSON(r, "/api/auth/login", loginRequest{Username: username, Password: password})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected login to succeed, got %d %s", w.Code, w.Body.String())
+	}
+	var tokens AuthTokens
+	json.Unmarshal(w.Body.Bytes(), &tokens)
+	return tokens
+}
+
+func deleteBook(r *gin.Engine, token string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("DELETE", "/api/books/1", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestNewAuth_InvalidConfig(t *testing.T) {
+	for _, config := range []AuthConfig{
+		{AccessTokenTTLSeconds: -1},
+		{Users: []AuthUser{{Password: "secret"}}},
+		{Users: []AuthUser{{Username: "a"}, {Username: "a"}}},
+	} {
+		if _, err := NewAuth(config); err == nil {
+			t.Errorf("Expected error for config %+v", config)
+		}
+	}
+}
+
+func TestAuth_Login(t *testing.T) {
+	r, _, _ := setupAuthRouter(t, AuthConfig{AccessTokenTTLSeconds: 60})
+
+	tokens := login(t, r, "admin", "admin123")
+	if tokens.TokenType != "Bearer" || tokens.ExpiresIn != 60 || strings.Count(tokens.AccessToken, ".") != 2 {
+		t.Errorf("Unexpected tokens %+v", tokens)
+	}
+
+	w := postJSON(r, "/api/auth/login", loginRequest{Username: "admin", Password: "wrong"})
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status code %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+	var apiErr mockapi.APIError
+	json.Unmarshal(w.Body.Bytes(), &apiErr)
+	if apiErr.Message != "unauthorized: invalid username or password" {
+		t.Errorf("Unexpected error %+v", apiErr)
+	}
+	if challenge := w.Header().Get("WWW-Authenticate"); challenge != "" {
+		t.Errorf("Expected no WWW-Authenticate for a failed login, got %q", challenge)
+	}
+}
+
+func TestAuth_ProtectedRoute(t *testing.T) {
+	r, _, _ := setupAuthRouter(t, AuthConfig{
+		Routes: map[string][]string{"DELETE /api/books/:id": {"admin"}},
+	})
+
+	// Unprotected routes stay public
+	req, _ := http.NewRequest("GET", "/api/books/1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	w = deleteBook(r, "")
+	if w.Code != http.StatusUnauthorized || w.Header().Get("WWW-Authenticate") != "Bearer" {
+		t.Errorf("Expected 401 with a plain Bearer challenge, got %d %v", w.Code, w.Header())
+	}
+
+	w = deleteBook(r, login(t, r, "reader", "reader123").AccessToken)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status code %d, got %d", http.StatusForbidden, w.Code)
+	}
+
+	w = deleteBook(r, login(t, r, "admin", "admin123").AccessToken)
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected status code %d, got %d", http.StatusNoContent, w.Code)
+	}
+}
+
+func TestAuth_InvalidAndExpiredToken(t *testing.T) {
+	r, _, now := setupAuthRouter(t, AuthConfig{
+		AccessTokenTTLSeconds: 30,
+		Routes:                map[string][]string{"DELETE /api/books/:id": nil},
+	})
+	tokens := login(t, r, "reader", "reader123")
+
+	// Changing the payload invalidates the signature
+	parts := strings.Split(tokens.AccessToken, ".")
+	payload, _ := json.Marshal(AuthClaims{Subject: "reader", Roles: []string{"admin"}, Type: accessTokenType, ExpiresAt: now.Add(time.Hour).Unix()})
+	forged := parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload) + "." + parts[2]
+	w := deleteBook(r, forged)
+	if w.Code != http.StatusUnauthorized || w.Header().Get("WWW-Authenticate") != `Bearer error="invalid_token"` {
+		t.Errorf("Expected 401 with an invalid_token challenge for a forged token, got %d %v", w.Code, w.Header())
+	}
+
+	// Refresh tokens are not accepted as access tokens
+	w = deleteBook(r, tokens.RefreshToken)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status code %d for a refresh token, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	*now = now.Add(30 * time.Second)
+	w = deleteBook(r, tokens.AccessToken)
+	var apiErr mockapi.APIError
+	json.Unmarshal(w.Body.Bytes(), &apiErr)
+	if w.Code != http.StatusUnauthorized || apiErr.Message != "unauthorized: token has expired" {
+		t.Errorf("Expected expired token error, got %d %+v", w.Code, apiErr)
+	}
+	if challenge := w.Header().Get("WWW-Authenticate"); challenge != `Bearer error="invalid_token"` {
+		t.Errorf("Expected an invalid_token challenge for an expired token, got %q", challenge)
+	}
+}
+
+func TestAuth_RefreshRotation(t *testing.T) {
+	r, _, now := setupAuthRouter(t, AuthConfig{AccessTokenTTLSeconds: 30, RefreshTokenTTLSeconds: 60})
+	tokens := login(t, r, "admin", "admin123")
+
+	*now = now.Add(45 * time.Second)
+	w := postJSON(r, "/api/auth/refresh", refreshRequest{RefreshToken: tokens.RefreshToken})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	var refreshed AuthTokens
+	json.Unmarshal(w.Body.Bytes(), &refreshed)
+	if refreshed.RefreshToken == tokens.RefreshToken || refreshed.AccessToken == tokens.AccessToken {
+		t.Error("Expected a new token pair")
+	}
+
+	w = postJSON(r, "/api/auth/refresh", refreshRequest{RefreshToken: tokens.RefreshToken})
+	var apiErr mockapi.APIError
+	json.Unmarshal(w.Body.Bytes(), &apiErr)
+	if w.Code != http.StatusUnauthorized || apiErr.Message != "unauthorized: refresh token has been revoked" {
+		t.Errorf("Expected reused refresh token to be rejected, got %d %+v", w.Code, apiErr)
+	}
+
+	*now = now.Add(60 * time.Second)
+	w = postJSON(r, "/api/auth/refresh", refreshRequest{RefreshToken: refreshed.RefreshToken})
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected expired refresh token to be rejected, got %d", w.Code)
+	}
+}
+
+func TestAuth_Me(t *testing.T) {
+	r, _, _ := setupAuthRouter(t, AuthConfig{
+		Users: []AuthUser{{Username: "ana", Password: "pw", Roles: []string{"editor"}}},
+	})
+	tokens := login(t, r, "ana", "pw")
+
+	req, _ := http.NewRequest("GET", "/api/auth/me", nil)
+	req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	var claims AuthClaims
+	json.Unmarshal(w.Body.Bytes(), &claims)
+	if claims.Subject != "ana" || len(claims.Roles) != 1 || claims.Roles[0] != "editor" {
+		t.Errorf("Unexpected claims %+v", claims)
+	}
+}
+
+func TestAuthClaimsFromContext(t *testing.T) {
+	r, _, _ := setupAuthRouter(t, AuthConfig{
+		Routes: map[string][]string{"GET /whoami": nil},
+	})
+	r.GET("/whoami", func(c *gin.Context) {
+		claims, _ := AuthClaimsFromContext(c)
+		c.String(200, claims.Subject)
+	})
+
+	req, _ := http.NewRequest("GET", "/whoami", nil)
+	req.Header.Set("Authorization", "Bearer "+login(t, r, "reader", "reader123").AccessToken)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Body.String() != "reader" {
+		t.Errorf("Expected subject reader, got %s", w.Body.String())
+	}
+}
@@ -0,0 +1,293 @@
+package ginrouter
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anggaaryas/go-mockapi"
+	"github.com/gin-gonic/gin"
+)
+
+// ChaosRule describes how requests to a route misbehave. Rates are fractions
+// between 0 and 1 and are rolled independently for every request.
+type ChaosRule struct {
+	LatencyMS    int     `json:"latency_ms"`
+	JitterMS     int     `json:"jitter_ms"`
+	ErrorRate    float64 `json:"error_rate"`
+	ErrorCodes   []int   `json:"error_codes,omitempty"`
+	DropRate     float64 `json:"drop_rate"`
+	TruncateRate float64 `json:"truncate_rate"`
+}
+
+// ChaosConfig holds the chaos rules. Routes are keyed by method and Gin route
+// path, e.g. "GET /api/books/:id"; Default applies to every other route.
+type ChaosConfig struct {
+	Enabled bool                 `json:"enabled"`
+	Default *ChaosRule           `json:"default,omitempty"`
+	Routes  map[string]ChaosRule `json:"routes,omitempty"`
+}
+
+// Chaos injects latency, errors, dropped connections and truncated bodies
+// into the routes it is installed on. It is safe to reconfigure at runtime.
+type Chaos struct {
+	mu     sync.RWMutex
+	config ChaosConfig
+	random func() float64
+	sleep  func(c *gin.Context, d time.Duration)
+}
+
+var defaultChaosErrorCodes = []int{500}
+
+func NewChaos(config ChaosConfig) (*Chaos, error) {
+	chaos := &Chaos{
+		random: rand.Float64,
+		sleep:  sleepContext,
+	}
+	if err := chaos.SetConfig(config); err != nil {
+		return nil, err
+	}
+	return chaos, nil
+}
+
+// isAdminPath reports whether path belongs to an admin route, which chaos
+// leaves alone so a misbehaving mock can always be reconfigured.
+func isAdminPath(path string) bool {
+	for _, admin := range []string{"/admin", "/chaos", "/scenarios"} {
+		if strings.HasPrefix(path, mockapi.GetMockapiPath()+admin) {
+			return true
+		}
+	}
+	return false
+}
+
+func sleepContext(c *gin.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-c.Request.Context().Done():
+	}
+}
+
+func validateChaosRule(route string, rule ChaosRule) error {
+	if rule.LatencyMS < 0 || rule.JitterMS < 0 {
+		return NewInvalidChaosConfigError(fmt.Sprintf("%s: latency should not be negative", route))
+	}
+	for _, rate := range []float64{rule.ErrorRate, rule.DropRate, rule.TruncateRate} {
+		if rate < 0 || rate > 1 {
+			return NewInvalidChaosConfigError(fmt.Sprintf("%s: rates should be between 0 and 1", route))
+		}
+	}
+	for _, code := range rule.ErrorCodes {
+		if code < 400 || code > 599 {
+			return NewInvalidChaosConfigError(fmt.Sprintf("%s: error code %d is not a 4xx or 5xx status", route, code))
+		}
+	}
+	return nil
+}
+
+// SetConfig replaces the whole chaos configuration.
+func (ch *Chaos) SetConfig(config ChaosConfig) error {
+	if config.Default != nil {
+		if err := validateChaosRule("default", *config.Default); err != nil {
+			return err
+		}
+	}
+	routes := make(map[string]ChaosRule, len(config.Routes))
+	for route, rule := range config.Routes {
+		if err := validateChaosRule(route, rule); err != nil {
+			return err
+		}
+		routes[route] = rule
+	}
+	config.Routes = routes
+
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	ch.config = config
+	return nil
+}
+
+// Config returns a copy of the current chaos configuration.
+func (ch *Chaos) Config() ChaosConfig {
+	ch.mu.RLock()
+	defer ch.mu.RUnlock()
+
+	config := ch.config
+	config.Routes = make(map[string]ChaosRule, len(ch.config.Routes))
+	for route, rule := range ch.config.Routes {
+		config.Routes[route] = rule
+	}
+	return config
+}
+
+// SetEnabled turns chaos on or off without touching the rules.
+func (ch *Chaos) SetEnabled(enabled bool) {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	ch.config.Enabled = enabled
+}
+
+// SetRule sets the rule for a single route such as "GET /api/books".
+func (ch *Chaos) SetRule(route string, rule ChaosRule) error {
+	if err := validateChaosRule(route, rule); err != nil {
+		return err
+	}
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	ch.config.Routes[route] = rule
+	return nil
+}
+
+// RemoveRule removes the rule of a single route, falling back to the default rule.
+func (ch *Chaos) RemoveRule(route string) {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	delete(ch.config.Routes, route)
+}
+
+func (ch *Chaos) ruleFor(route string) (ChaosRule, bool) {
+	ch.mu.RLock()
+	defer ch.mu.RUnlock()
+
+	if !ch.config.Enabled {
+		return ChaosRule{}, false
+	}
+	if rule, ok := ch.config.Routes[route]; ok {
+		return rule, true
+	}
+	if ch.config.Default != nil {
+		return *ch.config.Default, true
+	}
+	return ChaosRule{}, false
+}
+
+func (ch *Chaos) roll(rate float64) bool {
+	return rate > 0 && ch.random() < rate
+}
+
+// Middleware returns the Gin middleware that applies the chaos rules. Install
+// it with r.Use before calling SetupMockApiRoute so it wraps the mock routes.
+func (ch *Chaos) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if isAdminPath(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+		rule, ok := ch.ruleFor(c.Request.Method + " " + c.FullPath())
+		if !ok {
+			c.Next()
+			return
+		}
+
+		delay := time.Duration(rule.LatencyMS) * time.Millisecond
+		if rule.JitterMS > 0 {
+			delay += time.Duration(ch.random() * float64(time.Duration(rule.JitterMS)*time.Millisecond))
+		}
+		if delay > 0 {
+			ch.sleep(c, delay)
+		}
+
+		if ch.roll(rule.DropRate) {
+			dropConnection(c)
+			return
+		}
+
+		if ch.roll(rule.ErrorRate) {
+			codes := rule.ErrorCodes
+			if len(codes) == 0 {
+				codes = defaultChaosErrorCodes
+			}
+			code := codes[int(ch.random()*float64(len(codes)))%len(codes)]
+			if code == http.StatusTooManyRequests {
+				c.Header("Retry-After", "1")
+			}
+			c.AbortWithStatusJSON(code, mockapi.APIError{
+				StatusCode: code,
+				Message:    fmt.Sprintf("chaos: injected %d response", code),
+			})
+			return
+		}
+
+		if ch.roll(rule.TruncateRate) {
+			w := &bufferedWriter{ResponseWriter: c.Writer}
+			c.Writer = w
+			c.Next()
+			c.Writer = w.ResponseWriter
+			body := w.body.Bytes()
+			// Declaring the full length and sending half makes the server
+			// close the connection, so clients see an unexpected EOF.
+			c.Writer.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			c.Writer.Write(body[:len(body)/2])
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// dropConnection closes the client connection without sending a response.
+// Connections that cannot be hijacked, such as HTTP/2 streams, are aborted
+// with http.ErrAbortHandler instead.
+func dropConnection(c *gin.Context) {
+	c.Abort()
+	defer func() {
+		if recover() != nil {
+			panic(http.ErrAbortHandler)
+		}
+	}()
+	conn, _, err := c.Writer.Hijack()
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+	conn.Close()
+}
+
+// bufferedWriter holds back the response body so it can be truncated.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func writeCustomError(c *gin.Context, err CustomError) {
+	c.JSON(err.StatusCode(), mockapi.APIError{
+		StatusCode: err.StatusCode(),
+		Message:    err.Error(),
+	})
+}
+
+// SetupAdminRoute registers GET and PUT /mockapi/chaos so the chaos
+// configuration can be inspected and changed while the server is running.
+func (ch *Chaos) SetupAdminRoute(r gin.IRouter) {
+	path := mockapi.GetMockapiPath() + "/chaos"
+
+	r.GET(path, func(c *gin.Context) {
+		c.JSON(200, ch.Config())
+	})
+	r.PUT(path, func(c *gin.Context) {
+		var config ChaosConfig
+		if err := c.ShouldBindJSON(&config); err != nil {
+			writeCustomError(c, NewInvalidBodyError(err.Error()))
+			return
+		}
+		if err := ch.SetConfig(config); err != nil {
+			writeAdminError(c, err)
+			return
+		}
+		c.JSON(200, ch.Config())
+	})
+}
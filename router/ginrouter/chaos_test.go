@@ -0,0 +1,229 @@
+package ginrouter
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anggaaryas/go-mockapi"
+	"github.com/gin-gonic/gin"
+)
+
+func setupChaosRouter(t *testing.T, config ChaosConfig, random float64) (*gin.Engine, *Chaos, *[]time.Duration) {
+	chaos, err := NewChaos(config)
+	if err != nil {
+		t.Fatalf("NewChaos failed: %v", err)
+	}
+	var slept []time.Duration
+	chaos.random = func() float64 { return random }
+	chaos.sleep = func(c *gin.Context, d time.Duration) { slept = append(slept, d) }
+
+	r := setupTestRouter()
+	r.Use(chaos.Middleware())
+	chaos.SetupAdminRoute(r)
+	Create(r).SetupMockApiRoute(&mockService{
+		getBookByIDFunc: func(id string) (mockapi.Book, error) {
+			return mockapi.Book{ID: 1, Title: "Test Book", Desc: "A description long enough to be cut in half"}, nil
+		},
+	})
+	return r, chaos, &slept
+}
+
+func TestNewChaos_InvalidConfig(t *testing.T) {
+	for _, rule := range []ChaosRule{
+		{ErrorRate: 1.5},
+		{DropRate: -0.1},
+		{LatencyMS: -1},
+		{ErrorCodes: []int{200}},
+	} {
+		if _, err := NewChaos(ChaosConfig{Default: &rule}); err == nil {
+			t.Errorf("Expected error for rule %+v", rule)
+		}
+	}
+}
+
+func TestChaos_Disabled(t *testing.T) {
+	r, _, slept := setupChaosRouter(t, ChaosConfig{Enabled: false, Default: &ChaosRule{LatencyMS: 100, ErrorRate: 1}}, 0)
+
+	req, _ := http.NewRequest("GET", "/api/books/1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if len(*slept) != 0 {
+		t.Errorf("Expected no latency, got %v", *slept)
+	}
+}
+
+func TestChaos_Latency(t *testing.T) {
+	r, _, slept := setupChaosRouter(t, ChaosConfig{Enabled: true, Default: &ChaosRule{LatencyMS: 100, JitterMS: 50}}, 0.5)
+
+	req, _ := http.NewRequest("GET", "/api/books/1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if len(*slept) != 1 || (*slept)[0] != 125*time.Millisecond {
+		t.Errorf("Expected 125ms latency, got %v", *slept)
+	}
+}
+
+func TestChaos_ErrorInjection(t *testing.T) {
+	config := ChaosConfig{
+		Enabled: true,
+		Routes: map[string]ChaosRule{
+			"GET /api/books/:id": {ErrorRate: 0.5, ErrorCodes: []int{500, 429, 503}},
+		},
+	}
+	r, _, _ := setupChaosRouter(t, config, 0.4)
+
+	req, _ := http.NewRequest("GET", "/api/books/1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status code %d, got %d", http.StatusTooManyRequests, w.Code)
+	}
+	if w.Header().Get("Retry-After") != "1" {
+		t.Errorf("Expected Retry-After header, got %q", w.Header().Get("Retry-After"))
+	}
+
+	var apiErr mockapi.APIError
+	if err := json.Unmarshal(w.Body.Bytes(), &apiErr); err != nil {
+		t.Fatalf("Failed to unmarshal error response: %v", err)
+	}
+	if apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected status code in error %d, got %d", http.StatusTooManyRequests, apiErr.StatusCode)
+	}
+
+	// Routes without a rule are left alone
+	req, _ = http.NewRequest("GET", "/api/books", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestChaos_RateNotHit(t *testing.T) {
+	r, _, _ := setupChaosRouter(t, ChaosConfig{Enabled: true, Default: &ChaosRule{ErrorRate: 0.3}}, 0.5)
+
+	req, _ := http.NewRequest("GET", "/api/books/1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestChaos_DropConnection(t *testing.T) {
+	r, _, _ := setupChaosRouter(t, ChaosConfig{Enabled: true, Default: &ChaosRule{DropRate: 1}}, 0)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/books/1")
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("Expected dropped connection to fail the request")
+	}
+}
+
+func TestChaos_TruncateBody(t *testing.T) {
+	r, _, _ := setupChaosRouter(t, ChaosConfig{Enabled: true, Default: &ChaosRule{TruncateRate: 1}}, 0)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/books/1")
+	if err != nil {
+		t.Fatalf("Expected response headers, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected unexpected EOF, got %v", err)
+	}
+	if int64(len(body)) >= resp.ContentLength {
+		t.Errorf("Expected body shorter than %d bytes, got %d", resp.ContentLength, len(body))
+	}
+}
+
+func TestChaos_ToggleAtRuntime(t *testing.T) {
+	r, chaos, _ := setupChaosRouter(t, ChaosConfig{Enabled: true}, 0)
+
+	if err := chaos.SetRule("GET /api/books/:id", ChaosRule{ErrorRate: 1, ErrorCodes: []int{503}}); err != nil {
+		t.Fatalf("SetRule failed: %v", err)
+	}
+
+	req, _ := http.NewRequest("GET", "/api/books/1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status code %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+
+	chaos.SetEnabled(false)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d after disabling, got %d", http.StatusOK, w.Code)
+	}
+
+	chaos.SetEnabled(true)
+	chaos.RemoveRule("GET /api/books/:id")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d after removing the rule, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestChaos_AdminRoute(t *testing.T) {
+	r, chaos, _ := setupChaosRouter(t, ChaosConfig{}, 0)
+
+	body := []byte(`{"enabled":true,"default":{"error_rate":1,"error_codes":[500]}}`)
+	req, _ := http.NewRequest("PUT", "/mockapi/chaos", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if !chaos.Config().Enabled {
+		t.Error("Expected chaos to be enabled")
+	}
+
+	// The admin route itself is never affected by chaos
+	req, _ = http.NewRequest("GET", "/mockapi/chaos", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	req, _ = http.NewRequest("GET", "/api/books/1", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status code %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+
+	req, _ = http.NewRequest("PUT", "/mockapi/chaos", bytes.NewReader([]byte(`{"default":{"drop_rate":2}}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -2,6 +2,7 @@ package ginrouter
 
 import (
 	"fmt"
+	"strings"
 )
 
 // BadRequestError represents a bad request error with a message.
@@ -9,6 +10,22 @@ type BadRequestError struct {
 	Message string `json:"message"`
 }
 
+// UnauthorizedError represents a request without valid credentials.
+type UnauthorizedError struct {
+	Message string `json:"message"`
+}
+
+// ForbiddenError represents an authenticated request that is not allowed.
+type ForbiddenError struct {
+	Message string `json:"message"`
+}
+
+// NotAcceptableError represents a request for a response format that is not
+// supported.
+type NotAcceptableError struct {
+	Message string `json:"message"`
+}
+
 type CustomError interface {
 	StatusCode() int
 	Error() string
@@ -21,10 +38,160 @@ func NewIDShouldBeIntError(param string) *BadRequestError {
 	}
 }
 
+// NewInvalidBodyError creates a new BadRequestError for a malformed request body.
+func NewInvalidBodyError(reason string) *BadRequestError {
+	return &BadRequestError{
+		Message: fmt.Sprintf("bad request: invalid request body: %s", reason),
+	}
+}
+
+// NewUnknownSortFieldError creates a new BadRequestError for an unsupported sort field.
+func NewUnknownSortFieldError(field string) *BadRequestError {
+	return &BadRequestError{
+		Message: fmt.Sprintf("bad request: unknown sort field %s", field),
+	}
+}
+
+// NewInvalidOrderError creates a new BadRequestError for an order other than asc or desc.
+func NewInvalidOrderError(order string) *BadRequestError {
+	return &BadRequestError{
+		Message: fmt.Sprintf("bad request: order should be asc or desc, got %s", order),
+	}
+}
+
+// NewInvalidPaginationError creates a new BadRequestError for a pagination mode other than offset or cursor.
+func NewInvalidPaginationError(mode string) *BadRequestError {
+	return &BadRequestError{
+		Message: fmt.Sprintf("bad request: pagination should be offset or cursor, got %s", mode),
+	}
+}
+
+// NewInvalidChaosConfigError creates a new BadRequestError for an invalid chaos configuration.
+func NewInvalidChaosConfigError(reason string) *BadRequestError {
+	return &BadRequestError{
+		Message: fmt.Sprintf("bad request: invalid chaos config: %s", reason),
+	}
+}
+
+// NewInvalidScenarioError creates a new BadRequestError for an invalid scenario.
+func NewInvalidScenarioError(reason string) *BadRequestError {
+	return &BadRequestError{
+		Message: fmt.Sprintf("bad request: invalid scenario: %s", reason),
+	}
+}
+
+// NewInvalidAuthConfigError creates a new BadRequestError for an invalid auth configuration.
+func NewInvalidAuthConfigError(reason string) *BadRequestError {
+	return &BadRequestError{
+		Message: fmt.Sprintf("bad request: invalid auth config: %s", reason),
+	}
+}
+
+// NewInvalidSessionConfigError creates a new BadRequestError for a session configuration that cannot be used.
+func NewInvalidSessionConfigError(reason string) *BadRequestError {
+	return &BadRequestError{
+		Message: fmt.Sprintf("bad request: invalid session config: %s", reason),
+	}
+}
+
+// NewInvalidSessionIDError creates a new BadRequestError for a session ID with unsupported characters.
+func NewInvalidSessionIDError(id string) *BadRequestError {
+	return &BadRequestError{
+		Message: fmt.Sprintf("bad request: invalid session id %s, use up to 64 letters, digits, dots, dashes or underscores", id),
+	}
+}
+
+// NewMissingSessionIDError creates a new BadRequestError for a session path prefix without a session ID.
+func NewMissingSessionIDError(prefix string) *BadRequestError {
+	return &BadRequestError{
+		Message: fmt.Sprintf("bad request: missing session id, use %s/{id}/...", prefix),
+	}
+}
+
+// NewSessionNotSupportedError creates a new BadRequestError for a route that only serves the shared data, requested in a session.
+func NewSessionNotSupportedError(path string) *BadRequestError {
+	return &BadRequestError{
+		Message: fmt.Sprintf("bad request: %s serves the shared data and is not available in a session", path),
+	}
+}
+
+// NewInvalidCredentialsError creates a new UnauthorizedError for a wrong username or password.
+func NewInvalidCredentialsError() *UnauthorizedError {
+	return &UnauthorizedError{
+		Message: "unauthorized: invalid username or password",
+	}
+}
+
+// NewMissingTokenError creates a new UnauthorizedError for a request without a bearer token.
+func NewMissingTokenError() *UnauthorizedError {
+	return &UnauthorizedError{
+		Message: "unauthorized: missing bearer token",
+	}
+}
+
+// NewInvalidTokenError creates a new UnauthorizedError for a malformed or badly signed token.
+func NewInvalidTokenError() *UnauthorizedError {
+	return &UnauthorizedError{
+		Message: "unauthorized: invalid token",
+	}
+}
+
+// NewTokenExpiredError creates a new UnauthorizedError for an expired token.
+func NewTokenExpiredError() *UnauthorizedError {
+	return &UnauthorizedError{
+		Message: "unauthorized: token has expired",
+	}
+}
+
+// NewRefreshTokenRevokedError creates a new UnauthorizedError for a refresh token that was already used.
+func NewRefreshTokenRevokedError() *UnauthorizedError {
+	return &UnauthorizedError{
+		Message: "unauthorized: refresh token has been revoked",
+	}
+}
+
+// NewMissingRoleError creates a new ForbiddenError for a user without any of the required roles.
+func NewMissingRoleError(roles []string) *ForbiddenError {
+	return &ForbiddenError{
+		Message: fmt.Sprintf("forbidden: requires role %s", strings.Join(roles, " or ")),
+	}
+}
+
+// NewNotAcceptableError creates a new NotAcceptableError listing the supported formats.
+func NewNotAcceptableError(formats []string) *NotAcceptableError {
+	return &NotAcceptableError{
+		Message: fmt.Sprintf("not acceptable: supported formats are %s", strings.Join(formats, ", ")),
+	}
+}
+
 func (e *BadRequestError) StatusCode() int {
 	return 400
 }
 
 func (e *BadRequestError) Error() string {
 	return e.Message
-}
\ No newline at end of file
+}
+
+func (e *UnauthorizedError) StatusCode() int {
+	return 401
+}
+
+func (e *UnauthorizedError) Error() string {
+	return e.Message
+}
+
+func (e *ForbiddenError) StatusCode() int {
+	return 403
+}
+
+func (e *ForbiddenError) Error() string {
+	return e.Message
+}
+
+func (e *NotAcceptableError) StatusCode() int {
+	return 406
+}
+
+func (e *NotAcceptableError) Error() string {
+	return e.Message
+}
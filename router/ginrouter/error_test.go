@@ -24,6 +24,117 @@ func TestNewIDShouldBeIntError_DifferentParam(t *testing.T) {
 	}
 }
 
+func TestNewInvalidBodyError(t *testing.T) {
+	err := NewInvalidBodyError("unexpected EOF")
+
+	expected := "bad request: invalid request body: unexpected EOF"
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+}
+
+func TestNewUnknownSortFieldError(t *testing.T) {
+	err := NewUnknownSortFieldError("price")
+
+	expected := "bad request: unknown sort field price"
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+}
+
+func TestNewInvalidOrderError(t *testing.T) {
+	err := NewInvalidOrderError("up")
+
+	expected := "bad request: order should be asc or desc, got up"
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+}
+
+func TestNewInvalidPaginationError(t *testing.T) {
+	err := NewInvalidPaginationError("page")
+
+	expected := "bad request: pagination should be offset or cursor, got page"
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+}
+
+func TestNewInvalidChaosConfigError(t *testing.T) {
+	err := NewInvalidChaosConfigError("default: rates should be between 0 and 1")
+
+	expected := "bad request: invalid chaos config: default: rates should be between 0 and 1"
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+}
+
+func TestNewInvalidScenarioError(t *testing.T) {
+	err := NewInvalidScenarioError("scenarios need a name")
+
+	expected := "bad request: invalid scenario: scenarios need a name"
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+}
+
+func TestNewInvalidSessionIDError(t *testing.T) {
+	err := NewInvalidSessionIDError("a b")
+
+	expected := "bad request: invalid session id a b, use up to 64 letters, digits, dots, dashes or underscores"
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+}
+
+func TestNewMissingSessionIDError(t *testing.T) {
+	err := NewMissingSessionIDError("/sessions")
+
+	expected := "bad request: missing session id, use /sessions/{id}/..."
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+}
+
+func TestNewSessionNotSupportedError(t *testing.T) {
+	err := NewSessionNotSupportedError("/graphql")
+
+	expected := "bad request: /graphql serves the shared data and is not available in a session"
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+}
+
+func TestNewMissingRoleError(t *testing.T) {
+	err := NewMissingRoleError([]string{"admin", "editor"})
+
+	expected := "forbidden: requires role admin or editor"
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+}
+
+func TestNewNotAcceptableError(t *testing.T) {
+	err := NewNotAcceptableError([]string{"json", "xml"})
+
+	expected := "not acceptable: supported formats are json, xml"
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+	if code := err.StatusCode(); code != 406 {
+		t.Errorf("Expected status code 406, got %d", code)
+	}
+}
+
+func TestAuthErrors_StatusCode(t *testing.T) {
+	if code := NewInvalidTokenError().StatusCode(); code != 401 {
+		t.Errorf("Expected status code 401, got %d", code)
+	}
+	if code := NewMissingRoleError([]string{"admin"}).StatusCode(); code != 403 {
+		t.Errorf("Expected status code 403, got %d", code)
+	}
+}
+
 func TestBadRequestError_StatusCode(t *testing.T) {
 	err := &BadRequestError{Message: "test error"}
 
@@ -53,3 +164,8 @@ func TestBadRequestError_ImplementsError(t *testing.T) {
 func TestBadRequestError_ImplementsCustomError(t *testing.T) {
 	var _ CustomError = &BadRequestError{}
 }
+
+func TestAuthErrors_ImplementCustomError(t *testing.T) {
+	var _ CustomError = &UnauthorizedError{}
+	var _ CustomError = &ForbiddenError{}
+}
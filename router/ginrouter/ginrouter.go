@@ -3,13 +3,17 @@ package ginrouter
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/anggaaryas/go-mockapi"
 	"github.com/gin-gonic/gin"
 )
 
 type config struct {
-	r *gin.Engine
+	r         *gin.Engine
+	resources []mockapi.ResourceService
+	style     mockapi.ResponseStyle
+	service   mockapi.Service
 }
 
 func Create(r *gin.Engine) mockapi.Router {
@@ -18,6 +22,20 @@ func Create(r *gin.Engine) mockapi.Router {
 	}
 }
 
+// CreateWithStyle returns a Router that renders the books of its JSON
+// responses in style, e.g. mockapi.ResponseStyleJSONAPI. Styles that
+// mockapi.ParseResponseStyle does not know are returned as errors.
+func CreateWithStyle(r *gin.Engine, style mockapi.ResponseStyle) (mockapi.Router, error) {
+	style, err := mockapi.ParseResponseStyle(string(style))
+	if err != nil {
+		return nil, err
+	}
+	return &config{
+		r:     r,
+		style: style,
+	}, nil
+}
+
 func (cfg *config) getErrorResponse(err error) mockapi.APIError {
 	statusCode := 500
 
@@ -41,13 +59,189 @@ func (cfg *config) getErrorResponse(err error) mockapi.APIError {
 	}
 }
 
+// splitQueryValues flattens repeated and comma-separated query values.
+func splitQueryValues(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
+
+func parseBookQuery(c *gin.Context) (mockapi.BookQuery, error) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	query := mockapi.BookQuery{
+		Page:     page,
+		PageSize: pageSize,
+		Search:   c.DefaultQuery("search", ""),
+		Sort:     c.DefaultQuery("sort", ""),
+		Order:    strings.ToLower(c.DefaultQuery("order", "")),
+		Category: splitQueryValues(c.QueryArray("category")),
+		Author:   splitQueryValues(c.QueryArray("author")),
+	}
+	if query.Sort != "" && !mockapi.IsBookSortField(query.Sort) {
+		return mockapi.BookQuery{}, NewUnknownSortFieldError(query.Sort)
+	}
+	if query.Order != "" && !mockapi.IsSortOrder(query.Order) {
+		return mockapi.BookQuery{}, NewInvalidOrderError(query.Order)
+	}
+	return query, nil
+}
+
+func parseResourceQuery(c *gin.Context) mockapi.ResourceQuery {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	return mockapi.ResourceQuery{
+		Page:     page,
+		PageSize: pageSize,
+		Search:   c.DefaultQuery("search", ""),
+		Sort:     c.DefaultQuery("sort", ""),
+		Order:    strings.ToLower(c.DefaultQuery("order", "")),
+	}
+}
+
 func (cfg *config) SetupMockApiRoute(service mockapi.Service) error {
+	cfg.service = service
 
-	cfg.r.StaticFS(mockapi.GetMockapiPath(), http.FS(mockapi.GetStaticFiles()))
+	cfg.r.StaticFS(mockapi.GetMockapiStaticPath(), http.FS(mockapi.GetStaticFS()))
+	cfg.setupImageRoute()
+	cfg.setupPlaceholderRoute(service)
+	cfg.r.GET(mockapi.GetMockapiPath()+"/openapi.json", func(c *gin.Context) {
+		c.JSON(200, OpenAPISpecWithStyle(cfg.style, cfg.resources...))
+	})
 
 	api := cfg.r.Group("/api")
 
-	api.GET("/books/:id", func(c *gin.Context) {
+	api.GET("/books/:id", cfg.negotiateFormat, func(c *gin.Context) {
+		id := c.Param("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			cfg.renderError(c, NewIDShouldBeIntError("id"))
+			return
+		}
+		book, err := serviceFor(c, service).GetBookByID(id)
+		if err != nil {
+			cfg.renderError(c, err)
+			return
+		}
+		cfg.render(c, 200, book)
+	})
+	api.GET("/books", cfg.negotiateFormat, func(c *gin.Context) {
+		query, err := parseBookQuery(c)
+		if err != nil {
+			cfg.renderError(c, err)
+			return
+		}
+		cursor := c.DefaultQuery("cursor", "")
+		pagination := c.DefaultQuery("pagination", "offset")
+		if cursor != "" {
+			pagination = "cursor"
+		}
+		switch pagination {
+		case "offset":
+			books, err := serviceFor(c, service).GetBooks(query)
+			if err != nil {
+				cfg.renderError(c, err)
+				return
+			}
+			cfg.render(c, 200, books)
+		case "cursor":
+			books, err := serviceFor(c, service).GetBooksByCursor(query, cursor)
+			if err != nil {
+				cfg.renderError(c, err)
+				return
+			}
+			cfg.render(c, 200, books)
+		default:
+			cfg.renderError(c, NewInvalidPaginationError(pagination))
+		}
+	})
+	api.POST("/books", cfg.negotiateFormat, func(c *gin.Context) {
+		var book mockapi.Book
+		if err := c.ShouldBindJSON(&book); err != nil {
+			cfg.renderError(c, NewInvalidBodyError(err.Error()))
+			return
+		}
+		created, err := serviceFor(c, service).CreateBook(book)
+		if err != nil {
+			cfg.renderError(c, err)
+			return
+		}
+		cfg.render(c, 201, created)
+	})
+	api.PUT("/books/:id", cfg.negotiateFormat, func(c *gin.Context) {
+		id := c.Param("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			cfg.renderError(c, NewIDShouldBeIntError("id"))
+			return
+		}
+		var book mockapi.Book
+		if err := c.ShouldBindJSON(&book); err != nil {
+			cfg.renderError(c, NewInvalidBodyError(err.Error()))
+			return
+		}
+		updated, err := serviceFor(c, service).UpdateBook(id, book)
+		if err != nil {
+			cfg.renderError(c, err)
+			return
+		}
+		cfg.render(c, 200, updated)
+	})
+	api.PATCH("/books/:id", cfg.negotiateFormat, func(c *gin.Context) {
+		id := c.Param("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			cfg.renderError(c, NewIDShouldBeIntError("id"))
+			return
+		}
+		var patch mockapi.BookPatch
+		if err := c.ShouldBindJSON(&patch); err != nil {
+			cfg.renderError(c, NewInvalidBodyError(err.Error()))
+			return
+		}
+		patched, err := serviceFor(c, service).PatchBook(id, patch)
+		if err != nil {
+			cfg.renderError(c, err)
+			return
+		}
+		cfg.render(c, 200, patched)
+	})
+	api.DELETE("/books/:id", cfg.negotiateFormat, func(c *gin.Context) {
+		id := c.Param("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			cfg.renderError(c, NewIDShouldBeIntError("id"))
+			return
+		}
+		if err := serviceFor(c, service).DeleteBook(id); err != nil {
+			cfg.renderError(c, err)
+			return
+		}
+		c.Status(204)
+	})
+	api.GET("/books/:id/reviews", func(c *gin.Context) {
+		id := c.Param("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			apiErr := cfg.getErrorResponse(NewIDShouldBeIntError("id"))
+			c.JSON(apiErr.StatusCode, apiErr)
+			return
+		}
+		reviews, err := serviceFor(c, service).GetBookReviews(id)
+		if err != nil {
+			apiErr := cfg.getErrorResponse(err)
+			c.JSON(apiErr.StatusCode, apiErr)
+			return
+		}
+		c.JSON(200, reviews)
+	})
+	api.POST("/books/:id/reviews", func(c *gin.Context) {
 		id := c.Param("id")
 		_, err := strconv.Atoi(id)
 		if err != nil {
@@ -55,25 +249,140 @@ func (cfg *config) SetupMockApiRoute(service mockapi.Service) error {
 			c.JSON(apiErr.StatusCode, apiErr)
 			return
 		}
-		book, err := service.GetBookByID(id)
+		var review mockapi.Review
+		if err := c.ShouldBindJSON(&review); err != nil {
+			apiErr := cfg.getErrorResponse(NewInvalidBodyError(err.Error()))
+			c.JSON(apiErr.StatusCode, apiErr)
+			return
+		}
+		created, err := serviceFor(c, service).CreateReview(id, review)
+		if err != nil {
+			apiErr := cfg.getErrorResponse(err)
+			c.JSON(apiErr.StatusCode, apiErr)
+			return
+		}
+		c.JSON(201, created)
+	})
+
+	api.GET("/authors", func(c *gin.Context) {
+		authors, err := serviceFor(c, service).GetAuthors()
+		if err != nil {
+			apiErr := cfg.getErrorResponse(err)
+			c.JSON(apiErr.StatusCode, apiErr)
+			return
+		}
+		c.JSON(200, authors)
+	})
+	api.GET("/authors/:id", func(c *gin.Context) {
+		id := c.Param("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			apiErr := cfg.getErrorResponse(NewIDShouldBeIntError("id"))
+			c.JSON(apiErr.StatusCode, apiErr)
+			return
+		}
+		author, err := serviceFor(c, service).GetAuthorByID(id)
+		if err != nil {
+			apiErr := cfg.getErrorResponse(err)
+			c.JSON(apiErr.StatusCode, apiErr)
+			return
+		}
+		c.JSON(200, author)
+	})
+	api.GET("/authors/:id/books", func(c *gin.Context) {
+		id := c.Param("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			cfg.renderError(c, NewIDShouldBeIntError("id"))
+			return
+		}
+		query, err := parseBookQuery(c)
+		if err != nil {
+			cfg.renderError(c, err)
+			return
+		}
+		books, err := serviceFor(c, service).GetAuthorBooks(id, query)
+		if err != nil {
+			cfg.renderError(c, err)
+			return
+		}
+		cfg.render(c, 200, books)
+	})
+
+	api.GET("/categories", func(c *gin.Context) {
+		categories, err := serviceFor(c, service).GetCategories()
+		if err != nil {
+			apiErr := cfg.getErrorResponse(err)
+			c.JSON(apiErr.StatusCode, apiErr)
+			return
+		}
+		c.JSON(200, categories)
+	})
+	api.GET("/categories/:id", func(c *gin.Context) {
+		id := c.Param("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			apiErr := cfg.getErrorResponse(NewIDShouldBeIntError("id"))
+			c.JSON(apiErr.StatusCode, apiErr)
+			return
+		}
+		category, err := serviceFor(c, service).GetCategoryByID(id)
+		if err != nil {
+			apiErr := cfg.getErrorResponse(err)
+			c.JSON(apiErr.StatusCode, apiErr)
+			return
+		}
+		c.JSON(200, category)
+	})
+	api.GET("/categories/:id/books", func(c *gin.Context) {
+		id := c.Param("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			cfg.renderError(c, NewIDShouldBeIntError("id"))
+			return
+		}
+		query, err := parseBookQuery(c)
+		if err != nil {
+			cfg.renderError(c, err)
+			return
+		}
+		books, err := serviceFor(c, service).GetCategoryBooks(id, query)
+		if err != nil {
+			cfg.renderError(c, err)
+			return
+		}
+		cfg.render(c, 200, books)
+	})
+
+	return nil
+}
+
+// SetupResourceRoute serves a generic resource under /api/{name}. The resource
+// also appears in the OpenAPI document. Resources serve the shared data, so
+// requests in a session are rejected.
+func (cfg *config) SetupResourceRoute(resource mockapi.ResourceService) error {
+	cfg.resources = append(cfg.resources, resource)
+
+	api := cfg.r.Group("/api")
+	name := resource.Schema().Name
+
+	api.GET("/"+name, SharedOnly, func(c *gin.Context) {
+		items, err := resource.GetResources(parseResourceQuery(c))
 		if err != nil {
 			apiErr := cfg.getErrorResponse(err)
 			c.JSON(apiErr.StatusCode, apiErr)
 			return
 		}
-		c.JSON(200, book)
+		c.JSON(200, items)
 	})
-	api.GET("/books", func(c *gin.Context) {
-		page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-		pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
-		search := c.DefaultQuery("search", "")
-		books, err := service.GetBooks(page, pageSize, search)
+	api.GET("/"+name+"/:id", SharedOnly, func(c *gin.Context) {
+		item, err := resource.GetResource(c.Param("id"))
 		if err != nil {
 			apiErr := cfg.getErrorResponse(err)
 			c.JSON(apiErr.StatusCode, apiErr)
 			return
 		}
-		c.JSON(200, books)
+		c.JSON(200, item)
 	})
 
 	return nil
@@ -1,6 +1,7 @@
 package ginrouter
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -12,8 +13,21 @@ import (
 )
 
 type mockService struct {
-	getBookByIDFunc func(id string) (mockapi.Book, error)
-	getBooksFunc    func(page int, pageSize int, search string) (mockapi.PaginatedBooks, error)
+	getBookByIDFunc      func(id string) (mockapi.Book, error)
+	getBooksFunc         func(query mockapi.BookQuery) (mockapi.PaginatedBooks, error)
+	getBooksByCursorFunc func(query mockapi.BookQuery, cursor string) (mockapi.CursorBooks, error)
+	createBookFunc       func(book mockapi.Book) (mockapi.Book, error)
+	updateBookFunc       func(id string, book mockapi.Book) (mockapi.Book, error)
+	patchBookFunc        func(id string, patch mockapi.BookPatch) (mockapi.Book, error)
+	deleteBookFunc       func(id string) error
+	getAuthorsFunc       func() ([]mockapi.Author, error)
+	getAuthorByIDFunc    func(id string) (mockapi.Author, error)
+	getAuthorBooksFunc   func(id string, query mockapi.BookQuery) (mockapi.PaginatedBooks, error)
+	getCategoriesFunc    func() ([]mockapi.Category, error)
+	getCategoryByIDFunc  func(id string) (mockapi.Category, error)
+	getCategoryBooksFunc func(id string, query mockapi.BookQuery) (mockapi.PaginatedBooks, error)
+	getBookReviewsFunc   func(bookID string) ([]mockapi.Review, error)
+	createReviewFunc     func(bookID string, review mockapi.Review) (mockapi.Review, error)
 }
 
 func (m *mockService) GetBookByID(id string) (mockapi.Book, error) {
@@ -23,13 +37,104 @@ func (m *mockService) GetBookByID(id string) (mockapi.Book, error) {
 	return mockapi.Book{}, nil
 }
 
-func (m *mockService) GetBooks(page int, pageSize int, search string) (mockapi.PaginatedBooks, error) {
+func (m *mockService) GetBooks(query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
 	if m.getBooksFunc != nil {
-		return m.getBooksFunc(page, pageSize, search)
+		return m.getBooksFunc(query)
 	}
 	return mockapi.PaginatedBooks{}, nil
 }
 
+func (m *mockService) GetBooksByCursor(query mockapi.BookQuery, cursor string) (mockapi.CursorBooks, error) {
+	if m.getBooksByCursorFunc != nil {
+		return m.getBooksByCursorFunc(query, cursor)
+	}
+	return mockapi.CursorBooks{}, nil
+}
+
+func (m *mockService) CreateBook(book mockapi.Book) (mockapi.Book, error) {
+	if m.createBookFunc != nil {
+		return m.createBookFunc(book)
+	}
+	return book, nil
+}
+
+func (m *mockService) UpdateBook(id string, book mockapi.Book) (mockapi.Book, error) {
+	if m.updateBookFunc != nil {
+		return m.updateBookFunc(id, book)
+	}
+	return book, nil
+}
+
+func (m *mockService) PatchBook(id string, patch mockapi.BookPatch) (mockapi.Book, error) {
+	if m.patchBookFunc != nil {
+		return m.patchBookFunc(id, patch)
+	}
+	return mockapi.Book{}, nil
+}
+
+func (m *mockService) DeleteBook(id string) error {
+	if m.deleteBookFunc != nil {
+		return m.deleteBookFunc(id)
+	}
+	return nil
+}
+
+func (m *mockService) GetAuthors() ([]mockapi.Author, error) {
+	if m.getAuthorsFunc != nil {
+		return m.getAuthorsFunc()
+	}
+	return []mockapi.Author{}, nil
+}
+
+func (m *mockService) GetAuthorByID(id string) (mockapi.Author, error) {
+	if m.getAuthorByIDFunc != nil {
+		return m.getAuthorByIDFunc(id)
+	}
+	return mockapi.Author{}, nil
+}
+
+func (m *mockService) GetAuthorBooks(id string, query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+	if m.getAuthorBooksFunc != nil {
+		return m.getAuthorBooksFunc(id, query)
+	}
+	return mockapi.PaginatedBooks{}, nil
+}
+
+func (m *mockService) GetCategories() ([]mockapi.Category, error) {
+	if m.getCategoriesFunc != nil {
+		return m.getCategoriesFunc()
+	}
+	return []mockapi.Category{}, nil
+}
+
+func (m *mockService) GetCategoryByID(id string) (mockapi.Category, error) {
+	if m.getCategoryByIDFunc != nil {
+		return m.getCategoryByIDFunc(id)
+	}
+	return mockapi.Category{}, nil
+}
+
+func (m *mockService) GetCategoryBooks(id string, query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+	if m.getCategoryBooksFunc != nil {
+		return m.getCategoryBooksFunc(id, query)
+	}
+	return mockapi.PaginatedBooks{}, nil
+}
+
+func (m *mockService) GetBookReviews(bookID string) ([]mockapi.Review, error) {
+	if m.getBookReviewsFunc != nil {
+		return m.getBookReviewsFunc(bookID)
+	}
+	return []mockapi.Review{}, nil
+}
+
+func (m *mockService) CreateReview(bookID string, review mockapi.Review) (mockapi.Review, error) {
+	if m.createReviewFunc != nil {
+		return m.createReviewFunc(bookID, review)
+	}
+	return review, nil
+}
+
 func setupTestRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	return gin.New()
@@ -165,7 +270,7 @@ func TestGetBooks_Success(t *testing.T) {
 	}
 
 	service := &mockService{
-		getBooksFunc: func(page int, pageSize int, search string) (mockapi.PaginatedBooks, error) {
+		getBooksFunc: func(query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
 			return expectedBooks, nil
 		},
 	}
@@ -196,14 +301,14 @@ func TestGetBooks_WithPagination(t *testing.T) {
 	router := Create(r)
 
 	service := &mockService{
-		getBooksFunc: func(page int, pageSize int, search string) (mockapi.PaginatedBooks, error) {
-			if page != 2 || pageSize != 5 {
-				t.Errorf("Expected page 2 and pageSize 5, got page %d and pageSize %d", page, pageSize)
+		getBooksFunc: func(query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+			if query.Page != 2 || query.PageSize != 5 {
+				t.Errorf("Expected page 2 and pageSize 5, got page %d and pageSize %d", query.Page, query.PageSize)
 			}
 			return mockapi.PaginatedBooks{
 				Data:       []mockapi.Book{},
-				Page:       page,
-				PageSize:   pageSize,
+				Page:       query.Page,
+				PageSize:   query.PageSize,
 				TotalItems: 20,
 				TotalPages: 4,
 			}, nil
@@ -226,9 +331,9 @@ func TestGetBooks_WithSearch(t *testing.T) {
 	router := Create(r)
 
 	service := &mockService{
-		getBooksFunc: func(page int, pageSize int, search string) (mockapi.PaginatedBooks, error) {
-			if search != "Go" {
-				t.Errorf("Expected search term 'Go', got %s", search)
+		getBooksFunc: func(query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+			if query.Search != "Go" {
+				t.Errorf("Expected search term 'Go', got %s", query.Search)
 			}
 			return mockapi.PaginatedBooks{
 				Data:       []mockapi.Book{{ID: 1, Title: "Go Programming"}},
@@ -256,15 +361,18 @@ func TestGetBooks_DefaultParameters(t *testing.T) {
 	router := Create(r)
 
 	service := &mockService{
-		getBooksFunc: func(page int, pageSize int, search string) (mockapi.PaginatedBooks, error) {
-			if page != 1 {
-				t.Errorf("Expected default page 1, got %d", page)
+		getBooksFunc: func(query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+			if query.Page != 1 {
+				t.Errorf("Expected default page 1, got %d", query.Page)
+			}
+			if query.PageSize != 10 {
+				t.Errorf("Expected default pageSize 10, got %d", query.PageSize)
 			}
-			if pageSize != 10 {
-				t.Errorf("Expected default pageSize 10, got %d", pageSize)
+			if query.Search != "" {
+				t.Errorf("Expected empty search, got %s", query.Search)
 			}
-			if search != "" {
-				t.Errorf("Expected empty search, got %s", search)
+			if query.Sort != "" || query.Order != "" || len(query.Category) != 0 {
+				t.Errorf("Expected no sort or filters, got %+v", query)
 			}
 			return mockapi.PaginatedBooks{}, nil
 		},
@@ -281,12 +389,128 @@ func TestGetBooks_DefaultParameters(t *testing.T) {
 	}
 }
 
+func TestGetBooks_WithSortAndFilters(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	var gotQuery mockapi.BookQuery
+	service := &mockService{
+		getBooksFunc: func(query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+			gotQuery = query
+			return mockapi.PaginatedBooks{}, nil
+		},
+	}
+
+	router.SetupMockApiRoute(service)
+
+	req, _ := http.NewRequest("GET", "/api/books?sort=title&order=DESC&category=DevOps,Database&category=Programming&author=Martin%20Fowler", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if gotQuery.Sort != "title" || gotQuery.Order != mockapi.OrderDesc {
+		t.Errorf("Expected sort title desc, got %s %s", gotQuery.Sort, gotQuery.Order)
+	}
+	expectedCategories := []string{"DevOps", "Database", "Programming"}
+	if len(gotQuery.Category) != len(expectedCategories) {
+		t.Fatalf("Expected categories %v, got %v", expectedCategories, gotQuery.Category)
+	}
+	for i, category := range expectedCategories {
+		if gotQuery.Category[i] != category {
+			t.Errorf("Expected category %s, got %s", category, gotQuery.Category[i])
+		}
+	}
+	if len(gotQuery.Author) != 1 || gotQuery.Author[0] != "Martin Fowler" {
+		t.Errorf("Expected author filter [Martin Fowler], got %v", gotQuery.Author)
+	}
+}
+
+func TestGetBooks_CursorPagination(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	var gotCursor string
+	service := &mockService{
+		getBooksFunc: func(query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+			t.Error("Expected offset pagination not to be used")
+			return mockapi.PaginatedBooks{}, nil
+		},
+		getBooksByCursorFunc: func(query mockapi.BookQuery, cursor string) (mockapi.CursorBooks, error) {
+			gotCursor = cursor
+			return mockapi.CursorBooks{Data: []mockapi.Book{{ID: 1}}, PageSize: query.PageSize, NextCursor: "next"}, nil
+		},
+	}
+
+	router.SetupMockApiRoute(service)
+
+	for _, tc := range []struct {
+		url    string
+		cursor string
+	}{
+		{"/api/books?pagination=cursor&page_size=1", ""},
+		{"/api/books?cursor=abc&page_size=1", "abc"},
+	} {
+		req, _ := http.NewRequest("GET", tc.url, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+		}
+		if gotCursor != tc.cursor {
+			t.Errorf("Expected cursor %q, got %q", tc.cursor, gotCursor)
+		}
+
+		var books mockapi.CursorBooks
+		if err := json.Unmarshal(w.Body.Bytes(), &books); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if books.NextCursor != "next" || books.PageSize != 1 {
+			t.Errorf("Unexpected response %+v", books)
+		}
+	}
+}
+
+func TestGetBooks_InvalidPagination(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	router.SetupMockApiRoute(&mockService{})
+
+	req, _ := http.NewRequest("GET", "/api/books?pagination=page", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestGetBooks_UnknownSortField(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	router.SetupMockApiRoute(&mockService{})
+
+	for _, url := range []string{"/api/books?sort=price", "/api/books?sort=title&order=up"} {
+		req, _ := http.NewRequest("GET", url, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d for %s, got %d", http.StatusBadRequest, url, w.Code)
+		}
+	}
+}
+
 func TestGetBooks_Error(t *testing.T) {
 	r := setupTestRouter()
 	router := Create(r)
 
 	service := &mockService{
-		getBooksFunc: func(page int, pageSize int, search string) (mockapi.PaginatedBooks, error) {
+		getBooksFunc: func(query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
 			return mockapi.PaginatedBooks{}, errors.New("database error")
 		},
 	}
@@ -351,3 +575,554 @@ func TestGetErrorResponse_GenericError_ReleaseMode(t *testing.T) {
 		t.Errorf("Expected message %s, got %s", expectedMessage, apiErr.Message)
 	}
 }
+
+func TestCreateBook_Success(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	service := &mockService{
+		createBookFunc: func(book mockapi.Book) (mockapi.Book, error) {
+			book.ID = 51
+			return book, nil
+		},
+	}
+
+	router.SetupMockApiRoute(service)
+
+	body := []byte(`{"title":"New Book","author":"New Author"}`)
+	req, _ := http.NewRequest("POST", "/api/books", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected status code %d, got %d", http.StatusCreated, w.Code)
+	}
+
+	var book mockapi.Book
+	if err := json.Unmarshal(w.Body.Bytes(), &book); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if book.ID != 51 {
+		t.Errorf("Expected book ID 51, got %d", book.ID)
+	}
+}
+
+func TestCreateBook_InvalidBody(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	router.SetupMockApiRoute(&mockService{})
+
+	req, _ := http.NewRequest("POST", "/api/books", bytes.NewReader([]byte(`{"title":`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCreateBook_ValidationError(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	service := &mockService{
+		createBookFunc: func(book mockapi.Book) (mockapi.Book, error) {
+			return mockapi.Book{}, mockapi.NewRequiredFieldError("title")
+		},
+	}
+
+	router.SetupMockApiRoute(service)
+
+	req, _ := http.NewRequest("POST", "/api/books", bytes.NewReader([]byte(`{"author":"New Author"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	var apiErr mockapi.APIError
+	if err := json.Unmarshal(w.Body.Bytes(), &apiErr); err != nil {
+		t.Fatalf("Failed to unmarshal error response: %v", err)
+	}
+	if apiErr.Message != "validation error: title is required" {
+		t.Errorf("Unexpected error message %s", apiErr.Message)
+	}
+}
+
+func TestUpdateBook_Success(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	service := &mockService{
+		updateBookFunc: func(id string, book mockapi.Book) (mockapi.Book, error) {
+			if id != "3" {
+				t.Errorf("Expected id 3, got %s", id)
+			}
+			book.ID = 3
+			return book, nil
+		},
+	}
+
+	router.SetupMockApiRoute(service)
+
+	body := []byte(`{"title":"Updated","author":"Someone"}`)
+	req, _ := http.NewRequest("PUT", "/api/books/3", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestUpdateBook_InvalidID(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	router.SetupMockApiRoute(&mockService{})
+
+	req, _ := http.NewRequest("PUT", "/api/books/abc", bytes.NewReader([]byte(`{}`)))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestPatchBook_Success(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	service := &mockService{
+		patchBookFunc: func(id string, patch mockapi.BookPatch) (mockapi.Book, error) {
+			if patch.Title == nil || *patch.Title != "Patched" {
+				t.Error("Expected title to be set in patch")
+			}
+			if patch.Author != nil {
+				t.Error("Expected author to be absent from patch")
+			}
+			book := mockapi.Book{ID: 1, Author: "Author"}
+			patch.Apply(&book)
+			return book, nil
+		},
+	}
+
+	router.SetupMockApiRoute(service)
+
+	req, _ := http.NewRequest("PATCH", "/api/books/1", bytes.NewReader([]byte(`{"title":"Patched"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestDeleteBook_Success(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	router.SetupMockApiRoute(&mockService{})
+
+	req, _ := http.NewRequest("DELETE", "/api/books/1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected status code %d, got %d", http.StatusNoContent, w.Code)
+	}
+}
+
+func TestDeleteBook_NotFound(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	service := &mockService{
+		deleteBookFunc: func(id string) error {
+			return mockapi.NewBookNotFoundError(id)
+		},
+	}
+
+	router.SetupMockApiRoute(service)
+
+	req, _ := http.NewRequest("DELETE", "/api/books/999", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestGetAuthorBooks_Success(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	service := &mockService{
+		getAuthorBooksFunc: func(id string, query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+			if id != "3" {
+				t.Errorf("Expected author ID 3, got %s", id)
+			}
+			if query.Page != 2 || query.Sort != "title" {
+				t.Errorf("Expected page 2 sorted by title, got %+v", query)
+			}
+			return mockapi.PaginatedBooks{Data: []mockapi.Book{{ID: 7, Author: "Martin Fowler"}}, Page: 2}, nil
+		},
+	}
+
+	router.SetupMockApiRoute(service)
+
+	req, _ := http.NewRequest("GET", "/api/authors/3/books?page=2&sort=title", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	var response mockapi.PaginatedBooks
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(response.Data) != 1 || response.Data[0].ID != 7 {
+		t.Errorf("Unexpected response %+v", response)
+	}
+}
+
+func TestAuthorsAndCategories_Routes(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	service := &mockService{
+		getAuthorsFunc: func() ([]mockapi.Author, error) {
+			return []mockapi.Author{{ID: 1, Name: "Alan A. A. Donovan"}}, nil
+		},
+		getCategoryByIDFunc: func(id string) (mockapi.Category, error) {
+			return mockapi.Category{}, mockapi.NewCategoryNotFoundError(id)
+		},
+	}
+
+	router.SetupMockApiRoute(service)
+
+	tests := []struct {
+		path       string
+		statusCode int
+	}{
+		{"/api/authors", http.StatusOK},
+		{"/api/authors/1", http.StatusOK},
+		{"/api/authors/abc", http.StatusBadRequest},
+		{"/api/categories", http.StatusOK},
+		{"/api/categories/99", http.StatusNotFound},
+		{"/api/categories/abc/books", http.StatusBadRequest},
+		{"/api/categories/1/books?sort=isbn", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		req, _ := http.NewRequest("GET", tt.path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != tt.statusCode {
+			t.Errorf("Expected status code %d for %s, got %d", tt.statusCode, tt.path, w.Code)
+		}
+	}
+}
+
+func TestCreateReview_Success(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	service := &mockService{
+		createReviewFunc: func(bookID string, review mockapi.Review) (mockapi.Review, error) {
+			if bookID != "5" {
+				t.Errorf("Expected book ID 5, got %s", bookID)
+			}
+			review.ID = 101
+			review.BookID = 5
+			return review, nil
+		},
+	}
+
+	router.SetupMockApiRoute(service)
+
+	body := []byte(`{"reviewer":"Alice","rating":4,"comment":"Great"}`)
+	req, _ := http.NewRequest("POST", "/api/books/5/reviews", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d", http.StatusCreated, w.Code)
+	}
+	var review mockapi.Review
+	if err := json.Unmarshal(w.Body.Bytes(), &review); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if review.ID != 101 || review.Reviewer != "Alice" {
+		t.Errorf("Unexpected review %+v", review)
+	}
+}
+
+func TestGetBookReviews_NotFound(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	service := &mockService{
+		getBookReviewsFunc: func(bookID string) ([]mockapi.Review, error) {
+			return nil, mockapi.NewBookNotFoundError(bookID)
+		},
+	}
+
+	router.SetupMockApiRoute(service)
+
+	req, _ := http.NewRequest("GET", "/api/books/999/reviews", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestStaticFiles(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	router.SetupMockApiRoute(&mockService{})
+
+	req, _ := http.NewRequest("GET", mockapi.GetMockapiStaticImagePath()+"clean-code.jpg", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "image/jpeg" {
+		t.Errorf("Expected image/jpeg content type, got %s", contentType)
+	}
+}
+
+func TestImageRoute(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	router.SetupMockApiRoute(&mockService{})
+
+	path := mockapi.GetMockapiImagePath() + "/clean-code.jpg"
+	req, _ := http.NewRequest("GET", path+"?w=80&h=80&fmt=webp", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "image/webp" {
+		t.Errorf("Expected image/webp content type, got %s", contentType)
+	}
+	if w.Header().Get("Cache-Control") != mockapi.ImageCacheControl || w.Header().Get("ETag") == "" {
+		t.Errorf("Expected caching headers, got %v", w.Header())
+	}
+
+	req, _ = http.NewRequest("GET", path+"?w=80&h=80&fmt=webp", nil)
+	req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotModified, w.Code)
+	}
+
+	for _, tt := range []struct {
+		path string
+		code int
+	}{
+		{path + "?w=abc", http.StatusBadRequest},
+		{path + "?fmt=gif", http.StatusBadRequest},
+		{mockapi.GetMockapiImagePath() + "/missing.jpg", http.StatusNotFound},
+	} {
+		req, _ := http.NewRequest("GET", tt.path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tt.code {
+			t.Errorf("Expected status code %d for %s, got %d", tt.code, tt.path, w.Code)
+		}
+	}
+}
+
+func TestPlaceholderRoute(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+
+	router.SetupMockApiRoute(&mockService{
+		getBookByIDFunc: func(id string) (mockapi.Book, error) {
+			if id == "7" {
+				return mockapi.Book{ID: 7, Title: "Untitled", Author: "Anonymous"}, nil
+			}
+			return mockapi.Book{}, mockapi.NewBookNotFoundError(id)
+		},
+	})
+
+	path := mockapi.GetMockapiPlaceholderPath() + "/7"
+	req, _ := http.NewRequest("GET", path, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "image/png" {
+		t.Errorf("Expected image/png content type, got %s", contentType)
+	}
+	if w.Header().Get("Cache-Control") != mockapi.PlaceholderCacheControl || w.Header().Get("ETag") == "" {
+		t.Errorf("Expected caching headers, got %v", w.Header())
+	}
+
+	req, _ = http.NewRequest("GET", path, nil)
+	req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotModified, w.Code)
+	}
+
+	for _, tt := range []struct {
+		path string
+		code int
+	}{
+		{path + "?fmt=svg", http.StatusOK},
+		{path + "?fmt=gif", http.StatusBadRequest},
+		{mockapi.GetMockapiPlaceholderPath() + "/8", http.StatusNotFound},
+	} {
+		req, _ := http.NewRequest("GET", tt.path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tt.code {
+			t.Errorf("Expected status code %d for %s, got %d", tt.code, tt.path, w.Code)
+		}
+	}
+}
+
+type widget struct {
+	ID    int     `json:"id"`
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+type mockResourceDataSource struct {
+	getByIDFunc func(id string) (widget, error)
+	listFunc    func(query mockapi.ResourceQuery) ([]widget, error)
+}
+
+func (m *mockResourceDataSource) PopulateData(schema *mockapi.ResourceSchema, seed []widget) error {
+	return nil
+}
+
+func (m *mockResourceDataSource) GetByID(id string) (widget, error) {
+	if m.getByIDFunc != nil {
+		return m.getByIDFunc(id)
+	}
+	return widget{}, nil
+}
+
+func (m *mockResourceDataSource) List(query mockapi.ResourceQuery) ([]widget, error) {
+	if m.listFunc != nil {
+		return m.listFunc(query)
+	}
+	return []widget{}, nil
+}
+
+func (m *mockResourceDataSource) Count(query mockapi.ResourceQuery) (int64, error) {
+	return 1, nil
+}
+
+func setupTestResource(t *testing.T, ds *mockResourceDataSource) *mockapi.Resource[widget] {
+	resource, err := mockapi.NewResource(mockapi.ResourceConfig[widget]{Name: "widgets", DataSource: ds})
+	if err != nil {
+		t.Fatalf("NewResource failed: %v", err)
+	}
+	return resource
+}
+
+func TestResourceRoute_List(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r).(mockapi.ResourceRouter)
+
+	ds := &mockResourceDataSource{
+		listFunc: func(query mockapi.ResourceQuery) ([]widget, error) {
+			if query.Page != 2 || query.PageSize != 5 || query.Search != "bolt" || query.Sort != "price" || query.Order != mockapi.OrderDesc {
+				t.Errorf("Unexpected query %+v", query)
+			}
+			return []widget{{ID: 1, Name: "Bolt"}}, nil
+		},
+	}
+	router.SetupResourceRoute(setupTestResource(t, ds))
+
+	req, _ := http.NewRequest("GET", "/api/widgets?page=2&page_size=5&search=bolt&sort=price&order=DESC", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	var response mockapi.PaginatedResources[widget]
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if len(response.Data) != 1 || response.Data[0].Name != "Bolt" {
+		t.Errorf("Expected one widget named Bolt, got %+v", response.Data)
+	}
+}
+
+func TestResourceRoute_UnknownSortField(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r).(mockapi.ResourceRouter)
+	router.SetupResourceRoute(setupTestResource(t, &mockResourceDataSource{}))
+
+	req, _ := http.NewRequest("GET", "/api/widgets?sort=color", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestResourceRoute_GetByID(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r).(mockapi.ResourceRouter)
+
+	ds := &mockResourceDataSource{
+		getByIDFunc: func(id string) (widget, error) {
+			if id != "1" {
+				return widget{}, mockapi.NewResourceNotFoundError("widgets", id)
+			}
+			return widget{ID: 1, Name: "Bolt"}, nil
+		},
+	}
+	router.SetupResourceRoute(setupTestResource(t, ds))
+
+	req, _ := http.NewRequest("GET", "/api/widgets/1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	var item widget
+	json.Unmarshal(w.Body.Bytes(), &item)
+	if item.Name != "Bolt" {
+		t.Errorf("Expected widget Bolt, got %+v", item)
+	}
+
+	req, _ = http.NewRequest("GET", "/api/widgets/2", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
@@ -5,6 +5,7 @@ go 1.25.1
 require (
 	github.com/anggaaryas/go-mockapi v0.1.3
 	github.com/gin-gonic/gin v1.11.0
+	github.com/ugorji/go/codec v1.3.1
 )
 
 require (
@@ -29,7 +30,6 @@ require (
 	github.com/quic-go/qpack v0.5.1 // indirect
 	github.com/quic-go/quic-go v0.55.0 // indirect
 	github.com/twitchyliquid64/golang-asm v0.15.1 // indirect
-	github.com/ugorji/go/codec v1.3.1 // indirect
 	go.uber.org/mock v0.6.0 // indirect
 	golang.org/x/arch v0.22.0 // indirect
 	golang.org/x/crypto v0.43.0 // indirect
@@ -0,0 +1,63 @@
+package ginrouter
+
+import (
+	"github.com/anggaaryas/go-mockapi"
+	"github.com/gin-gonic/gin"
+)
+
+// setupImageRoute registers GET /mockapi/image/:filename, which serves an
+// embedded image resized and converted according to the w, h and fmt query
+// parameters.
+func (cfg *config) setupImageRoute() {
+	renderer := mockapi.NewImageRenderer(mockapi.DefaultImageCacheSize)
+
+	cfg.r.GET(mockapi.GetMockapiImagePath()+"/:filename", func(c *gin.Context) {
+		opts, err := mockapi.ParseImageOptions(c.Query("w"), c.Query("h"), c.Query("fmt"))
+		if err != nil {
+			apiErr := cfg.getErrorResponse(err)
+			c.JSON(apiErr.StatusCode, apiErr)
+			return
+		}
+		img, err := renderer.Render(c.Param("filename"), opts)
+		if err != nil {
+			apiErr := cfg.getErrorResponse(err)
+			c.JSON(apiErr.StatusCode, apiErr)
+			return
+		}
+
+		c.Header("Cache-Control", mockapi.ImageCacheControl)
+		c.Header("ETag", img.ETag)
+		if img.NotModified(c.GetHeader("If-None-Match")) {
+			c.Status(304)
+			return
+		}
+		c.Data(200, img.ContentType, img.Data)
+	})
+}
+
+// setupPlaceholderRoute registers GET /mockapi/placeholder/:id, which renders
+// the placeholder cover of a book as a PNG, or an SVG with fmt=svg.
+func (cfg *config) setupPlaceholderRoute(service mockapi.Service) {
+	cfg.r.GET(mockapi.GetMockapiPlaceholderPath()+"/:id", func(c *gin.Context) {
+		book, err := serviceFor(c, service).GetBookByID(c.Param("id"))
+		if err != nil {
+			apiErr := cfg.getErrorResponse(err)
+			c.JSON(apiErr.StatusCode, apiErr)
+			return
+		}
+		img, err := mockapi.RenderPlaceholder(book, c.Query("fmt"))
+		if err != nil {
+			apiErr := cfg.getErrorResponse(err)
+			c.JSON(apiErr.StatusCode, apiErr)
+			return
+		}
+
+		c.Header("Cache-Control", mockapi.PlaceholderCacheControl)
+		c.Header("ETag", img.ETag)
+		if img.NotModified(c.GetHeader("If-None-Match")) {
+			c.Status(304)
+			return
+		}
+		c.Data(200, img.ContentType, img.Data)
+	})
+}
@@ -0,0 +1,209 @@
+package ginrouter
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/anggaaryas/go-mockapi"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/render"
+)
+
+// Response formats of the book routes, selected with the format query
+// parameter or the Accept header.
+const (
+	FormatJSON    = "json"
+	FormatXML     = "xml"
+	FormatCSV     = "csv"
+	FormatMsgPack = "msgpack"
+)
+
+const (
+	formatKey = "mockapi.format"
+
+	mimeCSV = "text/csv"
+)
+
+var formats = []string{FormatJSON, FormatXML, FormatCSV, FormatMsgPack}
+
+// formatMediaTypes maps the media types of the Accept header to formats, in
+// order of preference.
+var formatMediaTypes = []struct {
+	mediaType string
+	format    string
+}{
+	{binding.MIMEJSON, FormatJSON},
+	{mockapi.ResponseStyleJSONAPI.ContentType(), FormatJSON},
+	{mockapi.ResponseStyleHAL.ContentType(), FormatJSON},
+	{binding.MIMEXML, FormatXML},
+	{binding.MIMEXML2, FormatXML},
+	{mimeCSV, FormatCSV},
+	{binding.MIMEMSGPACK2, FormatMsgPack},
+	{binding.MIMEMSGPACK, FormatMsgPack},
+}
+
+var bookCSVHeader = []string{"id", "title", "author", "category", "desc", "cover_url"}
+
+// negotiateFormat is the middleware choosing the response format of a route.
+// The format query parameter takes precedence over the Accept header, and
+// browsers, which ask for text/html first, keep getting JSON. Requests for
+// any other format get a 406 Not Acceptable.
+func (cfg *config) negotiateFormat(c *gin.Context) {
+	format := strings.ToLower(c.Query("format"))
+	if format == "" {
+		format = acceptedFormat(c)
+	}
+	if !slices.Contains(formats, format) {
+		c.Abort()
+		apiErr := cfg.getErrorResponse(NewNotAcceptableError(formats))
+		c.JSON(apiErr.StatusCode, apiErr)
+		return
+	}
+	c.Set(formatKey, format)
+	c.Next()
+}
+
+// acceptedFormat returns the format matching the Accept header, JSON when
+// there is no header, or "" when no format matches.
+func acceptedFormat(c *gin.Context) string {
+	accept := c.GetHeader("Accept")
+	if accept == "" || strings.Contains(accept, "text/html") {
+		return FormatJSON
+	}
+
+	offered := make([]string, len(formatMediaTypes))
+	for i, media := range formatMediaTypes {
+		offered[i] = media.mediaType
+	}
+	mediaType := c.NegotiateFormat(offered...)
+	for _, media := range formatMediaTypes {
+		if media.mediaType == mediaType {
+			return media.format
+		}
+	}
+	return ""
+}
+
+// render writes data in the format chosen by negotiateFormat, or as JSON on
+// routes without it. JSON is rendered in the response style of the router.
+func (cfg *config) render(c *gin.Context, code int, data any) {
+	switch c.GetString(formatKey) {
+	case FormatXML:
+		c.Render(code, xmlRender{data: data})
+	case FormatCSV:
+		c.Render(code, csvRender{data: data})
+	case FormatMsgPack:
+		c.Render(code, render.MsgPack{Data: data})
+	default:
+		if cfg.style.IsPlain() {
+			c.JSON(code, data)
+			return
+		}
+		doc, err := cfg.style.Render(serviceFor(c, cfg.service), c.Request.URL, data)
+		if err != nil {
+			// Errors are written as they are when the style fails on them too.
+			if _, ok := data.(mockapi.APIError); ok {
+				c.JSON(code, data)
+				return
+			}
+			cfg.renderError(c, err)
+			return
+		}
+		c.Header("Content-Type", cfg.style.ContentType())
+		c.JSON(code, doc)
+	}
+}
+
+// renderError writes err as an APIError with render.
+func (cfg *config) renderError(c *gin.Context, err error) {
+	apiErr := cfg.getErrorResponse(err)
+	cfg.render(c, apiErr.StatusCode, apiErr)
+}
+
+// xmlRender writes data as an XML document whose root element is named
+// after the kind of data.
+type xmlRender struct {
+	data any
+}
+
+func (r xmlRender) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", binding.MIMEXML+"; charset=utf-8")
+}
+
+func (r xmlRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+
+	name := "response"
+	switch r.data.(type) {
+	case mockapi.Book:
+		name = "book"
+	case mockapi.PaginatedBooks, mockapi.CursorBooks:
+		name = "books"
+	case mockapi.APIError:
+		name = "error"
+	}
+	if _, err := w.Write([]byte(xml.Header)); err != nil {
+		return err
+	}
+	return xml.NewEncoder(w).EncodeElement(r.data, xml.StartElement{Name: xml.Name{Local: name}})
+}
+
+// csvRender writes books as CSV rows under a header row. The pagination
+// fields of a page of books are sent as X-Page, X-Page-Size, X-Total-Items,
+// X-Total-Pages, X-Next-Cursor and X-Prev-Cursor headers instead.
+type csvRender struct {
+	data any
+}
+
+func (r csvRender) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", mimeCSV+"; charset=utf-8")
+}
+
+func (r csvRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+
+	var header []string
+	var rows [][]string
+	switch data := r.data.(type) {
+	case mockapi.Book:
+		header, rows = bookCSVHeader, bookCSVRows([]mockapi.Book{data})
+	case mockapi.PaginatedBooks:
+		w.Header().Set("X-Page", strconv.Itoa(data.Page))
+		w.Header().Set("X-Page-Size", strconv.Itoa(data.PageSize))
+		w.Header().Set("X-Total-Items", strconv.FormatInt(data.TotalItems, 10))
+		w.Header().Set("X-Total-Pages", strconv.Itoa(data.TotalPages))
+		header, rows = bookCSVHeader, bookCSVRows(data.Data)
+	case mockapi.CursorBooks:
+		w.Header().Set("X-Page-Size", strconv.Itoa(data.PageSize))
+		if data.NextCursor != "" {
+			w.Header().Set("X-Next-Cursor", data.NextCursor)
+		}
+		if data.PrevCursor != "" {
+			w.Header().Set("X-Prev-Cursor", data.PrevCursor)
+		}
+		header, rows = bookCSVHeader, bookCSVRows(data.Data)
+	case mockapi.APIError:
+		header, rows = []string{"code", "message"}, [][]string{{strconv.Itoa(data.StatusCode), data.Message}}
+	default:
+		return fmt.Errorf("csv: cannot write %T", r.data)
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Write(header)
+	writer.WriteAll(rows)
+	return writer.Error()
+}
+
+func bookCSVRows(books []mockapi.Book) [][]string {
+	rows := make([][]string, len(books))
+	for i, book := range books {
+		rows[i] = []string{strconv.Itoa(book.ID), book.Title, book.Author, book.Category, book.Desc, book.CoverURL}
+	}
+	return rows
+}
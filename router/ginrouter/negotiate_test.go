@@ -0,0 +1,219 @@
+package ginrouter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anggaaryas/go-mockapi"
+	"github.com/ugorji/go/codec"
+)
+
+func setupNegotiationRouter() http.Handler {
+	r := setupTestRouter()
+	Create(r).SetupMockApiRoute(&mockService{
+		getBookByIDFunc: func(id string) (mockapi.Book, error) {
+			if id == "1" {
+				return mockapi.Book{ID: 1, Title: "Go, Again", Author: "Gopher", CoverURL: "http://test.com/go.jpg"}, nil
+			}
+			return mockapi.Book{}, mockapi.NewBookNotFoundError(id)
+		},
+		getBooksFunc: func(query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+			return mockapi.PaginatedBooks{
+				Data:       []mockapi.Book{{ID: 1, Title: "Book 1", Author: "A"}, {ID: 2, Title: "Book 2", Author: "B"}},
+				Page:       1,
+				PageSize:   2,
+				TotalItems: 5,
+				TotalPages: 3,
+			}, nil
+		},
+	})
+	return r
+}
+
+func negotiationRequest(handler http.Handler, path string, accept string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", path, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestNegotiateFormat_ContentType(t *testing.T) {
+	handler := setupNegotiationRouter()
+
+	tests := []struct {
+		path        string
+		accept      string
+		contentType string
+	}{
+		{"/api/books/1", "", "application/json"},
+		{"/api/books/1", "*/*", "application/json"},
+		{"/api/books/1", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", "application/json"},
+		{"/api/books/1", "application/xml", "application/xml"},
+		{"/api/books/1", "text/xml", "application/xml"},
+		{"/api/books/1", "text/csv", "text/csv"},
+		{"/api/books/1", "application/msgpack", "application/msgpack"},
+		{"/api/books/1", "application/x-msgpack", "application/msgpack"},
+		{"/api/books/1?format=csv", "application/json", "text/csv"},
+		{"/api/books/1?format=XML", "", "application/xml"},
+	}
+	for _, tt := range tests {
+		w := negotiationRequest(handler, tt.path, tt.accept)
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status code %d for %s with Accept %q, got %d", http.StatusOK, tt.path, tt.accept, w.Code)
+		}
+		if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, tt.contentType) {
+			t.Errorf("Expected content type %s for %s with Accept %q, got %s", tt.contentType, tt.path, tt.accept, contentType)
+		}
+	}
+}
+
+func TestNegotiateFormat_NotAcceptable(t *testing.T) {
+	handler := setupNegotiationRouter()
+
+	for _, tt := range []struct {
+		path   string
+		accept string
+	}{
+		{"/api/books/1", "image/png"},
+		{"/api/books", "application/yaml"},
+		{"/api/books/1?format=yaml", ""},
+	} {
+		w := negotiationRequest(handler, tt.path, tt.accept)
+		if w.Code != http.StatusNotAcceptable {
+			t.Errorf("Expected status code %d for %s with Accept %q, got %d", http.StatusNotAcceptable, tt.path, tt.accept, w.Code)
+		}
+	}
+}
+
+func TestNegotiateFormat_XML(t *testing.T) {
+	handler := setupNegotiationRouter()
+
+	w := negotiationRequest(handler, "/api/books?page_size=2", "application/xml")
+	var page struct {
+		XMLName    xml.Name       `xml:"books"`
+		Books      []mockapi.Book `xml:"data>book"`
+		TotalItems int64          `xml:"total_items"`
+	}
+	if err := xml.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatalf("Failed to parse XML: %v\n%s", err, w.Body.String())
+	}
+	if len(page.Books) != 2 || page.Books[1].Title != "Book 2" || page.TotalItems != 5 {
+		t.Errorf("Unexpected page %+v", page)
+	}
+
+	w = negotiationRequest(handler, "/api/books/9", "application/xml")
+	var apiErr struct {
+		XMLName xml.Name `xml:"error"`
+		Code    int      `xml:"code"`
+	}
+	if err := xml.Unmarshal(w.Body.Bytes(), &apiErr); err != nil || apiErr.Code != http.StatusNotFound {
+		t.Errorf("Expected an XML error with code 404, got %v %s", err, w.Body.String())
+	}
+}
+
+func TestNegotiateFormat_CSV(t *testing.T) {
+	handler := setupNegotiationRouter()
+
+	w := negotiationRequest(handler, "/api/books/1?format=csv", "")
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	expected := [][]string{
+		{"id", "title", "author", "category", "desc", "cover_url"},
+		{"1", "Go, Again", "Gopher", "", "", "http://test.com/go.jpg"},
+	}
+	if len(records) != 2 || strings.Join(records[0], ",") != strings.Join(expected[0], ",") || records[1][1] != expected[1][1] {
+		t.Errorf("Expected %q, got %q", expected, records)
+	}
+
+	w = negotiationRequest(handler, "/api/books?format=csv", "")
+	records, _ = csv.NewReader(w.Body).ReadAll()
+	if len(records) != 3 {
+		t.Errorf("Expected a header and 2 rows, got %q", records)
+	}
+	if w.Header().Get("X-Total-Items") != "5" || w.Header().Get("X-Total-Pages") != "3" {
+		t.Errorf("Expected pagination headers, got %v", w.Header())
+	}
+}
+
+func TestNegotiateFormat_MsgPack(t *testing.T) {
+	handler := setupNegotiationRouter()
+
+	w := negotiationRequest(handler, "/api/books/1", "application/msgpack")
+	var book map[string]any
+	handle := codec.MsgpackHandle{}
+	handle.RawToString = true
+	if err := codec.NewDecoderBytes(w.Body.Bytes(), &handle).Decode(&book); err != nil {
+		t.Fatalf("Failed to decode MessagePack: %v", err)
+	}
+	if title, _ := book["title"].(string); title != "Go, Again" {
+		t.Errorf("Expected title Go, Again, got %v", book)
+	}
+}
+
+func TestCreateWithStyle(t *testing.T) {
+	if _, err := CreateWithStyle(setupTestRouter(), "xml"); err == nil {
+		t.Error("Expected error for an invalid style")
+	}
+
+	r := setupTestRouter()
+	router, err := CreateWithStyle(r, mockapi.ResponseStyleJSONAPI)
+	if err != nil {
+		t.Fatalf("CreateWithStyle failed: %v", err)
+	}
+	router.SetupMockApiRoute(&mockService{
+		getBookByIDFunc: func(id string) (mockapi.Book, error) {
+			if id == "1" {
+				return mockapi.Book{ID: 1, Title: "Go", Author: "Gopher"}, nil
+			}
+			return mockapi.Book{}, mockapi.NewBookNotFoundError(id)
+		},
+		getAuthorsFunc: func() ([]mockapi.Author, error) {
+			return []mockapi.Author{{ID: 3, Name: "Gopher"}}, nil
+		},
+	})
+
+	w := negotiationRequest(r, "/api/books/1", "application/vnd.api+json")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "application/vnd.api+json" {
+		t.Errorf("Expected content type application/vnd.api+json, got %s", contentType)
+	}
+	var doc struct {
+		Data struct {
+			Type string `json:"type"`
+			ID   string `json:"id"`
+		} `json:"data"`
+		Included []map[string]any `json:"included"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &doc)
+	if doc.Data.Type != "books" || doc.Data.ID != "1" || len(doc.Included) != 1 {
+		t.Errorf("Unexpected JSON:API document %s", w.Body.String())
+	}
+
+	w = negotiationRequest(r, "/api/books/9", "")
+	var errs struct {
+		Errors []struct {
+			Status string `json:"status"`
+		} `json:"errors"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &errs)
+	if w.Code != http.StatusNotFound || len(errs.Errors) != 1 || errs.Errors[0].Status != "404" {
+		t.Errorf("Expected a JSON:API error with status 404, got %d %s", w.Code, w.Body.String())
+	}
+
+	w = negotiationRequest(r, "/api/books/1?format=xml", "")
+	if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "application/xml") {
+		t.Errorf("Expected the XML format to ignore the style, got %s", contentType)
+	}
+}
@@ -0,0 +1,553 @@
+package ginrouter
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anggaaryas/go-mockapi"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultOIDCTokenTTL = time.Hour
+	oidcCodeTTL         = time.Minute
+	oidcKeyID           = "mockapi"
+)
+
+// OIDCClient is an OAuth2 client registered with the mock provider. Clients
+// without a secret are public and must use PKCE. An empty RedirectURIs list
+// accepts any redirect URI.
+type OIDCClient struct {
+	ID           string   `json:"id"`
+	Secret       string   `json:"secret,omitempty"`
+	RedirectURIs []string `json:"redirect_uris,omitempty"`
+}
+
+// DefaultOIDCClients returns the clients used when OIDCConfig has no clients:
+// a confidential client for server apps and a public one for SPAs and mobile apps.
+func DefaultOIDCClients() []OIDCClient {
+	return []OIDCClient{
+		{ID: "mockapi", Secret: "mockapi-secret"},
+		{ID: "mockapi-public"},
+	}
+}
+
+// OIDCConfig configures the OAuth2 and OpenID Connect mock provider. Issuer
+// defaults to BASE_URL followed by /mockapi/oidc, where the endpoints are
+// served. Users default to DefaultAuthUsers and sign in with their password,
+// or without a prompt when the authorization request has a matching
+// login_hint. Tokens expire after TokenTTLSeconds, one hour by default.
+type OIDCConfig struct {
+	Issuer          string          `json:"issuer,omitempty"`
+	Clients         []OIDCClient    `json:"clients,omitempty"`
+	Users           []AuthUser      `json:"users,omitempty"`
+	TokenTTLSeconds int             `json:"token_ttl_seconds"`
+	PrivateKey      *rsa.PrivateKey `json:"-"`
+}
+
+// OIDCTokens is the response of the token endpoint.
+type OIDCTokens struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token,omitempty"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+// oauthError is an error response as defined by RFC 6749.
+type oauthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+	status      int
+}
+
+func (e *oauthError) StatusCode() int {
+	return e.status
+}
+
+func (e *oauthError) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+func newOAuthError(status int, code string, description string) *oauthError {
+	return &oauthError{Code: code, Description: description, status: status}
+}
+
+type authorizationRequest struct {
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+type authorizationCode struct {
+	request   authorizationRequest
+	user      AuthUser
+	expiresAt time.Time
+}
+
+// OIDCProvider is a local OAuth2 and OpenID Connect provider supporting the
+// authorization code flow with PKCE and the client credentials flow. Tokens
+// are RS256 JWTs that can be checked against the JWKS endpoint.
+type OIDCProvider struct {
+	mu       sync.Mutex
+	issuer   string
+	key      *rsa.PrivateKey
+	tokenTTL time.Duration
+	clients  map[string]OIDCClient
+	users    map[string]AuthUser
+	names    []string
+	codes    map[string]authorizationCode
+	now      func() time.Time
+}
+
+// GetOIDCPath returns the path the provider's endpoints are served under.
+func GetOIDCPath() string {
+	return mockapi.GetMockapiPath() + "/oidc"
+}
+
+func NewOIDCProvider(config OIDCConfig) (*OIDCProvider, error) {
+	if config.TokenTTLSeconds < 0 {
+		return nil, NewInvalidAuthConfigError("token TTL should not be negative")
+	}
+	if len(config.Clients) == 0 {
+		config.Clients = DefaultOIDCClients()
+	}
+	if len(config.Users) == 0 {
+		config.Users = DefaultAuthUsers()
+	}
+
+	provider := &OIDCProvider{
+		issuer:   strings.TrimSuffix(config.Issuer, "/"),
+		key:      config.PrivateKey,
+		tokenTTL: time.Duration(config.TokenTTLSeconds) * time.Second,
+		clients:  map[string]OIDCClient{},
+		users:    map[string]AuthUser{},
+		codes:    map[string]authorizationCode{},
+		now:      time.Now,
+	}
+	if provider.issuer == "" {
+		provider.issuer = mockapi.GetBaseURL() + GetOIDCPath()
+	}
+	if provider.tokenTTL == 0 {
+		provider.tokenTTL = defaultOIDCTokenTTL
+	}
+	if provider.key == nil {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, err
+		}
+		provider.key = key
+	}
+	for _, client := range config.Clients {
+		if client.ID == "" {
+			return nil, NewInvalidAuthConfigError("clients need an id")
+		}
+		if _, ok := provider.clients[client.ID]; ok {
+			return nil, NewInvalidAuthConfigError(fmt.Sprintf("client %s is defined more than once", client.ID))
+		}
+		provider.clients[client.ID] = client
+	}
+	for _, user := range config.Users {
+		if user.Username == "" {
+			return nil, NewInvalidAuthConfigError("users need a username")
+		}
+		if _, ok := provider.users[user.Username]; ok {
+			return nil, NewInvalidAuthConfigError(fmt.Sprintf("user %s is defined more than once", user.Username))
+		}
+		provider.users[user.Username] = user
+		provider.names = append(provider.names, user.Username)
+	}
+	return provider, nil
+}
+
+// Issuer returns the issuer of the tokens, which is also the base URL of the
+// discovery document.
+func (p *OIDCProvider) Issuer() string {
+	return p.issuer
+}
+
+func (p *OIDCProvider) sign(claims map[string]any) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": oidcKeyID})
+	payload, _ := json.Marshal(claims)
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, _ := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// verify checks the signature and expiry of a token issued by the provider
+// and returns its claims.
+func (p *OIDCProvider) verify(token string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, NewInvalidTokenError()
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, NewInvalidTokenError()
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if rsa.VerifyPKCS1v15(&p.key.PublicKey, crypto.SHA256, digest[:], signature) != nil {
+		return nil, NewInvalidTokenError()
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, NewInvalidTokenError()
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, NewInvalidTokenError()
+	}
+	if exp, _ := claims["exp"].(float64); p.now().Unix() >= int64(exp) {
+		return nil, NewTokenExpiredError()
+	}
+	return claims, nil
+}
+
+// userClaims returns the claims about user that the granted scopes allow.
+func userClaims(user AuthUser, scopes []string) map[string]any {
+	claims := map[string]any{"sub": user.Username}
+	if slices.Contains(scopes, "profile") {
+		claims["preferred_username"] = user.Username
+		if user.Name != "" {
+			claims["name"] = user.Name
+		}
+	}
+	if slices.Contains(scopes, "email") && user.Email != "" {
+		claims["email"] = user.Email
+		claims["email_verified"] = true
+	}
+	if len(user.Roles) > 0 {
+		claims["roles"] = user.Roles
+	}
+	return claims
+}
+
+// issue creates the tokens of a grant. Without a user, as in the client
+// credentials flow, the subject is the client and no ID token is issued.
+func (p *OIDCProvider) issue(clientID string, user *AuthUser, scope string, nonce string) OIDCTokens {
+	now := p.now()
+	scopes := strings.Fields(scope)
+	access := map[string]any{
+		"iss":       p.issuer,
+		"sub":       clientID,
+		"aud":       p.issuer,
+		"client_id": clientID,
+		"scope":     scope,
+		"jti":       newTokenID(),
+		"iat":       now.Unix(),
+		"exp":       now.Add(p.tokenTTL).Unix(),
+	}
+	if user != nil {
+		access["sub"] = user.Username
+	}
+
+	tokens := OIDCTokens{
+		AccessToken: p.sign(access),
+		TokenType:   "Bearer",
+		ExpiresIn:   int(p.tokenTTL / time.Second),
+		Scope:       scope,
+	}
+	if user != nil && slices.Contains(scopes, "openid") {
+		id := userClaims(*user, scopes)
+		id["iss"] = p.issuer
+		id["aud"] = clientID
+		id["iat"] = now.Unix()
+		id["exp"] = now.Add(p.tokenTTL).Unix()
+		id["auth_time"] = now.Unix()
+		if nonce != "" {
+			id["nonce"] = nonce
+		}
+		tokens.IDToken = p.sign(id)
+	}
+	return tokens
+}
+
+// discovery returns the OpenID Connect discovery document.
+func (p *OIDCProvider) discovery() map[string]any {
+	return map[string]any{
+		"issuer":                                p.issuer,
+		"authorization_endpoint":                p.issuer + "/authorize",
+		"token_endpoint":                        p.issuer + "/token",
+		"userinfo_endpoint":                     p.issuer + "/userinfo",
+		"jwks_uri":                              p.issuer + "/jwks.json",
+		"response_types_supported":              []string{"code"},
+		"grant_types_supported":                 []string{"authorization_code", "client_credentials"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"scopes_supported":                      []string{"openid", "profile", "email"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post", "none"},
+		"code_challenge_methods_supported":      []string{"S256", "plain"},
+		"claims_supported":                      []string{"sub", "name", "preferred_username", "email", "email_verified", "roles", "nonce"},
+	}
+}
+
+// jwks returns the public signing key as a JSON Web Key Set.
+func (p *OIDCProvider) jwks() map[string]any {
+	pub := p.key.PublicKey
+	return map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": oidcKeyID,
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	}
+}
+
+// checkAuthorizationRequest validates the client, redirect URI and PKCE
+// parameters. Errors are returned to the user agent rather than redirected
+// because the redirect URI cannot be trusted yet.
+func (p *OIDCProvider) checkAuthorizationRequest(req authorizationRequest, responseType string) error {
+	client, ok := p.clients[req.ClientID]
+	if !ok {
+		return newOAuthError(400, "invalid_client", "unknown client_id")
+	}
+	if req.RedirectURI == "" || (len(client.RedirectURIs) > 0 && !slices.Contains(client.RedirectURIs, req.RedirectURI)) {
+		return newOAuthError(400, "invalid_request", "redirect_uri is missing or not registered for the client")
+	}
+	if responseType != "code" {
+		return newOAuthError(400, "unsupported_response_type", "only the code response type is supported")
+	}
+	if client.Secret == "" && req.CodeChallenge == "" {
+		return newOAuthError(400, "invalid_request", "public clients must use PKCE")
+	}
+	switch req.CodeChallengeMethod {
+	case "", "plain", "S256":
+	default:
+		return newOAuthError(400, "invalid_request", "code_challenge_method should be S256 or plain")
+	}
+	return nil
+}
+
+// redirectWithCode sends the user agent back to the client with a new
+// authorization code.
+func (p *OIDCProvider) redirectWithCode(c *gin.Context, req authorizationRequest, user AuthUser) {
+	code := newTokenID()
+	p.mu.Lock()
+	for id, issued := range p.codes {
+		if p.now().After(issued.expiresAt) {
+			delete(p.codes, id)
+		}
+	}
+	p.codes[code] = authorizationCode{request: req, user: user, expiresAt: p.now().Add(oidcCodeTTL)}
+	p.mu.Unlock()
+
+	params := url.Values{"code": {code}}
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+	c.Redirect(http.StatusFound, appendQuery(req.RedirectURI, params))
+}
+
+func appendQuery(rawURL string, params url.Values) string {
+	if strings.Contains(rawURL, "?") {
+		return rawURL + "&" + params.Encode()
+	}
+	return rawURL + "?" + params.Encode()
+}
+
+var oidcLoginPage = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Sign in to Go MockAPI</title></head>
+<body>
+<h1>Sign in</h1>
+<p>{{.ClientID}} wants to access your account.</p>
+{{if .Error}}<p style="color: red">{{.Error}}</p>{{end}}
+<form method="post">
+<input type="hidden" name="client_id" value="{{.ClientID}}">
+<input type="hidden" name="redirect_uri" value="{{.RedirectURI}}">
+<input type="hidden" name="response_type" value="code">
+<input type="hidden" name="scope" value="{{.Scope}}">
+<input type="hidden" name="state" value="{{.State}}">
+<input type="hidden" name="nonce" value="{{.Nonce}}">
+<input type="hidden" name="code_challenge" value="{{.CodeChallenge}}">
+<input type="hidden" name="code_challenge_method" value="{{.CodeChallengeMethod}}">
+<label>Username <select name="username">{{range .Users}}<option>{{.}}</option>{{end}}</select></label>
+<label>Password <input type="password" name="password"></label>
+<button type="submit">Sign in</button>
+</form>
+</body>
+</html>
+`))
+
+func (p *OIDCProvider) renderLogin(c *gin.Context, req authorizationRequest, message string) {
+	c.Status(200)
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	oidcLoginPage.Execute(c.Writer, struct {
+		authorizationRequest
+		Users []string
+		Error string
+	}{req, p.names, message})
+}
+
+// authenticateClient reads client credentials from HTTP basic auth or the
+// form body. Public clients only send their client_id.
+func (p *OIDCProvider) authenticateClient(c *gin.Context) (OIDCClient, error) {
+	clientID, secret, ok := c.Request.BasicAuth()
+	if !ok {
+		clientID, secret = c.PostForm("client_id"), c.PostForm("client_secret")
+	}
+	client, found := p.clients[clientID]
+	if !found || subtle.ConstantTimeCompare([]byte(client.Secret), []byte(secret)) != 1 {
+		return OIDCClient{}, newOAuthError(401, "invalid_client", "unknown client or wrong client secret")
+	}
+	return client, nil
+}
+
+func verifyCodeChallenge(req authorizationRequest, verifier string) bool {
+	if req.CodeChallenge == "" {
+		return true
+	}
+	if req.CodeChallengeMethod == "S256" {
+		sum := sha256.Sum256([]byte(verifier))
+		verifier = base64.RawURLEncoding.EncodeToString(sum[:])
+	}
+	return subtle.ConstantTimeCompare([]byte(req.CodeChallenge), []byte(verifier)) == 1
+}
+
+// exchangeCode redeems an authorization code. Codes can only be used once.
+func (p *OIDCProvider) exchangeCode(client OIDCClient, code string, redirectURI string, verifier string) (OIDCTokens, error) {
+	p.mu.Lock()
+	issued, ok := p.codes[code]
+	delete(p.codes, code)
+	p.mu.Unlock()
+
+	switch {
+	case !ok || p.now().After(issued.expiresAt):
+		return OIDCTokens{}, newOAuthError(400, "invalid_grant", "authorization code is invalid, expired or already used")
+	case issued.request.ClientID != client.ID:
+		return OIDCTokens{}, newOAuthError(400, "invalid_grant", "authorization code was issued to another client")
+	case issued.request.RedirectURI != redirectURI:
+		return OIDCTokens{}, newOAuthError(400, "invalid_grant", "redirect_uri does not match the authorization request")
+	case !verifyCodeChallenge(issued.request, verifier):
+		return OIDCTokens{}, newOAuthError(400, "invalid_grant", "code_verifier does not match the code challenge")
+	}
+	return p.issue(client.ID, &issued.user, issued.request.Scope, issued.request.Nonce), nil
+}
+
+func writeOAuthError(c *gin.Context, err error) {
+	oauthErr, ok := err.(*oauthError)
+	if !ok {
+		oauthErr = newOAuthError(500, "server_error", err.Error())
+	}
+	if oauthErr.status == 401 {
+		c.Header("WWW-Authenticate", `Basic realm="mockapi"`)
+	}
+	c.JSON(oauthErr.status, oauthErr)
+}
+
+func readAuthorizationRequest(get func(string) string) authorizationRequest {
+	return authorizationRequest{
+		ClientID:            get("client_id"),
+		RedirectURI:         get("redirect_uri"),
+		Scope:               get("scope"),
+		State:               get("state"),
+		Nonce:               get("nonce"),
+		CodeChallenge:       get("code_challenge"),
+		CodeChallengeMethod: get("code_challenge_method"),
+	}
+}
+
+// SetupOIDCRoute registers the discovery document, JWKS, authorize, token and
+// userinfo endpoints under GetOIDCPath.
+func (p *OIDCProvider) SetupOIDCRoute(r gin.IRouter) {
+	oidc := r.Group(GetOIDCPath())
+
+	oidc.GET("/.well-known/openid-configuration", func(c *gin.Context) {
+		c.JSON(200, p.discovery())
+	})
+	oidc.GET("/jwks.json", func(c *gin.Context) {
+		c.JSON(200, p.jwks())
+	})
+	oidc.GET("/authorize", func(c *gin.Context) {
+		req := readAuthorizationRequest(c.Query)
+		if err := p.checkAuthorizationRequest(req, c.Query("response_type")); err != nil {
+			writeOAuthError(c, err)
+			return
+		}
+		if user, ok := p.users[c.Query("login_hint")]; ok {
+			p.redirectWithCode(c, req, user)
+			return
+		}
+		p.renderLogin(c, req, "")
+	})
+	oidc.POST("/authorize", func(c *gin.Context) {
+		req := readAuthorizationRequest(c.PostForm)
+		if err := p.checkAuthorizationRequest(req, c.PostForm("response_type")); err != nil {
+			writeOAuthError(c, err)
+			return
+		}
+		user, ok := p.users[c.PostForm("username")]
+		if !ok || subtle.ConstantTimeCompare([]byte(user.Password), []byte(c.PostForm("password"))) != 1 {
+			p.renderLogin(c, req, "Invalid username or password")
+			return
+		}
+		p.redirectWithCode(c, req, user)
+	})
+	oidc.POST("/token", func(c *gin.Context) {
+		c.Header("Cache-Control", "no-store")
+		client, err := p.authenticateClient(c)
+		if err != nil {
+			writeOAuthError(c, err)
+			return
+		}
+
+		switch c.PostForm("grant_type") {
+		case "authorization_code":
+			tokens, err := p.exchangeCode(client, c.PostForm("code"), c.PostForm("redirect_uri"), c.PostForm("code_verifier"))
+			if err != nil {
+				writeOAuthError(c, err)
+				return
+			}
+			c.JSON(200, tokens)
+		case "client_credentials":
+			if client.Secret == "" {
+				writeOAuthError(c, newOAuthError(400, "unauthorized_client", "public clients cannot use client credentials"))
+				return
+			}
+			c.JSON(200, p.issue(client.ID, nil, c.PostForm("scope"), ""))
+		default:
+			writeOAuthError(c, newOAuthError(400, "unsupported_grant_type", "grant_type should be authorization_code or client_credentials"))
+		}
+	})
+	oidc.GET("/userinfo", func(c *gin.Context) {
+		scheme, token, _ := strings.Cut(c.GetHeader("Authorization"), " ")
+		if !strings.EqualFold(scheme, "Bearer") || token == "" {
+			abortWithAuthError(c, NewMissingTokenError())
+			return
+		}
+		claims, err := p.verify(token)
+		if err != nil {
+			abortWithAuthError(c, err)
+			return
+		}
+		scope, _ := claims["scope"].(string)
+		user, ok := p.users[fmt.Sprint(claims["sub"])]
+		if !ok || !slices.Contains(strings.Fields(scope), "openid") {
+			abortWithAuthError(c, NewInvalidTokenError())
+			return
+		}
+		c.JSON(200, userClaims(user, strings.Fields(scope)))
+	})
+}
@@ -0,0 +1,292 @@
+package ginrouter
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var testOIDCKey = sync.OnceValue(func() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	return key
+})
+
+func setupOIDCRouter(t *testing.T, config OIDCConfig) (*gin.Engine, *OIDCProvider, *time.Time) {
+	config.Issuer = "http://mock.local/mockapi/oidc"
+	config.PrivateKey = testOIDCKey()
+	provider, err := NewOIDCProvider(config)
+	if err != nil {
+		t.Fatalf("NewOIDCProvider failed: %v", err)
+	}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	provider.now = func() time.Time { return now }
+
+	r := setupTestRouter()
+	provider.SetupOIDCRoute(r)
+	return r, provider, &now
+}
+
+func postForm(r *gin.Engine, path string, form url.Values, basicUser string, basicPassword string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if basicUser != "" {
+		req.SetBasicAuth(basicUser, basicPassword)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func getJSON(r *gin.Engine, path string, token string, v any) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	json.Unmarshal(w.Body.Bytes(), v)
+	return w
+}
+
+// authorize runs the authorization request with a login_hint and returns the
+// code from the redirect.
+func authorize(t *testing.T, r *gin.Engine, params url.Values) string {
+	req, _ := http.NewRequest("GET", GetOIDCPath()+"/authorize?"+params.Encode(), nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusFound {
+		t.Fatalf("Expected status code %d, got %d %s", http.StatusFound, w.Code, w.Body.String())
+	}
+	location, _ := url.Parse(w.Header().Get("Location"))
+	if location.Query().Get("state") != params.Get("state") {
+		t.Errorf("Expected state %s, got %s", params.Get("state"), location.Query().Get("state"))
+	}
+	return location.Query().Get("code")
+}
+
+func decodeJWTClaims(t *testing.T, token string) map[string]any {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("Expected a JWT, got %s", token)
+	}
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	var claims map[string]any
+	json.Unmarshal(payload, &claims)
+	return claims
+}
+
+func TestNewOIDCProvider_InvalidConfig(t *testing.T) {
+	for _, config := range []OIDCConfig{
+		{TokenTTLSeconds: -1},
+		{Clients: []OIDCClient{{Secret: "secret"}}},
+		{Clients: []OIDCClient{{ID: "a"}, {ID: "a"}}},
+		{Users: []AuthUser{{Username: "a"}, {Username: "a"}}},
+	} {
+		config.PrivateKey = testOIDCKey()
+		if _, err := NewOIDCProvider(config); err == nil {
+			t.Errorf("Expected error for config %+v", config)
+		}
+	}
+}
+
+func TestOIDC_DiscoveryAndJWKS(t *testing.T) {
+	r, provider, _ := setupOIDCRouter(t, OIDCConfig{})
+
+	var discovery map[string]any
+	getJSON(r, GetOIDCPath()+"/.well-known/openid-configuration", "", &discovery)
+	if discovery["issuer"] != "http://mock.local/mockapi/oidc" || discovery["token_endpoint"] != "http://mock.local/mockapi/oidc/token" {
+		t.Errorf("Unexpected discovery document %v", discovery)
+	}
+
+	var jwks struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	getJSON(r, GetOIDCPath()+"/jwks.json", "", &jwks)
+	if len(jwks.Keys) != 1 || jwks.Keys[0]["kid"] != oidcKeyID {
+		t.Fatalf("Unexpected JWKS %v", jwks)
+	}
+
+	// Tokens verify against the published key
+	n, _ := base64.RawURLEncoding.DecodeString(jwks.Keys[0]["n"])
+	e, _ := base64.RawURLEncoding.DecodeString(jwks.Keys[0]["e"])
+	pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	token := provider.issue("mockapi", nil, "", "").AccessToken
+	parts := strings.Split(token, ".")
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("Expected token to verify against the JWKS: %v", err)
+	}
+}
+
+func TestOIDC_AuthorizationCodeWithPKCE(t *testing.T) {
+	r, _, _ := setupOIDCRouter(t, OIDCConfig{})
+
+	verifier := "a-very-long-random-code-verifier-for-the-test-1234567890"
+	sum := sha256.Sum256([]byte(verifier))
+	code := authorize(t, r, url.Values{
+		"response_type":         {"code"},
+		"client_id":             {"mockapi-public"},
+		"redirect_uri":          {"http://localhost:3000/callback"},
+		"scope":                 {"openid profile email"},
+		"state":                 {"xyz"},
+		"nonce":                 {"n-0S6"},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(sum[:])},
+		"code_challenge_method": {"S256"},
+		"login_hint":            {"reader"},
+	})
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {"mockapi-public"},
+		"code":          {code},
+		"redirect_uri":  {"http://localhost:3000/callback"},
+		"code_verifier": {verifier},
+	}
+	w := postForm(r, GetOIDCPath()+"/token", form, "", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var tokens OIDCTokens
+	json.Unmarshal(w.Body.Bytes(), &tokens)
+
+	id := decodeJWTClaims(t, tokens.IDToken)
+	if id["sub"] != "reader" || id["aud"] != "mockapi-public" || id["nonce"] != "n-0S6" || id["email"] != "reader@example.com" {
+		t.Errorf("Unexpected ID token claims %v", id)
+	}
+
+	var userinfo map[string]any
+	getJSON(r, GetOIDCPath()+"/userinfo", tokens.AccessToken, &userinfo)
+	if userinfo["sub"] != "reader" || userinfo["name"] != "Rey Reader" {
+		t.Errorf("Unexpected userinfo %v", userinfo)
+	}
+
+	// Codes are single use
+	w = postForm(r, GetOIDCPath()+"/token", form, "", "")
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "invalid_grant") {
+		t.Errorf("Expected invalid_grant for a reused code, got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestOIDC_AuthorizationCodeErrors(t *testing.T) {
+	r, _, _ := setupOIDCRouter(t, OIDCConfig{
+		Clients: []OIDCClient{
+			{ID: "web", Secret: "s3cret", RedirectURIs: []string{"http://app.local/cb"}},
+			{ID: "spa"},
+		},
+	})
+
+	for name, params := range map[string]url.Values{
+		"public client without PKCE": {"response_type": {"code"}, "client_id": {"spa"}, "redirect_uri": {"http://app.local/cb"}},
+		"unregistered redirect URI":  {"response_type": {"code"}, "client_id": {"web"}, "redirect_uri": {"http://evil.local/cb"}},
+		"unknown client":             {"response_type": {"code"}, "client_id": {"nobody"}, "redirect_uri": {"http://app.local/cb"}},
+		"implicit flow":              {"response_type": {"token"}, "client_id": {"web"}, "redirect_uri": {"http://app.local/cb"}},
+	} {
+		req, _ := http.NewRequest("GET", GetOIDCPath()+"/authorize?"+params.Encode(), nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d for %s, got %d", http.StatusBadRequest, name, w.Code)
+		}
+	}
+
+	code := authorize(t, r, url.Values{
+		"response_type":  {"code"},
+		"client_id":      {"web"},
+		"redirect_uri":   {"http://app.local/cb"},
+		"code_challenge": {"plain-challenge"},
+		"login_hint":     {"admin"},
+	})
+	w := postForm(r, GetOIDCPath()+"/token", url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {"http://app.local/cb"},
+		"code_verifier": {"wrong"},
+	}, "web", "s3cret")
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "code_verifier") {
+		t.Errorf("Expected invalid_grant for a wrong verifier, got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestOIDC_LoginForm(t *testing.T) {
+	r, _, _ := setupOIDCRouter(t, OIDCConfig{})
+	params := url.Values{
+		"response_type": {"code"},
+		"client_id":     {"mockapi"},
+		"redirect_uri":  {"http://localhost:3000/callback"},
+		"scope":         {"openid"},
+	}
+
+	req, _ := http.NewRequest("GET", GetOIDCPath()+"/authorize?"+params.Encode(), nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "<option>admin</option>") {
+		t.Errorf("Expected login page listing the users, got %d %s", w.Code, w.Body.String())
+	}
+
+	params.Set("username", "admin")
+	params.Set("password", "wrong")
+	w = postForm(r, GetOIDCPath()+"/authorize", params, "", "")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "Invalid username or password") {
+		t.Errorf("Expected login page with an error, got %d", w.Code)
+	}
+
+	params.Set("password", "admin123")
+	w = postForm(r, GetOIDCPath()+"/authorize", params, "", "")
+	if w.Code != http.StatusFound || !strings.HasPrefix(w.Header().Get("Location"), "http://localhost:3000/callback?code=") {
+		t.Errorf("Expected redirect with a code, got %d %s", w.Code, w.Header().Get("Location"))
+	}
+}
+
+func TestOIDC_ClientCredentials(t *testing.T) {
+	r, provider, now := setupOIDCRouter(t, OIDCConfig{TokenTTLSeconds: 60})
+	form := url.Values{"grant_type": {"client_credentials"}, "scope": {"books:read"}}
+
+	w := postForm(r, GetOIDCPath()+"/token", form, "mockapi", "mockapi-secret")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var tokens OIDCTokens
+	json.Unmarshal(w.Body.Bytes(), &tokens)
+	if tokens.IDToken != "" || tokens.ExpiresIn != 60 || decodeJWTClaims(t, tokens.AccessToken)["sub"] != "mockapi" {
+		t.Errorf("Unexpected tokens %+v", tokens)
+	}
+
+	// Client tokens have no user
+	var userinfo map[string]any
+	if w := getJSON(r, GetOIDCPath()+"/userinfo", tokens.AccessToken, &userinfo); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status code %d for userinfo, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	w = postForm(r, GetOIDCPath()+"/token", form, "mockapi", "wrong")
+	if w.Code != http.StatusUnauthorized || !strings.Contains(w.Body.String(), "invalid_client") {
+		t.Errorf("Expected invalid_client, got %d %s", w.Code, w.Body.String())
+	}
+
+	form.Set("client_id", "mockapi-public")
+	w = postForm(r, GetOIDCPath()+"/token", form, "", "")
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "unauthorized_client") {
+		t.Errorf("Expected unauthorized_client, got %d %s", w.Code, w.Body.String())
+	}
+
+	*now = now.Add(time.Minute)
+	if _, err := provider.verify(tokens.AccessToken); err == nil {
+		t.Error("Expected token to have expired")
+	}
+}
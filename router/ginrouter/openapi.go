@@ -0,0 +1,658 @@
+package ginrouter
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/anggaaryas/go-mockapi"
+)
+
+type object = map[string]any
+
+var openAPIComponents = []any{
+	mockapi.Book{},
+	mockapi.BookPatch{},
+	mockapi.PaginatedBooks{},
+	mockapi.CursorBooks{},
+	mockapi.APIError{},
+	mockapi.Author{},
+	mockapi.Category{},
+	mockapi.Review{},
+}
+
+// schemaOf builds a JSON schema from a Go type using its json struct tags.
+// Named structs are referenced as components.
+func schemaOf(t reflect.Type, ref bool) object {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaOf(t.Elem(), ref)
+	case reflect.Slice:
+		return object{"type": "array", "items": schemaOf(t.Elem(), true)}
+	case reflect.String:
+		return object{"type": "string"}
+	case reflect.Bool:
+		return object{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return object{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return object{"type": "number"}
+	case reflect.Struct:
+		if ref {
+			return object{"$ref": "#/components/schemas/" + t.Name()}
+		}
+		properties := object{}
+		required := []string{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" || !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			properties[name] = schemaOf(field.Type, true)
+			if field.Type.Kind() != reflect.Pointer && !strings.Contains(opts, "omitempty") {
+				required = append(required, name)
+			}
+		}
+		return object{"type": "object", "properties": properties, "required": required}
+	}
+	return object{}
+}
+
+func schemaRef(name string) object {
+	return object{"$ref": "#/components/schemas/" + name}
+}
+
+func jsonContent(schema object) object {
+	return object{"application/json": object{"schema": schema}}
+}
+
+func jsonResponse(description string, schema object) object {
+	return object{"description": description, "content": jsonContent(schema)}
+}
+
+func errorResponse(description string) object {
+	return jsonResponse(description, schemaRef("APIError"))
+}
+
+// styledComponents names the components that the documents of a response
+// style use in place of the plain Book, PaginatedBooks, CursorBooks and
+// APIError. HAL errors are plain.
+var styledComponents = map[mockapi.ResponseStyle]map[string]string{
+	mockapi.ResponseStyleJSONAPI: {
+		"Book":           "JSONAPIBookDocument",
+		"PaginatedBooks": "JSONAPIBooksDocument",
+		"CursorBooks":    "JSONAPIBooksDocument",
+		"APIError":       "JSONAPIErrors",
+	},
+	mockapi.ResponseStyleHAL: {
+		"Book":           "HALBook",
+		"PaginatedBooks": "HALBooksPage",
+		"CursorBooks":    "HALBooksCursorPage",
+	},
+}
+
+// styledSchema references the components of style standing in for the plain
+// components names, with oneOf when there are several.
+func styledSchema(style mockapi.ResponseStyle, names ...string) object {
+	var refs []object
+	seen := map[string]bool{}
+	for _, name := range names {
+		if styled, ok := styledComponents[style][name]; ok {
+			name = styled
+		}
+		if !seen[name] {
+			seen[name] = true
+			refs = append(refs, schemaRef(name))
+		}
+	}
+	if len(refs) == 1 {
+		return refs[0]
+	}
+	return object{"oneOf": refs}
+}
+
+// styledResponse is jsonResponse for the routes that render their JSON in
+// the response style of the router, with the Content-Type of the style.
+func styledResponse(style mockapi.ResponseStyle, description string, names ...string) object {
+	contentType := "application/json"
+	if !style.IsPlain() {
+		contentType = style.ContentType()
+	}
+	return object{"description": description, "content": object{contentType: object{"schema": styledSchema(style, names...)}}}
+}
+
+func styledErrorResponse(style mockapi.ResponseStyle, description string) object {
+	return styledResponse(style, description, "APIError")
+}
+
+// negotiatedResponse is styledResponse for the routes that also answer in
+// XML, CSV and MessagePack, which are never styled.
+func negotiatedResponse(style mockapi.ResponseStyle, description string, names ...string) object {
+	response := styledResponse(style, description, names...)
+	schema := styledSchema(mockapi.ResponseStylePlain, names...)
+	content := response["content"].(object)
+	content["application/xml"] = object{"schema": schema}
+	content["text/csv"] = object{"schema": object{"type": "string"}}
+	content["application/msgpack"] = object{"schema": schema}
+	return response
+}
+
+// styleSchemas returns the components describing the documents of style.
+func styleSchemas(style mockapi.ResponseStyle) object {
+	stringSchema := object{"type": "string"}
+	integerSchema := object{"type": "integer"}
+	switch style {
+	case mockapi.ResponseStyleJSONAPI:
+		links := object{"type": "object", "additionalProperties": stringSchema}
+		relationship := object{
+			"type": "object",
+			"properties": object{
+				"data": object{
+					"type":       "object",
+					"properties": object{"type": stringSchema, "id": stringSchema},
+					"required":   []string{"type", "id"},
+				},
+				"links": links,
+			},
+		}
+		return object{
+			"JSONAPIBookResource": object{
+				"type": "object",
+				"properties": object{
+					"type": object{"type": "string", "const": "books"},
+					"id":   stringSchema,
+					"attributes": object{
+						"type": "object",
+						"properties": object{
+							"title":     stringSchema,
+							"author":    stringSchema,
+							"category":  stringSchema,
+							"desc":      stringSchema,
+							"cover_url": stringSchema,
+						},
+						"required": []string{"title", "author", "category", "desc", "cover_url"},
+					},
+					"relationships": object{
+						"type":       "object",
+						"properties": object{"author": relationship, "category": relationship, "reviews": relationship},
+					},
+					"links": links,
+				},
+				"required": []string{"type", "id", "attributes", "links"},
+			},
+			"JSONAPIIncluded": object{
+				"type": "object",
+				"properties": object{
+					"type": object{"type": "string", "enum": []string{"authors", "categories"}},
+					"id":   stringSchema,
+					"attributes": object{
+						"type":       "object",
+						"properties": object{"name": stringSchema},
+						"required":   []string{"name"},
+					},
+					"links": links,
+				},
+				"required": []string{"type", "id", "attributes", "links"},
+			},
+			"JSONAPIBookDocument": object{
+				"type": "object",
+				"properties": object{
+					"data":     schemaRef("JSONAPIBookResource"),
+					"included": arrayOf("JSONAPIIncluded"),
+					"links":    links,
+				},
+				"required": []string{"data", "included", "links"},
+			},
+			"JSONAPIBooksDocument": object{
+				"type": "object",
+				"properties": object{
+					"data":     arrayOf("JSONAPIBookResource"),
+					"included": arrayOf("JSONAPIIncluded"),
+					"meta": object{
+						"type": "object",
+						"properties": object{
+							"page":        integerSchema,
+							"page_size":   integerSchema,
+							"total_items": integerSchema,
+							"total_pages": integerSchema,
+						},
+						"required": []string{"page_size"},
+					},
+					"links": links,
+				},
+				"required": []string{"data", "included", "meta", "links"},
+			},
+			"JSONAPIErrors": object{
+				"type": "object",
+				"properties": object{
+					"errors": object{"type": "array", "items": object{
+						"type":       "object",
+						"properties": object{"status": stringSchema, "title": stringSchema, "detail": stringSchema},
+						"required":   []string{"status", "title", "detail"},
+					}},
+				},
+				"required": []string{"errors"},
+			},
+		}
+	case mockapi.ResponseStyleHAL:
+		links := object{"type": "object", "additionalProperties": object{
+			"type":       "object",
+			"properties": object{"href": stringSchema},
+			"required":   []string{"href"},
+		}}
+		embedded := object{
+			"type":       "object",
+			"properties": object{"books": arrayOf("HALBook")},
+			"required":   []string{"books"},
+		}
+		return object{
+			"HALNamed": object{
+				"type":       "object",
+				"properties": object{"id": integerSchema, "name": stringSchema, "_links": links},
+				"required":   []string{"id", "name", "_links"},
+			},
+			"HALBook": object{
+				"allOf": []object{schemaRef("Book"), {
+					"type": "object",
+					"properties": object{
+						"_links": links,
+						"_embedded": object{
+							"type":       "object",
+							"properties": object{"author": schemaRef("HALNamed"), "category": schemaRef("HALNamed")},
+						},
+					},
+					"required": []string{"_links", "_embedded"},
+				}},
+			},
+			"HALBooksPage": object{
+				"type": "object",
+				"properties": object{
+					"_links":      links,
+					"_embedded":   embedded,
+					"page":        integerSchema,
+					"page_size":   integerSchema,
+					"total_items": integerSchema,
+					"total_pages": integerSchema,
+				},
+				"required": []string{"_links", "_embedded", "page", "page_size", "total_items", "total_pages"},
+			},
+			"HALBooksCursorPage": object{
+				"type": "object",
+				"properties": object{
+					"_links":    links,
+					"_embedded": embedded,
+					"page_size": integerSchema,
+				},
+				"required": []string{"_links", "_embedded", "page_size"},
+			},
+		}
+	}
+	return object{}
+}
+
+func queryParam(name string, description string, schema object) object {
+	return object{"name": name, "in": "query", "required": false, "description": description, "schema": schema}
+}
+
+func pathParam(name string, description string, schema object) object {
+	return object{"name": name, "in": "path", "required": true, "description": description, "schema": schema}
+}
+
+var bookIDParam = pathParam("id", "Book ID", object{"type": "integer"})
+var formatParam = queryParam("format", "Response format, instead of the Accept header", object{"type": "string", "enum": formats, "default": FormatJSON})
+var authorIDParam = pathParam("id", "Author ID", object{"type": "integer"})
+var categoryIDParam = pathParam("id", "Category ID", object{"type": "integer"})
+
+// bookQueryParams are the offset pagination, search, sort and filter parameters.
+func bookQueryParams() []object {
+	return []object{
+		queryParam("page", "Page number in offset pagination mode", object{"type": "integer", "default": 1}),
+		queryParam("page_size", "Number of books per page", object{"type": "integer", "default": 10}),
+		queryParam("search", "Case-insensitive match on title or author", object{"type": "string"}),
+		queryParam("sort", "Field to sort by", object{"type": "string", "enum": mockapi.BookSortFields(), "default": "id"}),
+		queryParam("order", "Sort direction", object{"type": "string", "enum": []string{mockapi.OrderAsc, mockapi.OrderDesc}, "default": mockapi.OrderAsc}),
+		queryParam("category", "Exact category match; repeat or comma-separate to match any", object{"type": "array", "items": object{"type": "string"}}),
+		queryParam("author", "Exact author match; repeat or comma-separate to match any", object{"type": "array", "items": object{"type": "string"}}),
+	}
+}
+
+func bookListParams() []object {
+	return append(bookQueryParams(),
+		queryParam("pagination", "Pagination mode", object{"type": "string", "enum": []string{"offset", "cursor"}, "default": "offset"}),
+		queryParam("cursor", "Cursor token from next_cursor or prev_cursor; implies cursor pagination", object{"type": "string"}),
+	)
+}
+
+func arrayOf(name string) object {
+	return object{"type": "array", "items": schemaRef(name)}
+}
+
+// resourcePaths describes the routes registered by SetupResourceRoute.
+func resourcePaths(schema *mockapi.ResourceSchema) (string, object, string, object) {
+	component := schema.Type.Name()
+	idSchema := object{"type": "string"}
+	if field, _ := schema.Field("id"); field.Kind != reflect.String {
+		idSchema = object{"type": "integer"}
+	}
+	listPath := object{
+		"get": object{
+			"operationId": "list-" + schema.Name,
+			"summary":     "List " + schema.Name,
+			"parameters": []object{
+				queryParam("page", "Page number", object{"type": "integer", "default": 1}),
+				queryParam("page_size", "Number of items per page", object{"type": "integer", "default": 10}),
+				queryParam("search", "Case-insensitive match on "+strings.Join(schema.SearchFields, ", "), object{"type": "string"}),
+				queryParam("sort", "Field to sort by", object{"type": "string", "enum": schema.SortFields, "default": "id"}),
+				queryParam("order", "Sort direction", object{"type": "string", "enum": []string{mockapi.OrderAsc, mockapi.OrderDesc}, "default": mockapi.OrderAsc}),
+			},
+			"responses": object{
+				"200": jsonResponse("A page of "+schema.Name, object{
+					"type": "object",
+					"properties": object{
+						"data":        arrayOf(component),
+						"page":        object{"type": "integer"},
+						"page_size":   object{"type": "integer"},
+						"total_items": object{"type": "integer"},
+						"total_pages": object{"type": "integer"},
+					},
+				}),
+				"400": errorResponse("Invalid query parameters"),
+				"500": errorResponse("Internal error"),
+			},
+		},
+	}
+	itemPath := object{
+		"get": object{
+			"operationId": "get-" + schema.Name,
+			"summary":     "Get one of " + schema.Name + " by ID",
+			"parameters":  []object{pathParam("id", "ID", idSchema)},
+			"responses": object{
+				"200": jsonResponse("The item", schemaRef(component)),
+				"404": errorResponse("Item not found"),
+				"500": errorResponse("Internal error"),
+			},
+		},
+	}
+	return "/api/" + schema.Name, listPath, "/api/" + schema.Name + "/{id}", itemPath
+}
+
+// OpenAPISpec returns the OpenAPI 3.1 document describing the routes
+// registered by SetupMockApiRoute and by SetupResourceRoute for resources.
+func OpenAPISpec(resources ...mockapi.ResourceService) map[string]any {
+	return OpenAPISpecWithStyle(mockapi.ResponseStylePlain, resources...)
+}
+
+// OpenAPISpecWithStyle is OpenAPISpec for a router created with
+// CreateWithStyle, whose book routes answer with the Content-Type and
+// documents of style.
+func OpenAPISpecWithStyle(style mockapi.ResponseStyle, resources ...mockapi.ResourceService) map[string]any {
+	schemas := styleSchemas(style)
+	for _, component := range openAPIComponents {
+		t := reflect.TypeOf(component)
+		schemas[t.Name()] = schemaOf(t, false)
+	}
+
+	spec := object{
+		"openapi": "3.1.0",
+		"info": object{
+			"title":       "Go MockAPI",
+			"description": "Mock API for books data",
+			"version":     "1.0.0",
+		},
+		"servers": []object{{"url": mockapi.GetBaseURL()}},
+		"paths": object{
+			"/api/books": object{
+				"get": object{
+					"operationId": "listBooks",
+					"summary":     "List books",
+					"parameters":  append(bookListParams(), formatParam),
+					"responses": object{
+						"200": negotiatedResponse(style, "A page of books", "PaginatedBooks", "CursorBooks"),
+						"400": styledErrorResponse(style, "Invalid query parameters"),
+						"406": errorResponse("Unsupported response format"),
+						"500": styledErrorResponse(style, "Internal error"),
+					},
+				},
+				"post": object{
+					"operationId": "createBook",
+					"summary":     "Create a book",
+					"parameters":  []object{formatParam},
+					"requestBody": object{"required": true, "content": jsonContent(schemaRef("Book"))},
+					"responses": object{
+						"201": negotiatedResponse(style, "The created book", "Book"),
+						"400": styledErrorResponse(style, "Invalid body or missing required field"),
+						"406": errorResponse("Unsupported response format"),
+						"500": styledErrorResponse(style, "Internal error"),
+					},
+				},
+			},
+			"/api/books/{id}": object{
+				"get": object{
+					"operationId": "getBook",
+					"summary":     "Get a book by ID",
+					"parameters":  []object{bookIDParam, formatParam},
+					"responses": object{
+						"200": negotiatedResponse(style, "The book", "Book"),
+						"400": styledErrorResponse(style, "ID is not an integer"),
+						"404": styledErrorResponse(style, "Book not found"),
+						"406": errorResponse("Unsupported response format"),
+						"500": styledErrorResponse(style, "Internal error"),
+					},
+				},
+				"put": object{
+					"operationId": "updateBook",
+					"summary":     "Replace a book",
+					"parameters":  []object{bookIDParam, formatParam},
+					"requestBody": object{"required": true, "content": jsonContent(schemaRef("Book"))},
+					"responses": object{
+						"200": negotiatedResponse(style, "The updated book", "Book"),
+						"400": styledErrorResponse(style, "Invalid ID, body or missing required field"),
+						"404": styledErrorResponse(style, "Book not found"),
+						"406": errorResponse("Unsupported response format"),
+						"500": styledErrorResponse(style, "Internal error"),
+					},
+				},
+				"patch": object{
+					"operationId": "patchBook",
+					"summary":     "Update only the given fields of a book",
+					"parameters":  []object{bookIDParam, formatParam},
+					"requestBody": object{"required": true, "content": jsonContent(schemaRef("BookPatch"))},
+					"responses": object{
+						"200": negotiatedResponse(style, "The updated book", "Book"),
+						"400": styledErrorResponse(style, "Invalid ID, body or empty required field"),
+						"404": styledErrorResponse(style, "Book not found"),
+						"406": errorResponse("Unsupported response format"),
+						"500": styledErrorResponse(style, "Internal error"),
+					},
+				},
+				"delete": object{
+					"operationId": "deleteBook",
+					"summary":     "Delete a book",
+					"parameters":  []object{bookIDParam, formatParam},
+					"responses": object{
+						"204": object{"description": "The book was deleted"},
+						"400": styledErrorResponse(style, "ID is not an integer"),
+						"404": styledErrorResponse(style, "Book not found"),
+						"406": errorResponse("Unsupported response format"),
+						"500": styledErrorResponse(style, "Internal error"),
+					},
+				},
+			},
+			"/api/books/{id}/reviews": object{
+				"get": object{
+					"operationId": "listBookReviews",
+					"summary":     "List the reviews of a book",
+					"parameters":  []object{bookIDParam},
+					"responses": object{
+						"200": jsonResponse("The reviews", arrayOf("Review")),
+						"400": errorResponse("ID is not an integer"),
+						"404": errorResponse("Book not found"),
+						"500": errorResponse("Internal error"),
+					},
+				},
+				"post": object{
+					"operationId": "createBookReview",
+					"summary":     "Review a book",
+					"parameters":  []object{bookIDParam},
+					"requestBody": object{"required": true, "content": jsonContent(schemaRef("Review"))},
+					"responses": object{
+						"201": jsonResponse("The created review", schemaRef("Review")),
+						"400": errorResponse("Invalid ID, body, missing reviewer or rating outside 1 to 5"),
+						"404": errorResponse("Book not found"),
+						"500": errorResponse("Internal error"),
+					},
+				},
+			},
+			"/api/authors": object{
+				"get": object{
+					"operationId": "listAuthors",
+					"summary":     "List authors",
+					"responses": object{
+						"200": jsonResponse("The authors", arrayOf("Author")),
+						"500": errorResponse("Internal error"),
+					},
+				},
+			},
+			"/api/authors/{id}": object{
+				"get": object{
+					"operationId": "getAuthor",
+					"summary":     "Get an author by ID",
+					"parameters":  []object{authorIDParam},
+					"responses": object{
+						"200": jsonResponse("The author", schemaRef("Author")),
+						"400": errorResponse("ID is not an integer"),
+						"404": errorResponse("Author not found"),
+						"500": errorResponse("Internal error"),
+					},
+				},
+			},
+			"/api/authors/{id}/books": object{
+				"get": object{
+					"operationId": "listAuthorBooks",
+					"summary":     "List the books of an author",
+					"parameters":  append([]object{authorIDParam}, bookQueryParams()...),
+					"responses": object{
+						"200": styledResponse(style, "A page of books", "PaginatedBooks"),
+						"400": styledErrorResponse(style, "Invalid ID or query parameters"),
+						"404": styledErrorResponse(style, "Author not found"),
+						"500": styledErrorResponse(style, "Internal error"),
+					},
+				},
+			},
+			"/api/categories": object{
+				"get": object{
+					"operationId": "listCategories",
+					"summary":     "List categories",
+					"responses": object{
+						"200": jsonResponse("The categories", arrayOf("Category")),
+						"500": errorResponse("Internal error"),
+					},
+				},
+			},
+			"/api/categories/{id}": object{
+				"get": object{
+					"operationId": "getCategory",
+					"summary":     "Get a category by ID",
+					"parameters":  []object{categoryIDParam},
+					"responses": object{
+						"200": jsonResponse("The category", schemaRef("Category")),
+						"400": errorResponse("ID is not an integer"),
+						"404": errorResponse("Category not found"),
+						"500": errorResponse("Internal error"),
+					},
+				},
+			},
+			"/api/categories/{id}/books": object{
+				"get": object{
+					"operationId": "listCategoryBooks",
+					"summary":     "List the books of a category",
+					"parameters":  append([]object{categoryIDParam}, bookQueryParams()...),
+					"responses": object{
+						"200": styledResponse(style, "A page of books", "PaginatedBooks"),
+						"400": styledErrorResponse(style, "Invalid ID or query parameters"),
+						"404": styledErrorResponse(style, "Category not found"),
+						"500": styledErrorResponse(style, "Internal error"),
+					},
+				},
+			},
+			mockapi.GetMockapiStaticPath() + "/{filepath}": object{
+				"get": object{
+					"operationId": "getStaticFile",
+					"summary":     "Get a bundled static file such as a book cover",
+					"parameters":  []object{pathParam("filepath", "Path of the file, e.g. image/clean-code.jpg", object{"type": "string"})},
+					"responses": object{
+						"200": object{"description": "The file", "content": object{"image/jpeg": object{"schema": object{"type": "string", "contentMediaType": "image/jpeg"}}}},
+						"404": object{"description": "File not found"},
+					},
+				},
+			},
+			mockapi.GetMockapiImagePath() + "/{filename}": object{
+				"get": object{
+					"operationId": "getImage",
+					"summary":     "Get a bundled image resized, cropped or converted",
+					"parameters": []object{
+						pathParam("filename", "Name of the image, e.g. clean-code.jpg", object{"type": "string"}),
+						queryParam("w", "Width in pixels", object{"type": "integer", "minimum": 1, "maximum": mockapi.MaxImageDimension}),
+						queryParam("h", "Height in pixels. With w the image is cropped around its center", object{"type": "integer", "minimum": 1, "maximum": mockapi.MaxImageDimension}),
+						queryParam("fmt", "Output format, the format of the file by default", object{"type": "string", "enum": []string{mockapi.ImageFormatJPEG, mockapi.ImageFormatPNG, mockapi.ImageFormatWebP}}),
+					},
+					"responses": object{
+						"200": object{"description": "The image", "content": object{
+							"image/jpeg":    object{"schema": object{"type": "string", "contentMediaType": "image/jpeg"}},
+							"image/png":     object{"schema": object{"type": "string", "contentMediaType": "image/png"}},
+							"image/webp":    object{"schema": object{"type": "string", "contentMediaType": "image/webp"}},
+							"image/svg+xml": object{"schema": object{"type": "string", "contentMediaType": "image/svg+xml"}},
+						}},
+						"304": object{"description": "The image matches If-None-Match"},
+						"400": errorResponse("Invalid query parameters"),
+						"404": errorResponse("Image not found"),
+					},
+				},
+			},
+			mockapi.GetMockapiPlaceholderPath() + "/{id}": object{
+				"get": object{
+					"operationId": "getPlaceholderCover",
+					"summary":     "Get a generated cover showing the title and author of a book",
+					"parameters": []object{
+						bookIDParam,
+						queryParam("fmt", "Output format", object{"type": "string", "enum": []string{mockapi.ImageFormatPNG, mockapi.ImageFormatSVG}, "default": mockapi.ImageFormatPNG}),
+					},
+					"responses": object{
+						"200": object{"description": "The cover", "content": object{
+							"image/png":     object{"schema": object{"type": "string", "contentMediaType": "image/png"}},
+							"image/svg+xml": object{"schema": object{"type": "string", "contentMediaType": "image/svg+xml"}},
+						}},
+						"304": object{"description": "The cover matches If-None-Match"},
+						"400": errorResponse("Invalid ID or format"),
+						"404": errorResponse("Book not found"),
+					},
+				},
+			},
+			mockapi.GetMockapiPath() + "/openapi.json": object{
+				"get": object{
+					"operationId": "getOpenAPISpec",
+					"summary":     "Get this OpenAPI document",
+					"responses": object{
+						"200": jsonResponse("The OpenAPI document", object{"type": "object"}),
+					},
+				},
+			},
+		},
+		"components": object{
+			"schemas": schemas,
+		},
+	}
+
+	paths := spec["paths"].(object)
+	for _, resource := range resources {
+		schema := resource.Schema()
+		schemas[schema.Type.Name()] = schemaOf(schema.Type, false)
+		listPath, list, itemPath, item := resourcePaths(schema)
+		paths[listPath] = list
+		paths[itemPath] = item
+	}
+	return spec
+}
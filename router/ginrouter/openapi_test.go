@@ -0,0 +1,206 @@
+package ginrouter
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/anggaaryas/go-mockapi"
+)
+
+var ginParamPattern = regexp.MustCompile(`[:*](\w+)`)
+
+func TestOpenAPISpec_InSyncWithRoutes(t *testing.T) {
+	for _, style := range []mockapi.ResponseStyle{mockapi.ResponseStylePlain, mockapi.ResponseStyleJSONAPI, mockapi.ResponseStyleHAL} {
+		r := setupTestRouter()
+		router, err := CreateWithStyle(r, style)
+		if err != nil {
+			t.Fatalf("CreateWithStyle failed: %v", err)
+		}
+		router.SetupMockApiRoute(&mockService{})
+
+		paths := OpenAPISpecWithStyle(style)["paths"].(object)
+
+		registered := map[string]bool{}
+		for _, route := range r.Routes() {
+			if route.Method == http.MethodHead {
+				continue
+			}
+			path := ginParamPattern.ReplaceAllString(route.Path, "{$1}")
+			method := strings.ToLower(route.Method)
+			registered[method+" "+path] = true
+
+			operations, ok := paths[path].(object)
+			if !ok {
+				t.Errorf("Route %s %s is missing from the %s OpenAPI spec", route.Method, route.Path, style)
+				continue
+			}
+			if _, ok := operations[method]; !ok {
+				t.Errorf("Route %s %s is missing from the %s OpenAPI spec", route.Method, route.Path, style)
+			}
+		}
+
+		for path, operations := range paths {
+			for method := range operations.(object) {
+				if !registered[method+" "+path] {
+					t.Errorf("%s OpenAPI operation %s %s has no registered route", style, method, path)
+				}
+			}
+		}
+	}
+}
+
+func TestOpenAPISpec_Styles(t *testing.T) {
+	tests := []struct {
+		style       mockapi.ResponseStyle
+		contentType string
+		book        string
+		page        string
+		error       string
+	}{
+		{mockapi.ResponseStylePlain, "application/json", "Book", "PaginatedBooks", "APIError"},
+		{mockapi.ResponseStyleJSONAPI, "application/vnd.api+json", "JSONAPIBookDocument", "JSONAPIBooksDocument", "JSONAPIErrors"},
+		{mockapi.ResponseStyleHAL, "application/hal+json", "HALBook", "HALBooksPage", "APIError"},
+	}
+
+	for _, tt := range tests {
+		paths := OpenAPISpecWithStyle(tt.style)["paths"].(object)
+		responses := paths["/api/books/{id}"].(object)["get"].(object)["responses"].(object)
+
+		content := responses["200"].(object)["content"].(object)
+		if ref := content[tt.contentType].(object)["schema"].(object)["$ref"]; ref != "#/components/schemas/"+tt.book {
+			t.Errorf("Expected %s book as %s %s, got %v", tt.style, tt.contentType, tt.book, content)
+		}
+		if ref := content["application/xml"].(object)["schema"].(object)["$ref"]; ref != "#/components/schemas/Book" {
+			t.Errorf("Expected %s XML book to stay plain, got %v", tt.style, ref)
+		}
+		content = responses["404"].(object)["content"].(object)
+		if ref := content[tt.contentType].(object)["schema"].(object)["$ref"]; ref != "#/components/schemas/"+tt.error {
+			t.Errorf("Expected %s error as %s %s, got %v", tt.style, tt.contentType, tt.error, content)
+		}
+
+		responses = paths["/api/authors/{id}/books"].(object)["get"].(object)["responses"].(object)
+		content = responses["200"].(object)["content"].(object)
+		if ref := content[tt.contentType].(object)["schema"].(object)["$ref"]; ref != "#/components/schemas/"+tt.page {
+			t.Errorf("Expected %s page as %s %s, got %v", tt.style, tt.contentType, tt.page, content)
+		}
+	}
+}
+
+func TestOpenAPISpec_SchemasMatchModels(t *testing.T) {
+	schemas := OpenAPISpec()["components"].(object)["schemas"].(object)
+
+	for _, component := range openAPIComponents {
+		typ := reflect.TypeOf(component)
+		schema, ok := schemas[typ.Name()].(object)
+		if !ok {
+			t.Fatalf("Expected schema for %s", typ.Name())
+		}
+		properties := schema["properties"].(object)
+		if len(properties) != typ.NumField() {
+			t.Errorf("Expected %d properties for %s, got %d", typ.NumField(), typ.Name(), len(properties))
+		}
+	}
+
+	book := schemas["Book"].(object)["properties"].(object)
+	if book["cover_url"].(object)["type"] != "string" || book["id"].(object)["type"] != "integer" {
+		t.Errorf("Unexpected Book schema %v", book)
+	}
+	paginated := schemas["PaginatedBooks"].(object)["properties"].(object)
+	if paginated["data"].(object)["items"].(object)["$ref"] != "#/components/schemas/Book" {
+		t.Errorf("Expected PaginatedBooks.data to reference Book, got %v", paginated["data"])
+	}
+	cursor := schemas["CursorBooks"].(object)["required"].([]string)
+	for _, name := range cursor {
+		if name == "next_cursor" || name == "prev_cursor" {
+			t.Errorf("Expected %s to be optional", name)
+		}
+	}
+}
+
+func TestOpenAPISpec_ReferencesResolve(t *testing.T) {
+	for _, style := range []mockapi.ResponseStyle{mockapi.ResponseStylePlain, mockapi.ResponseStyleJSONAPI, mockapi.ResponseStyleHAL} {
+		spec := OpenAPISpecWithStyle(style)
+		data, err := json.Marshal(spec)
+		if err != nil {
+			t.Fatalf("Failed to marshal spec: %v", err)
+		}
+		schemas := spec["components"].(object)["schemas"].(object)
+
+		for _, match := range regexp.MustCompile(`"#/components/schemas/(\w+)"`).FindAllStringSubmatch(string(data), -1) {
+			if _, ok := schemas[match[1]]; !ok {
+				t.Errorf("Reference to unknown schema %s in the %s spec", match[1], style)
+			}
+		}
+	}
+}
+
+func TestOpenAPISpec_Served(t *testing.T) {
+	r := setupTestRouter()
+	Create(r).SetupMockApiRoute(&mockService{})
+
+	req, _ := http.NewRequest("GET", mockapi.GetMockapiPath()+"/openapi.json", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var spec map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("Failed to unmarshal spec: %v", err)
+	}
+	if spec["openapi"] != "3.1.0" {
+		t.Errorf("Expected OpenAPI version 3.1.0, got %v", spec["openapi"])
+	}
+}
+
+func TestOpenAPISpec_ServedWithStyle(t *testing.T) {
+	r := setupTestRouter()
+	router, _ := CreateWithStyle(r, mockapi.ResponseStyleHAL)
+	router.SetupMockApiRoute(&mockService{})
+
+	req, _ := http.NewRequest("GET", mockapi.GetMockapiPath()+"/openapi.json", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var spec map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("Failed to unmarshal spec: %v", err)
+	}
+	schemas := spec["components"].(map[string]any)["schemas"].(map[string]any)
+	if _, ok := schemas["HALBook"]; !ok {
+		t.Error("Expected the served spec to describe the HAL style")
+	}
+}
+
+func TestOpenAPISpec_Resources(t *testing.T) {
+	r := setupTestRouter()
+	router := Create(r)
+	router.SetupMockApiRoute(&mockService{})
+	router.(mockapi.ResourceRouter).SetupResourceRoute(setupTestResource(t, &mockResourceDataSource{}))
+
+	req, _ := http.NewRequest("GET", mockapi.GetMockapiPath()+"/openapi.json", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var spec map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("Failed to decode spec: %v", err)
+	}
+	paths := spec["paths"].(map[string]any)
+	for _, path := range []string{"/api/widgets", "/api/widgets/{id}"} {
+		if _, ok := paths[path]; !ok {
+			t.Errorf("Expected path %s in the OpenAPI spec", path)
+		}
+	}
+	schemas := spec["components"].(map[string]any)["schemas"].(map[string]any)
+	if _, ok := schemas["widget"]; !ok {
+		t.Errorf("Expected widget schema in the OpenAPI spec")
+	}
+}
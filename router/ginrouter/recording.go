@@ -0,0 +1,255 @@
+package ginrouter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/anggaaryas/go-mockapi"
+	"github.com/gin-gonic/gin"
+)
+
+// RecordedExchange is one request/response pair, stored as a single JSONL
+// line. Bodies that are not valid UTF-8 are base64 encoded and flagged by the
+// matching encoding field.
+type RecordedExchange struct {
+	Time                 time.Time   `json:"time"`
+	Method               string      `json:"method"`
+	Path                 string      `json:"path"`
+	Query                string      `json:"query"`
+	RequestHeaders       http.Header `json:"request_headers"`
+	RequestBody          string      `json:"request_body"`
+	RequestBodyEncoding  string      `json:"request_body_encoding,omitempty"`
+	Status               int         `json:"status"`
+	ResponseHeaders      http.Header `json:"response_headers"`
+	ResponseBody         string      `json:"response_body"`
+	ResponseBodyEncoding string      `json:"response_body_encoding,omitempty"`
+	LatencyMS            float64     `json:"latency_ms"`
+}
+
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+func decodeBody(body string, encoding string) ([]byte, error) {
+	if encoding == "base64" {
+		return base64.StdEncoding.DecodeString(body)
+	}
+	return []byte(body), nil
+}
+
+// requestSignature identifies a request by method, path, query, response
+// format and body. The query is normalised so parameter order does not
+// matter, and the format is the one negotiated from the format query
+// parameter or the Accept header, so the same route asked for as JSON and as
+// XML replays different responses.
+func requestSignature(method string, path string, rawQuery string, header http.Header, body []byte) string {
+	query, err := url.ParseQuery(rawQuery)
+	if err == nil {
+		rawQuery = query.Encode()
+	}
+	return fmt.Sprintf("%s %s?%s %s %s", method, path, rawQuery, requestFormat(query, header), body)
+}
+
+// requestFormat returns the response format negotiateFormat chooses for a
+// request with query and header, or "" when no format matches.
+func requestFormat(query url.Values, header http.Header) string {
+	if format := query.Get("format"); format != "" {
+		return strings.ToLower(format)
+	}
+	return acceptedFormat(&gin.Context{Request: &http.Request{Header: header}})
+}
+
+// Signature returns the signature replay uses to match incoming requests.
+func (e RecordedExchange) Signature() (string, error) {
+	body, err := decodeBody(e.RequestBody, e.RequestBodyEncoding)
+	if err != nil {
+		return "", err
+	}
+	return requestSignature(e.Method, e.Path, e.Query, e.RequestHeaders, body), nil
+}
+
+// Recorder appends every request/response pair passing through its
+// middleware to w as JSONL.
+type Recorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	now func() time.Time
+}
+
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{
+		enc: json.NewEncoder(w),
+		now: time.Now,
+	}
+}
+
+// teeWriter passes the response through while keeping a copy of the body.
+type teeWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *teeWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *teeWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Middleware returns the Gin middleware that records each exchange. Install
+// it with r.Use before calling SetupMockApiRoute.
+func (rec *Recorder) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := rec.now()
+
+		var requestBody []byte
+		if c.Request.Body != nil {
+			requestBody, _ = io.ReadAll(c.Request.Body)
+			c.Request.Body = io.NopCloser(bytes.NewReader(requestBody))
+		}
+
+		w := &teeWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		exchange := RecordedExchange{
+			Time:            start.UTC(),
+			Method:          c.Request.Method,
+			Path:            c.Request.URL.Path,
+			Query:           c.Request.URL.RawQuery,
+			RequestHeaders:  c.Request.Header.Clone(),
+			Status:          c.Writer.Status(),
+			ResponseHeaders: c.Writer.Header().Clone(),
+			LatencyMS:       float64(rec.now().Sub(start).Microseconds()) / 1000,
+		}
+		exchange.RequestBody, exchange.RequestBodyEncoding = encodeBody(requestBody)
+		exchange.ResponseBody, exchange.ResponseBodyEncoding = encodeBody(w.body.Bytes())
+
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+		if err := rec.enc.Encode(exchange); err != nil {
+			c.Error(err)
+		}
+	}
+}
+
+// Replayer serves recorded responses verbatim for requests whose signature
+// matches a recorded exchange. When the same request was recorded several
+// times, the responses are served in recorded order and the last one repeats.
+type Replayer struct {
+	mu        sync.Mutex
+	exchanges map[string][]RecordedExchange
+	served    map[string]int
+	// Fallthrough passes unmatched requests on to the real handlers instead
+	// of answering them with 404.
+	Fallthrough bool
+}
+
+// LoadRecording reads a JSONL recording written by Recorder.
+func LoadRecording(r io.Reader) (*Replayer, error) {
+	rp := &Replayer{
+		exchanges: map[string][]RecordedExchange{},
+		served:    map[string]int{},
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var exchange RecordedExchange
+		if err := json.Unmarshal(scanner.Bytes(), &exchange); err != nil {
+			return nil, fmt.Errorf("recording line %d: %w", line, err)
+		}
+		signature, err := exchange.Signature()
+		if err != nil {
+			return nil, fmt.Errorf("recording line %d: %w", line, err)
+		}
+		rp.exchanges[signature] = append(rp.exchanges[signature], exchange)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rp, nil
+}
+
+func (rp *Replayer) next(signature string) (RecordedExchange, bool) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	exchanges := rp.exchanges[signature]
+	if len(exchanges) == 0 {
+		return RecordedExchange{}, false
+	}
+	i := rp.served[signature]
+	if i >= len(exchanges) {
+		i = len(exchanges) - 1
+	}
+	rp.served[signature] = i + 1
+	return exchanges[i], true
+}
+
+// Reset starts serving every recorded sequence from the beginning again.
+func (rp *Replayer) Reset() {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	rp.served = map[string]int{}
+}
+
+// Middleware returns the Gin middleware that answers requests from the
+// recording. Install it with r.Use before calling SetupMockApiRoute.
+func (rp *Replayer) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var requestBody []byte
+		if c.Request.Body != nil {
+			requestBody, _ = io.ReadAll(c.Request.Body)
+			c.Request.Body = io.NopCloser(bytes.NewReader(requestBody))
+		}
+
+		signature := requestSignature(c.Request.Method, c.Request.URL.Path, c.Request.URL.RawQuery, c.Request.Header, requestBody)
+		exchange, ok := rp.next(signature)
+		if !ok {
+			if rp.Fallthrough {
+				c.Next()
+				return
+			}
+			c.Abort()
+			writeCustomError(c, &mockapi.NotFoundError{
+				Message: fmt.Sprintf("not found: no recorded response for %s %s", c.Request.Method, c.Request.URL.RequestURI()),
+			})
+			return
+		}
+
+		body, err := decodeBody(exchange.ResponseBody, exchange.ResponseBodyEncoding)
+		if err != nil {
+			c.AbortWithError(500, err)
+			return
+		}
+		c.Abort()
+		for key, values := range exchange.ResponseHeaders {
+			c.Writer.Header()[key] = append([]string(nil), values...)
+		}
+		c.Writer.WriteHeader(exchange.Status)
+		c.Writer.Write(body)
+	}
+}
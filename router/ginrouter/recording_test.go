@@ -0,0 +1,215 @@
+package ginrouter
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anggaaryas/go-mockapi"
+	"github.com/gin-gonic/gin"
+)
+
+func setupRecordingRouter(out *bytes.Buffer, service mockapi.Service) *gin.Engine {
+	r := setupTestRouter()
+	r.Use(NewRecorder(out).Middleware())
+	Create(r).SetupMockApiRoute(service)
+	return r
+}
+
+func TestRecorder_WritesExchanges(t *testing.T) {
+	var out bytes.Buffer
+	r := setupRecordingRouter(&out, &mockService{
+		createBookFunc: func(book mockapi.Book) (mockapi.Book, error) {
+			book.ID = 51
+			return book, nil
+		},
+	})
+
+	req, _ := http.NewRequest("POST", "/api/books?x=1", strings.NewReader(`{"title":"New","author":"Someone"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d", http.StatusCreated, w.Code)
+	}
+
+	var exchange RecordedExchange
+	if err := json.Unmarshal(out.Bytes(), &exchange); err != nil {
+		t.Fatalf("Failed to unmarshal recorded exchange: %v", err)
+	}
+	if exchange.Method != "POST" || exchange.Path != "/api/books" || exchange.Query != "x=1" {
+		t.Errorf("Expected POST /api/books?x=1, got %s %s?%s", exchange.Method, exchange.Path, exchange.Query)
+	}
+	if exchange.RequestHeaders.Get("Content-Type") != "application/json" {
+		t.Errorf("Expected request Content-Type to be recorded, got %v", exchange.RequestHeaders)
+	}
+	if exchange.RequestBody != `{"title":"New","author":"Someone"}` {
+		t.Errorf("Expected request body to be recorded, got %q", exchange.RequestBody)
+	}
+	if exchange.Status != http.StatusCreated {
+		t.Errorf("Expected recorded status %d, got %d", http.StatusCreated, exchange.Status)
+	}
+	if exchange.ResponseBody != w.Body.String() {
+		t.Errorf("Expected response body %q, got %q", w.Body.String(), exchange.ResponseBody)
+	}
+}
+
+func TestRecorder_BinaryBody(t *testing.T) {
+	var out bytes.Buffer
+	r := setupTestRouter()
+	r.Use(NewRecorder(&out).Middleware())
+	r.GET("/bin", func(c *gin.Context) {
+		c.Data(200, "application/octet-stream", []byte{0xff, 0xd8, 0x00})
+	})
+
+	req, _ := http.NewRequest("GET", "/bin", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	var exchange RecordedExchange
+	if err := json.Unmarshal(out.Bytes(), &exchange); err != nil {
+		t.Fatalf("Failed to unmarshal recorded exchange: %v", err)
+	}
+	if exchange.ResponseBodyEncoding != "base64" {
+		t.Errorf("Expected base64 encoding, got %q", exchange.ResponseBodyEncoding)
+	}
+	body, _ := decodeBody(exchange.ResponseBody, exchange.ResponseBodyEncoding)
+	if !bytes.Equal(body, []byte{0xff, 0xd8, 0x00}) {
+		t.Errorf("Expected body to round trip, got %v", body)
+	}
+}
+
+func TestReplayer_ServesRecordedResponses(t *testing.T) {
+	var out bytes.Buffer
+	count := 0
+	r := setupRecordingRouter(&out, &mockService{
+		getBooksFunc: func(query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+			count++
+			return mockapi.PaginatedBooks{TotalItems: int64(count)}, nil
+		},
+	})
+
+	var recorded []string
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest("GET", "/api/books?page=1&search=go", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		recorded = append(recorded, w.Body.String())
+	}
+
+	replayer, err := LoadRecording(&out)
+	if err != nil {
+		t.Fatalf("LoadRecording failed: %v", err)
+	}
+	replay := setupTestRouter()
+	replay.Use(replayer.Middleware())
+	Create(replay).SetupMockApiRoute(&mockService{
+		getBooksFunc: func(query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+			t.Error("Expected replay not to reach the service")
+			return mockapi.PaginatedBooks{}, nil
+		},
+	})
+
+	// Query parameter order does not matter, and repeated requests are
+	// answered in recorded order with the last response repeating.
+	for _, want := range []string{recorded[0], recorded[1], recorded[1]} {
+		req, _ := http.NewRequest("GET", "/api/books?search=go&page=1", nil)
+		w := httptest.NewRecorder()
+		replay.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+		}
+		if w.Body.String() != want {
+			t.Errorf("Expected body %q, got %q", want, w.Body.String())
+		}
+	}
+
+	replayer.Reset()
+	req, _ := http.NewRequest("GET", "/api/books?page=1&search=go", nil)
+	w := httptest.NewRecorder()
+	replay.ServeHTTP(w, req)
+	if w.Body.String() != recorded[0] {
+		t.Errorf("Expected first body after reset, got %q", w.Body.String())
+	}
+}
+
+func TestReplayer_MatchesFormat(t *testing.T) {
+	var out bytes.Buffer
+	r := setupRecordingRouter(&out, &mockService{
+		getBookByIDFunc: func(id string) (mockapi.Book, error) {
+			return mockapi.Book{ID: 1, Title: "Clean Code"}, nil
+		},
+	})
+
+	recorded := map[string]string{}
+	for _, accept := range []string{"application/json", "application/xml"} {
+		req, _ := http.NewRequest("GET", "/api/books/1", nil)
+		req.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		recorded[accept] = w.Body.String()
+	}
+
+	replayer, err := LoadRecording(&out)
+	if err != nil {
+		t.Fatalf("LoadRecording failed: %v", err)
+	}
+	replay := setupTestRouter()
+	replay.Use(replayer.Middleware())
+
+	for _, test := range []struct {
+		accept string
+		want   string
+	}{
+		{"application/xml", recorded["application/xml"]},
+		{"", recorded["application/json"]},
+		{"text/html,application/xml", recorded["application/json"]},
+	} {
+		req, _ := http.NewRequest("GET", "/api/books/1", nil)
+		if test.accept != "" {
+			req.Header.Set("Accept", test.accept)
+		}
+		w := httptest.NewRecorder()
+		replay.ServeHTTP(w, req)
+
+		if w.Body.String() != test.want {
+			t.Errorf("Expected body %q for Accept %q, got %q", test.want, test.accept, w.Body.String())
+		}
+	}
+}
+
+func TestReplayer_Unmatched(t *testing.T) {
+	replayer, err := LoadRecording(strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("LoadRecording failed: %v", err)
+	}
+	r := setupTestRouter()
+	r.Use(replayer.Middleware())
+	Create(r).SetupMockApiRoute(&mockService{})
+
+	req, _ := http.NewRequest("GET", "/api/books/1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	replayer.Fallthrough = true
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d with fallthrough, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestLoadRecording_InvalidLine(t *testing.T) {
+	if _, err := LoadRecording(strings.NewReader("{\"method\":\"GET\"}\nnot json\n")); err == nil {
+		t.Error("Expected error for invalid recording line")
+	}
+}
@@ -0,0 +1,49 @@
+package ginrouter
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/anggaaryas/go-mockapi"
+	"github.com/gin-gonic/gin"
+)
+
+var routeParamPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// SetupMockRoute serves a route loaded from a definition file. Conflicts
+// with routes that are already registered are returned as errors. Inside a
+// session the templates read the session's data.
+func (cfg *config) SetupMockRoute(route *mockapi.MockRoute) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("route %s %s: %v", route.Method, route.Path, r)
+		}
+	}()
+
+	path := routeParamPattern.ReplaceAllString(route.Path, ":$1")
+	cfg.r.Handle(route.Method, path, func(c *gin.Context) {
+		params := map[string]string{}
+		for _, param := range c.Params {
+			params[param.Key] = param.Value
+		}
+		response, err := route.RespondFor(serviceFor(c, nil), params, c.Request.URL.Query())
+		if err != nil {
+			apiErr := cfg.getErrorResponse(err)
+			c.JSON(apiErr.StatusCode, apiErr)
+			return
+		}
+		if response.Delay > 0 {
+			sleepContext(c, response.Delay)
+			if c.Request.Context().Err() != nil {
+				// The client went away during the delay.
+				return
+			}
+		}
+		for name, value := range response.Headers {
+			c.Header(name, value)
+		}
+		c.Status(response.Status)
+		c.Writer.Write(response.Body)
+	})
+	return nil
+}
@@ -0,0 +1,165 @@
+package ginrouter
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/anggaaryas/go-mockapi"
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultScenario is the scenario that restores the data source's seed and
+// the initial chaos configuration.
+const DefaultScenario = "default"
+
+// Scenario is a named state of the mock API. Activating it resets the data
+// source to Books, where nil means the data source's seed and an empty list
+// an empty library, and replaces the chaos configuration with Chaos, e.g. to
+// make every route return 503 for a "server down" scenario. Without Chaos
+// the configuration given to NewChaos is restored.
+type Scenario struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Books       []mockapi.Book `json:"books,omitempty"`
+	Chaos       *ChaosConfig   `json:"chaos,omitempty"`
+}
+
+// ScenarioStatus lists the registered scenarios and the active one.
+type ScenarioStatus struct {
+	Active    string     `json:"active"`
+	Scenarios []Scenario `json:"scenarios"`
+}
+
+// Scenarios switches the mock API between named scenarios at runtime.
+type Scenarios struct {
+	mu        sync.Mutex
+	ds        mockapi.ResettableDataSource
+	chaos     *Chaos
+	baseline  ChaosConfig
+	scenarios []Scenario
+	active    string
+}
+
+// NewScenarios registers scenarios for ds, which must implement
+// mockapi.ResettableDataSource. chaos may be nil when no scenario changes
+// the chaos configuration. A default scenario is added unless one is given.
+func NewScenarios(ds mockapi.DataSource, chaos *Chaos, scenarios ...Scenario) (*Scenarios, error) {
+	resettable, ok := ds.(mockapi.ResettableDataSource)
+	if !ok {
+		return nil, fmt.Errorf("data source %T cannot be reset", ds)
+	}
+
+	s := &Scenarios{
+		ds:     resettable,
+		chaos:  chaos,
+		active: DefaultScenario,
+	}
+	if chaos != nil {
+		s.baseline = chaos.Config()
+	}
+	if !containsScenario(scenarios, DefaultScenario) {
+		s.scenarios = append(s.scenarios, Scenario{Name: DefaultScenario, Description: "Seed dataset"})
+	}
+	for _, scenario := range scenarios {
+		if err := s.add(scenario); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func containsScenario(scenarios []Scenario, name string) bool {
+	for _, scenario := range scenarios {
+		if scenario.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Scenarios) add(scenario Scenario) error {
+	if scenario.Name == "" {
+		return NewInvalidScenarioError("scenarios need a name")
+	}
+	if containsScenario(s.scenarios, scenario.Name) {
+		return NewInvalidScenarioError(fmt.Sprintf("scenario %s is defined more than once", scenario.Name))
+	}
+	if scenario.Books != nil {
+		if err := mockapi.ValidateBooks(scenario.Books); err != nil {
+			return NewInvalidScenarioError(fmt.Sprintf("%s: %v", scenario.Name, err))
+		}
+	}
+	if scenario.Chaos != nil {
+		if s.chaos == nil {
+			return NewInvalidScenarioError(fmt.Sprintf("%s: a Chaos is needed to change the chaos configuration", scenario.Name))
+		}
+		if _, err := NewChaos(*scenario.Chaos); err != nil {
+			return NewInvalidScenarioError(fmt.Sprintf("%s: %v", scenario.Name, err))
+		}
+	}
+	s.scenarios = append(s.scenarios, scenario)
+	return nil
+}
+
+// Activate resets the data source and chaos configuration to the named
+// scenario. Activating the active scenario again resets it too.
+func (s *Scenarios) Activate(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var scenario *Scenario
+	for i := range s.scenarios {
+		if s.scenarios[i].Name == name {
+			scenario = &s.scenarios[i]
+		}
+	}
+	if scenario == nil {
+		return &mockapi.NotFoundError{Message: fmt.Sprintf("not found: scenario %s does not exist", name)}
+	}
+
+	if err := s.ds.ResetData(scenario.Books); err != nil {
+		return err
+	}
+	if s.chaos != nil {
+		config := s.baseline
+		if scenario.Chaos != nil {
+			config = *scenario.Chaos
+		}
+		if err := s.chaos.SetConfig(config); err != nil {
+			return err
+		}
+	}
+	s.active = name
+	return nil
+}
+
+// Status returns the registered scenarios and the active one. Books are left
+// out to keep the response small.
+func (s *Scenarios) Status() ScenarioStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := ScenarioStatus{Active: s.active}
+	for _, scenario := range s.scenarios {
+		scenario.Books = nil
+		status.Scenarios = append(status.Scenarios, scenario)
+	}
+	return status
+}
+
+// SetupAdminRoute registers GET /mockapi/scenarios and
+// POST /mockapi/scenarios/:name, which activates a scenario.
+func (s *Scenarios) SetupAdminRoute(r gin.IRouter) {
+	path := mockapi.GetMockapiPath() + "/scenarios"
+
+	r.GET(path, func(c *gin.Context) {
+		c.JSON(200, s.Status())
+	})
+	r.POST(path+"/:name", func(c *gin.Context) {
+		if err := s.Activate(c.Param("name")); err != nil {
+			writeAdminError(c, err)
+			return
+		}
+		c.JSON(200, s.Status())
+	})
+}
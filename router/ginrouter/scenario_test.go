@@ -0,0 +1,140 @@
+package ginrouter
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anggaaryas/go-mockapi"
+	"github.com/gin-gonic/gin"
+)
+
+type mockResettableDataSource struct {
+	mockapi.DataSource
+	resetDataFunc func(seed []mockapi.Book) error
+}
+
+func (m *mockResettableDataSource) ResetData(seed []mockapi.Book) error {
+	if m.resetDataFunc != nil {
+		return m.resetDataFunc(seed)
+	}
+	return nil
+}
+
+func setupScenarioRouter(t *testing.T, ds mockapi.DataSource, scenarios ...Scenario) (*gin.Engine, *Scenarios) {
+	chaos, _ := NewChaos(ChaosConfig{})
+	s, err := NewScenarios(ds, chaos, scenarios...)
+	if err != nil {
+		t.Fatalf("NewScenarios failed: %v", err)
+	}
+
+	r := setupTestRouter()
+	r.Use(chaos.Middleware())
+	s.SetupAdminRoute(r)
+	Create(r).SetupMockApiRoute(&mockService{})
+	return r, s
+}
+
+func serve(r *gin.Engine, method string, path string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestNewScenarios_Invalid(t *testing.T) {
+	ds := &mockResettableDataSource{}
+
+	if _, err := NewScenarios(&mockDataSourceOnly{}, nil); err == nil {
+		t.Error("Expected error for a data source that cannot be reset")
+	}
+	for _, scenario := range []Scenario{
+		{Description: "no name"},
+		{Name: "bad-books", Books: []mockapi.Book{{ID: 1}}},
+		{Name: "needs-chaos", Chaos: &ChaosConfig{Enabled: true}},
+	} {
+		if _, err := NewScenarios(ds, nil, scenario); err == nil {
+			t.Errorf("Expected error for scenario %+v", scenario)
+		}
+	}
+	if _, err := NewScenarios(ds, nil, Scenario{Name: "a"}, Scenario{Name: "a"}); err == nil {
+		t.Error("Expected error for duplicate scenarios")
+	}
+}
+
+type mockDataSourceOnly struct {
+	mockapi.DataSource
+}
+
+func TestScenarios_Activate(t *testing.T) {
+	var seeds [][]mockapi.Book
+	ds := &mockResettableDataSource{
+		resetDataFunc: func(seed []mockapi.Book) error {
+			seeds = append(seeds, seed)
+			return nil
+		},
+	}
+	r, _ := setupScenarioRouter(t, ds,
+		Scenario{Name: "empty-library", Books: []mockapi.Book{}},
+		Scenario{Name: "one-book", Books: []mockapi.Book{{ID: 1, Title: "Only Book", Author: "Solo Author"}}},
+		Scenario{Name: "server-down", Chaos: &ChaosConfig{Enabled: true, Default: &ChaosRule{ErrorRate: 1, ErrorCodes: []int{503}}}},
+	)
+
+	w := serve(r, "POST", mockapi.GetMockapiPath()+"/scenarios/one-book")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	var status ScenarioStatus
+	json.Unmarshal(w.Body.Bytes(), &status)
+	if status.Active != "one-book" || len(status.Scenarios) != 4 || status.Scenarios[0].Name != DefaultScenario {
+		t.Errorf("Unexpected status %+v", status)
+	}
+	if len(seeds) != 1 || len(seeds[0]) != 1 || seeds[0][0].Title != "Only Book" {
+		t.Errorf("Expected data source to be reset to one book, got %v", seeds)
+	}
+
+	serve(r, "POST", mockapi.GetMockapiPath()+"/scenarios/empty-library")
+	if len(seeds) != 2 || seeds[1] == nil || len(seeds[1]) != 0 {
+		t.Errorf("Expected data source to be reset to an empty library, got %v", seeds)
+	}
+
+	serve(r, "POST", mockapi.GetMockapiPath()+"/scenarios/server-down")
+	if w := serve(r, "GET", "/api/books/1"); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status code %d while the server is down, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+
+	// Admin routes are not affected by chaos, so the default can be restored
+	if w := serve(r, "POST", mockapi.GetMockapiPath()+"/scenarios/default"); w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if w := serve(r, "GET", "/api/books/1"); w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d after restoring the default, got %d", http.StatusOK, w.Code)
+	}
+	if seeds[len(seeds)-1] != nil {
+		t.Errorf("Expected default scenario to restore the built-in dataset, got %v", seeds[len(seeds)-1])
+	}
+}
+
+func TestScenarios_ActivateErrors(t *testing.T) {
+	ds := &mockResettableDataSource{
+		resetDataFunc: func(seed []mockapi.Book) error {
+			return errors.New("database is locked")
+		},
+	}
+	r, s := setupScenarioRouter(t, ds)
+
+	if w := serve(r, "POST", mockapi.GetMockapiPath()+"/scenarios/missing"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, w.Code)
+	}
+	if w := serve(r, "POST", mockapi.GetMockapiPath()+"/scenarios/default"); w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status code %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+
+	var status ScenarioStatus
+	json.Unmarshal(serve(r, "GET", mockapi.GetMockapiPath()+"/scenarios").Body.Bytes(), &status)
+	if status.Active != DefaultScenario || s.Status().Active != DefaultScenario {
+		t.Errorf("Expected default to stay active, got %+v", status)
+	}
+}
@@ -0,0 +1,362 @@
+package ginrouter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anggaaryas/go-mockapi"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultSessionHeader is the header that selects a session.
+	DefaultSessionHeader = "X-Mock-Session"
+	// DefaultSessionPathPrefix is the path prefix that selects a session, as
+	// in /sessions/{id}/api/books.
+	DefaultSessionPathPrefix = "/sessions"
+
+	defaultSessionIdleTimeout = 30 * time.Minute
+
+	sessionServiceKey = "mockapi.session.service"
+)
+
+var sessionIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// SessionConfig configures isolated sessions. NewDataSource returns the empty
+// data source a session copies the shared data into on its first write, e.g.
+// memory.Create, and must return a mockapi.SnapshotDataSource. Sessions idle
+// for IdleTimeoutSeconds, 30 minutes by default, are dropped.
+type SessionConfig struct {
+	Header             string                    `json:"header,omitempty"`
+	PathPrefix         string                    `json:"path_prefix,omitempty"`
+	IdleTimeoutSeconds int                       `json:"idle_timeout_seconds"`
+	NewDataSource      func() mockapi.DataSource `json:"-"`
+}
+
+// SessionInfo describes a session in the admin endpoint.
+type SessionInfo struct {
+	ID       string    `json:"id"`
+	Copied   bool      `json:"copied"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+type session struct {
+	ds       *sessionDataSource
+	service  mockapi.Service
+	lastSeen time.Time
+}
+
+// Sessions gives every client that sends a session ID its own view of the
+// data. A session reads the shared data source until its first write, which
+// copies the data so that writes never reach the shared data or other
+// sessions. Requests without a session ID use the shared data source.
+type Sessions struct {
+	mu          sync.Mutex
+	config      SessionConfig
+	base        mockapi.SnapshotDataSource
+	idleTimeout time.Duration
+	sessions    map[string]*session
+	now         func() time.Time
+}
+
+// NewSessions returns Sessions sharing ds, which must implement
+// mockapi.SnapshotDataSource.
+func NewSessions(ds mockapi.DataSource, config SessionConfig) (*Sessions, error) {
+	base, ok := ds.(mockapi.SnapshotDataSource)
+	if !ok {
+		return nil, fmt.Errorf("data source %T cannot be copied into sessions", ds)
+	}
+	if config.NewDataSource == nil {
+		return nil, NewInvalidSessionConfigError("NewDataSource is required")
+	}
+	if _, ok := config.NewDataSource().(mockapi.SnapshotDataSource); !ok {
+		return nil, NewInvalidSessionConfigError("NewDataSource should return a mockapi.SnapshotDataSource")
+	}
+	if config.IdleTimeoutSeconds < 0 {
+		return nil, NewInvalidSessionConfigError("idle timeout should not be negative")
+	}
+	if config.PathPrefix != "" && (!strings.HasPrefix(config.PathPrefix, "/") || strings.HasSuffix(config.PathPrefix, "/")) {
+		return nil, NewInvalidSessionConfigError("path prefix should start and not end with /")
+	}
+	if config.Header == "" {
+		config.Header = DefaultSessionHeader
+	}
+	if config.PathPrefix == "" {
+		config.PathPrefix = DefaultSessionPathPrefix
+	}
+
+	s := &Sessions{
+		config:      config,
+		base:        base,
+		idleTimeout: time.Duration(config.IdleTimeoutSeconds) * time.Second,
+		sessions:    map[string]*session{},
+		now:         time.Now,
+	}
+	if s.idleTimeout == 0 {
+		s.idleTimeout = defaultSessionIdleTimeout
+	}
+	return s, nil
+}
+
+// service returns the service of session id, creating the session when it is
+// new. Idle sessions are dropped first.
+func (s *Sessions) service(id string) mockapi.Service {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for key, session := range s.sessions {
+		if now.Sub(session.lastSeen) >= s.idleTimeout {
+			delete(s.sessions, key)
+		}
+	}
+
+	sess, ok := s.sessions[id]
+	if !ok {
+		ds := &sessionDataSource{base: s.base, newDataSource: s.config.NewDataSource}
+		sess = &session{ds: ds, service: mockapi.NewService(ds)}
+		s.sessions[id] = sess
+	}
+	sess.lastSeen = now
+	return sess.service
+}
+
+// Sessions returns the active sessions ordered by ID.
+func (s *Sessions) Sessions() []SessionInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	infos := []SessionInfo{}
+	for id, session := range s.sessions {
+		if s.now().Sub(session.lastSeen) >= s.idleTimeout {
+			continue
+		}
+		infos = append(infos, SessionInfo{ID: id, Copied: session.ds.copied(), LastSeen: session.lastSeen})
+	}
+	slices.SortFunc(infos, func(a, b SessionInfo) int { return strings.Compare(a.ID, b.ID) })
+	return infos
+}
+
+// Delete drops session id and its data.
+func (s *Sessions) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.sessions[id]; !ok {
+		return &mockapi.NotFoundError{Message: fmt.Sprintf("not found: session %s does not exist", id)}
+	}
+	delete(s.sessions, id)
+	return nil
+}
+
+// serviceFor returns the service of the caller's session, as set by the
+// sessions middleware, or service outside a session.
+func serviceFor(c *gin.Context, service mockapi.Service) mockapi.Service {
+	if value, ok := c.Get(sessionServiceKey); ok {
+		return value.(mockapi.Service)
+	}
+	return service
+}
+
+// SharedOnly is a Gin handler for routes that only serve the shared data,
+// such as custom resources or GraphQL. It rejects requests in a session with
+// 400 instead of letting them silently read outside the session.
+func SharedOnly(c *gin.Context) {
+	if _, ok := c.Get(sessionServiceKey); ok {
+		c.Abort()
+		writeCustomError(c, NewSessionNotSupportedError(c.FullPath()))
+	}
+}
+
+// Middleware returns the Gin middleware that selects the session named by the
+// session header. Install it with r.Use before calling SetupMockApiRoute so it
+// wraps the mock routes.
+func (s *Sessions) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(s.config.Header)
+		if id == "" {
+			c.Next()
+			return
+		}
+		if !sessionIDPattern.MatchString(id) {
+			c.Abort()
+			writeCustomError(c, NewInvalidSessionIDError(id))
+			return
+		}
+		c.Set(sessionServiceKey, s.service(id))
+		c.Next()
+	}
+}
+
+// Handler wraps the Gin engine so /sessions/{id}/... is served as ... with the
+// session header set to id, for clients that cannot send headers.
+func (s *Sessions) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest, ok := strings.CutPrefix(r.URL.Path, s.config.PathPrefix+"/")
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		id, path, _ := strings.Cut(rest, "/")
+		if id == "" {
+			err := NewMissingSessionIDError(s.config.PathPrefix)
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(err.StatusCode())
+			json.NewEncoder(w).Encode(mockapi.APIError{StatusCode: err.StatusCode(), Message: err.Error()})
+			return
+		}
+
+		r2 := r.Clone(r.Context())
+		r2.URL.Path = "/" + path
+		r2.URL.RawPath = ""
+		r2.Header.Set(s.config.Header, id)
+		next.ServeHTTP(w, r2)
+	})
+}
+
+// SetupAdminRoute registers GET /mockapi/sessions and
+// DELETE /mockapi/sessions/:id.
+func (s *Sessions) SetupAdminRoute(r gin.IRouter) {
+	path := mockapi.GetMockapiPath() + "/sessions"
+
+	r.GET(path, func(c *gin.Context) {
+		c.JSON(200, s.Sessions())
+	})
+	r.DELETE(path+"/:id", func(c *gin.Context) {
+		if err := s.Delete(c.Param("id")); err != nil {
+			writeAdminError(c, err)
+			return
+		}
+		c.Status(204)
+	})
+}
+
+// sessionDataSource reads from the shared data source until the first write,
+// which copies the shared data into a data source of its own.
+type sessionDataSource struct {
+	mu            sync.RWMutex
+	base          mockapi.SnapshotDataSource
+	newDataSource func() mockapi.DataSource
+	own           mockapi.DataSource
+}
+
+func (ds *sessionDataSource) copied() bool {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+	return ds.own != nil
+}
+
+func (ds *sessionDataSource) reader() mockapi.DataSource {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+	if ds.own != nil {
+		return ds.own
+	}
+	return ds.base
+}
+
+func (ds *sessionDataSource) writer() (mockapi.DataSource, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	if ds.own != nil {
+		return ds.own, nil
+	}
+
+	data, err := ds.base.ExportData()
+	if err != nil {
+		return nil, err
+	}
+	own := ds.newDataSource().(mockapi.SnapshotDataSource)
+	if err := own.ImportData(data); err != nil {
+		return nil, err
+	}
+	ds.own = own
+	return own, nil
+}
+
+// PopulateData does nothing, as the shared data source is populated already.
+func (ds *sessionDataSource) PopulateData() error {
+	return nil
+}
+
+func (ds *sessionDataSource) GetBookByID(id string) (mockapi.Book, error) {
+	return ds.reader().GetBookByID(id)
+}
+
+func (ds *sessionDataSource) GetBooks(query mockapi.BookQuery) ([]mockapi.Book, error) {
+	return ds.reader().GetBooks(query)
+}
+
+func (ds *sessionDataSource) GetBooksCount(query mockapi.BookQuery) (int64, error) {
+	return ds.reader().GetBooksCount(query)
+}
+
+func (ds *sessionDataSource) GetBooksByCursor(query mockapi.BookQuery, cursor *mockapi.BookCursor) ([]mockapi.Book, error) {
+	return ds.reader().GetBooksByCursor(query, cursor)
+}
+
+func (ds *sessionDataSource) CreateBook(book mockapi.Book) (mockapi.Book, error) {
+	own, err := ds.writer()
+	if err != nil {
+		return mockapi.Book{}, err
+	}
+	return own.CreateBook(book)
+}
+
+func (ds *sessionDataSource) UpdateBook(id string, book mockapi.Book) (mockapi.Book, error) {
+	own, err := ds.writer()
+	if err != nil {
+		return mockapi.Book{}, err
+	}
+	return own.UpdateBook(id, book)
+}
+
+func (ds *sessionDataSource) PatchBook(id string, patch mockapi.BookPatch) (mockapi.Book, error) {
+	own, err := ds.writer()
+	if err != nil {
+		return mockapi.Book{}, err
+	}
+	return own.PatchBook(id, patch)
+}
+
+func (ds *sessionDataSource) DeleteBook(id string) error {
+	own, err := ds.writer()
+	if err != nil {
+		return err
+	}
+	return own.DeleteBook(id)
+}
+
+func (ds *sessionDataSource) GetAuthors() ([]mockapi.Author, error) {
+	return ds.reader().GetAuthors()
+}
+
+func (ds *sessionDataSource) GetAuthorByID(id string) (mockapi.Author, error) {
+	return ds.reader().GetAuthorByID(id)
+}
+
+func (ds *sessionDataSource) GetCategories() ([]mockapi.Category, error) {
+	return ds.reader().GetCategories()
+}
+
+func (ds *sessionDataSource) GetCategoryByID(id string) (mockapi.Category, error) {
+	return ds.reader().GetCategoryByID(id)
+}
+
+func (ds *sessionDataSource) GetReviews(bookID string) ([]mockapi.Review, error) {
+	return ds.reader().GetReviews(bookID)
+}
+
+func (ds *sessionDataSource) CreateReview(review mockapi.Review) (mockapi.Review, error) {
+	own, err := ds.writer()
+	if err != nil {
+		return mockapi.Review{}, err
+	}
+	return own.CreateReview(review)
+}
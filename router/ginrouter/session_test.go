@@ -0,0 +1,242 @@
+package ginrouter
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/anggaaryas/go-mockapi"
+)
+
+// bookStore is a minimal mockapi.SnapshotDataSource holding only books.
+type bookStore struct {
+	mockSnapshotDataSource
+	mu    sync.Mutex
+	books []mockapi.Book
+}
+
+func (s *bookStore) GetBookByID(id string) (mockapi.Book, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, book := range s.books {
+		if strconv.Itoa(book.ID) == id {
+			return book, nil
+		}
+	}
+	return mockapi.Book{}, mockapi.NewBookNotFoundError(id)
+}
+
+func (s *bookStore) CreateBook(book mockapi.Book) (mockapi.Book, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	book.ID = len(s.books) + 1
+	s.books = append(s.books, book)
+	return book, nil
+}
+
+func (s *bookStore) DeleteBook(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.books = slices.DeleteFunc(s.books, func(book mockapi.Book) bool { return strconv.Itoa(book.ID) == id })
+	return nil
+}
+
+func (s *bookStore) ExportData() (mockapi.Dataset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return mockapi.Dataset{Books: slices.Clone(s.books)}, nil
+}
+
+func (s *bookStore) ImportData(data mockapi.Dataset) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.books = slices.Clone(data.Books)
+	return nil
+}
+
+func newBookStore() mockapi.DataSource {
+	return &bookStore{}
+}
+
+func setupSessionRouter(t *testing.T, base *bookStore) (http.Handler, *Sessions) {
+	sessions, err := NewSessions(base, SessionConfig{NewDataSource: newBookStore})
+	if err != nil {
+		t.Fatalf("NewSessions failed: %v", err)
+	}
+
+	r := setupTestRouter()
+	r.Use(sessions.Middleware())
+	sessions.SetupAdminRoute(r)
+	Create(r).SetupMockApiRoute(mockapi.NewService(base))
+	return sessions.Handler(r), sessions
+}
+
+func sessionRequest(handler http.Handler, method string, path string, session string, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	if session != "" {
+		req.Header.Set(DefaultSessionHeader, session)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestNewSessions_Invalid(t *testing.T) {
+	if _, err := NewSessions(&mockResettableDataSource{}, SessionConfig{NewDataSource: newBookStore}); err == nil {
+		t.Error("Expected error for a data source that cannot be copied")
+	}
+
+	configs := []SessionConfig{
+		{},
+		{NewDataSource: func() mockapi.DataSource { return &mockResettableDataSource{} }},
+		{NewDataSource: newBookStore, IdleTimeoutSeconds: -1},
+		{NewDataSource: newBookStore, PathPrefix: "sessions"},
+		{NewDataSource: newBookStore, PathPrefix: "/sessions/"},
+	}
+	for _, config := range configs {
+		if _, err := NewSessions(&bookStore{}, config); err == nil {
+			t.Errorf("Expected error for config %+v", config)
+		}
+	}
+}
+
+func TestSessions_Isolation(t *testing.T) {
+	base := &bookStore{books: []mockapi.Book{{ID: 1, Title: "Shared", Author: "A"}}}
+	handler, sessions := setupSessionRouter(t, base)
+
+	// Reads fall through to the shared data until the session writes
+	if w := sessionRequest(handler, "GET", "/api/books/1", "alice", ""); w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if infos := sessions.Sessions(); len(infos) != 1 || infos[0].Copied {
+		t.Errorf("Expected an uncopied session, got %+v", infos)
+	}
+
+	if w := sessionRequest(handler, "DELETE", "/api/books/1", "alice", ""); w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status code %d, got %d", http.StatusNoContent, w.Code)
+	}
+	if w := sessionRequest(handler, "GET", "/api/books/1", "alice", ""); w.Code != http.StatusNotFound {
+		t.Errorf("Expected book 1 to be deleted for alice, got %d", w.Code)
+	}
+	if w := sessionRequest(handler, "GET", "/api/books/1", "bob", ""); w.Code != http.StatusOK {
+		t.Errorf("Expected book 1 to still exist for bob, got %d", w.Code)
+	}
+	if w := sessionRequest(handler, "GET", "/api/books/1", "", ""); w.Code != http.StatusOK {
+		t.Errorf("Expected book 1 to still exist in the shared data, got %d", w.Code)
+	}
+
+	w := sessionRequest(handler, "POST", "/sessions/carol/api/books", "", `{"title": "Carol's", "author": "C"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	if w := sessionRequest(handler, "GET", "/sessions/carol/api/books/2", "", ""); w.Code != http.StatusOK {
+		t.Errorf("Expected carol's book through the path prefix, got %d", w.Code)
+	}
+	if w := sessionRequest(handler, "GET", "/api/books/2", "", ""); w.Code != http.StatusNotFound {
+		t.Errorf("Expected carol's book to stay out of the shared data, got %d", w.Code)
+	}
+
+	var infos []SessionInfo
+	json.Unmarshal(sessionRequest(handler, "GET", mockapi.GetMockapiPath()+"/sessions", "", "").Body.Bytes(), &infos)
+	if len(infos) != 3 || infos[0].ID != "alice" || !infos[0].Copied || infos[1].Copied {
+		t.Errorf("Unexpected sessions %+v", infos)
+	}
+}
+
+func TestSessions_InvalidID(t *testing.T) {
+	handler, _ := setupSessionRouter(t, &bookStore{})
+
+	if w := sessionRequest(handler, "GET", "/api/books/1", "no spaces", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestSessions_MissingID(t *testing.T) {
+	handler, sessions := setupSessionRouter(t, &bookStore{books: []mockapi.Book{{ID: 1, Title: "Shared", Author: "A"}}})
+
+	w := sessionRequest(handler, "DELETE", "/sessions//api/books/1", "", "")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	if w := sessionRequest(handler, "GET", "/api/books/1", "", ""); w.Code != http.StatusOK {
+		t.Errorf("Expected book 1 to still exist in the shared data, got %d", w.Code)
+	}
+	if infos := sessions.Sessions(); len(infos) != 0 {
+		t.Errorf("Expected no sessions, got %+v", infos)
+	}
+}
+
+func TestSessions_MockRoute(t *testing.T) {
+	base := &bookStore{books: []mockapi.Book{{ID: 1, Title: "Shared", Author: "A"}}}
+	sessions, _ := NewSessions(base, SessionConfig{NewDataSource: newBookStore})
+	r := setupTestRouter()
+	r.Use(sessions.Middleware())
+	router := Create(r)
+	router.SetupMockApiRoute(mockapi.NewService(base))
+	setupTestMockRoutes(t, router, []mockapi.RouteDefinition{
+		{Method: "GET", Path: "/api/loans/{book_id}", Body: `{{ (book .Params.book_id).Title }}`},
+	}, mockapi.NewService(base))
+	handler := sessions.Handler(r)
+
+	sessionRequest(handler, "DELETE", "/api/books/1", "alice", "")
+	if w := sessionRequest(handler, "GET", "/api/loans/1", "alice", ""); w.Code != http.StatusNotFound {
+		t.Errorf("Expected the route to read alice's data, got %d %s", w.Code, w.Body.String())
+	}
+	if w := sessionRequest(handler, "GET", "/api/loans/1", "", ""); w.Code != http.StatusOK || w.Body.String() != "Shared" {
+		t.Errorf("Expected the route to read the shared data, got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestSessions_ResourceRoute(t *testing.T) {
+	sessions, _ := NewSessions(&bookStore{}, SessionConfig{NewDataSource: newBookStore})
+	r := setupTestRouter()
+	r.Use(sessions.Middleware())
+	Create(r).(mockapi.ResourceRouter).SetupResourceRoute(setupTestResource(t, &mockResourceDataSource{}))
+	handler := sessions.Handler(r)
+
+	if w := sessionRequest(handler, "GET", "/api/widgets", "", ""); w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if w := sessionRequest(handler, "GET", "/api/widgets", "alice", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d in a session, got %d", http.StatusBadRequest, w.Code)
+	}
+	if w := sessionRequest(handler, "GET", "/sessions/alice/api/widgets/1", "", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d in a session, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestSessions_Expiry(t *testing.T) {
+	base := &bookStore{books: []mockapi.Book{{ID: 1, Title: "Shared", Author: "A"}}}
+	handler, sessions := setupSessionRouter(t, base)
+	now := time.Now()
+	sessions.now = func() time.Time { return now }
+
+	sessionRequest(handler, "DELETE", "/api/books/1", "alice", "")
+	now = now.Add(defaultSessionIdleTimeout)
+
+	if infos := sessions.Sessions(); len(infos) != 0 {
+		t.Errorf("Expected idle session to expire, got %+v", infos)
+	}
+	if w := sessionRequest(handler, "GET", "/api/books/1", "alice", ""); w.Code != http.StatusOK {
+		t.Errorf("Expected an expired session to start over from the shared data, got %d", w.Code)
+	}
+}
+
+func TestSessions_Delete(t *testing.T) {
+	handler, _ := setupSessionRouter(t, &bookStore{})
+	sessionRequest(handler, "GET", "/api/books/1", "alice", "")
+
+	if w := sessionRequest(handler, "DELETE", mockapi.GetMockapiPath()+"/sessions/alice", "", ""); w.Code != http.StatusNoContent {
+		t.Errorf("Expected status code %d, got %d", http.StatusNoContent, w.Code)
+	}
+	if w := sessionRequest(handler, "DELETE", mockapi.GetMockapiPath()+"/sessions/alice", "", ""); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
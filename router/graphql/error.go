@@ -0,0 +1,45 @@
+package graphql
+
+import (
+	"errors"
+	"net/http"
+)
+
+type CustomError interface {
+	StatusCode() int
+	Error() string
+}
+
+// resolverError is an error of the Service reported with the HTTP status code
+// the REST routes would answer with, as the code extension of the GraphQL
+// error. Errors that are not a CustomError are hidden behind a generic
+// message, like the REST routers do.
+type resolverError struct {
+	err        error
+	message    string
+	statusCode int
+}
+
+func newResolverError(err error) *resolverError {
+	var customErr CustomError
+	if errors.As(err, &customErr) {
+		return &resolverError{err: err, message: customErr.Error(), statusCode: customErr.StatusCode()}
+	}
+	return &resolverError{
+		err:        err,
+		message:    "An error occurred while processing your request",
+		statusCode: http.StatusInternalServerError,
+	}
+}
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+func (e *resolverError) Unwrap() error {
+	return e.err
+}
+
+func (e *resolverError) Extensions() map[string]any {
+	return map[string]any{"code": e.statusCode}
+}
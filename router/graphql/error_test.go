@@ -0,0 +1,43 @@
+package graphql
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/anggaaryas/go-mockapi"
+)
+
+// unauthorizedError is a CustomError that is not defined by mockapi, like
+// the errors of the routers.
+type unauthorizedError struct{}
+
+func (unauthorizedError) StatusCode() int {
+	return http.StatusUnauthorized
+}
+
+func (unauthorizedError) Error() string {
+	return "unauthorized: missing token"
+}
+
+func TestNewResolverError(t *testing.T) {
+	tests := []struct {
+		err     error
+		code    int
+		message string
+	}{
+		{mockapi.NewInvalidPageSizeError(), http.StatusBadRequest, "validation error: page_size should be at least 1"},
+		{mockapi.NewBookAlreadyExistsError("1"), http.StatusConflict, mockapi.NewBookAlreadyExistsError("1").Error()},
+		{unauthorizedError{}, http.StatusUnauthorized, "unauthorized: missing token"},
+		{errors.New("sql: no such table: books"), http.StatusInternalServerError, "An error occurred while processing your request"},
+	}
+	for _, tt := range tests {
+		err := newResolverError(tt.err)
+		if err.Extensions()["code"] != tt.code || err.Error() != tt.message {
+			t.Errorf("Expected code %d and message %q for %v, got %v and %q", tt.code, tt.message, tt.err, err.Extensions()["code"], err.Error())
+		}
+		if !errors.Is(err, tt.err) {
+			t.Errorf("Expected %v to wrap %v", err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+module github.com/anggaaryas/go-mockapi/router/graphql
+
+go 1.25.1
+
+require (
+	github.com/anggaaryas/go-mockapi v0.1.3
+	github.com/graph-gophers/graphql-go v1.9.0
+)
@@ -0,0 +1,28 @@
+package graphql
+
+// graphiQLPage loads GraphiQL from a CDN and points it at the URL it is served
+// from.
+const graphiQLPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>Go MockAPI GraphiQL</title>
+	<link rel="stylesheet" href="https://unpkg.com/graphiql@3/graphiql.min.css">
+</head>
+<body style="margin: 0">
+	<div id="graphiql" style="height: 100vh"></div>
+	<script crossorigin src="https://unpkg.com/react@18/umd/react.production.min.js"></script>
+	<script crossorigin src="https://unpkg.com/react-dom@18/umd/react-dom.production.min.js"></script>
+	<script crossorigin src="https://unpkg.com/graphiql@3/graphiql.min.js"></script>
+	<script>
+		const fetcher = GraphiQL.createFetcher({ url: window.location.pathname });
+		ReactDOM.createRoot(document.getElementById("graphiql")).render(
+			React.createElement(GraphiQL, {
+				fetcher,
+				defaultQuery: "{\n  books(pageSize: 5) {\n    totalItems\n    data {\n      id\n      title\n      author\n    }\n  }\n}\n",
+			}),
+		);
+	</script>
+</body>
+</html>
+`
@@ -0,0 +1,85 @@
+// Package graphql serves the books of a mockapi.Service over GraphQL, next to
+// the REST routes of a router.
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/anggaaryas/go-mockapi"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+// Path is the path the handler is meant to be served at.
+const Path = "/graphql"
+
+type handler struct {
+	schema *graphqlgo.Schema
+}
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// NewHandler returns an http.Handler answering GraphQL queries with the books
+// of service. Queries are sent as a JSON body with POST, or as the query,
+// operationName and variables query parameters with GET. A GET from a browser
+// without a query gets the GraphiQL IDE.
+func NewHandler(service mockapi.Service) http.Handler {
+	return &handler{
+		schema: graphqlgo.MustParseSchema(Schema, &resolver{service: service}),
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		if query.Get("query") == "" && strings.Contains(r.Header.Get("Accept"), "text/html") {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(graphiQLPage))
+			return
+		}
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid variables: "+err.Error())
+				return
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid body: "+err.Error())
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed: use GET or POST")
+		return
+	}
+	if req.Query == "" {
+		writeError(w, http.StatusBadRequest, "query is required")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.schema.Exec(r.Context(), req.Query, req.OperationName, req.Variables))
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a request that could not be executed as a GraphQL
+// response with a single error.
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]any{
+		"errors": []map[string]string{{"message": message}},
+	})
+}
@@ -0,0 +1,302 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/anggaaryas/go-mockapi"
+)
+
+type mockService struct {
+	getBookByIDFunc      func(id string) (mockapi.Book, error)
+	getBooksFunc         func(query mockapi.BookQuery) (mockapi.PaginatedBooks, error)
+	getBooksByCursorFunc func(query mockapi.BookQuery, cursor string) (mockapi.CursorBooks, error)
+	createBookFunc       func(book mockapi.Book) (mockapi.Book, error)
+	updateBookFunc       func(id string, book mockapi.Book) (mockapi.Book, error)
+	patchBookFunc        func(id string, patch mockapi.BookPatch) (mockapi.Book, error)
+	deleteBookFunc       func(id string) error
+	getAuthorsFunc       func() ([]mockapi.Author, error)
+	getAuthorByIDFunc    func(id string) (mockapi.Author, error)
+	getAuthorBooksFunc   func(id string, query mockapi.BookQuery) (mockapi.PaginatedBooks, error)
+	getCategoriesFunc    func() ([]mockapi.Category, error)
+	getCategoryByIDFunc  func(id string) (mockapi.Category, error)
+	getCategoryBooksFunc func(id string, query mockapi.BookQuery) (mockapi.PaginatedBooks, error)
+	getBookReviewsFunc   func(bookID string) ([]mockapi.Review, error)
+	createReviewFunc     func(bookID string, review mockapi.Review) (mockapi.Review, error)
+}
+
+func (m *mockService) GetBookByID(id string) (mockapi.Book, error) {
+	if m.getBookByIDFunc != nil {
+		return m.getBookByIDFunc(id)
+	}
+	return mockapi.Book{}, nil
+}
+
+func (m *mockService) GetBooks(query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+	if m.getBooksFunc != nil {
+		return m.getBooksFunc(query)
+	}
+	return mockapi.PaginatedBooks{}, nil
+}
+
+func (m *mockService) GetBooksByCursor(query mockapi.BookQuery, cursor string) (mockapi.CursorBooks, error) {
+	if m.getBooksByCursorFunc != nil {
+		return m.getBooksByCursorFunc(query, cursor)
+	}
+	return mockapi.CursorBooks{}, nil
+}
+
+func (m *mockService) CreateBook(book mockapi.Book) (mockapi.Book, error) {
+	if m.createBookFunc != nil {
+		return m.createBookFunc(book)
+	}
+	return book, nil
+}
+
+func (m *mockService) UpdateBook(id string, book mockapi.Book) (mockapi.Book, error) {
+	if m.updateBookFunc != nil {
+		return m.updateBookFunc(id, book)
+	}
+	return book, nil
+}
+
+func (m *mockService) PatchBook(id string, patch mockapi.BookPatch) (mockapi.Book, error) {
+	if m.patchBookFunc != nil {
+		return m.patchBookFunc(id, patch)
+	}
+	return mockapi.Book{}, nil
+}
+
+func (m *mockService) DeleteBook(id string) error {
+	if m.deleteBookFunc != nil {
+		return m.deleteBookFunc(id)
+	}
+	return nil
+}
+
+func (m *mockService) GetAuthors() ([]mockapi.Author, error) {
+	if m.getAuthorsFunc != nil {
+		return m.getAuthorsFunc()
+	}
+	return []mockapi.Author{}, nil
+}
+
+func (m *mockService) GetAuthorByID(id string) (mockapi.Author, error) {
+	if m.getAuthorByIDFunc != nil {
+		return m.getAuthorByIDFunc(id)
+	}
+	return mockapi.Author{}, nil
+}
+
+func (m *mockService) GetAuthorBooks(id string, query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+	if m.getAuthorBooksFunc != nil {
+		return m.getAuthorBooksFunc(id, query)
+	}
+	return mockapi.PaginatedBooks{}, nil
+}
+
+func (m *mockService) GetCategories() ([]mockapi.Category, error) {
+	if m.getCategoriesFunc != nil {
+		return m.getCategoriesFunc()
+	}
+	return []mockapi.Category{}, nil
+}
+
+func (m *mockService) GetCategoryByID(id string) (mockapi.Category, error) {
+	if m.getCategoryByIDFunc != nil {
+		return m.getCategoryByIDFunc(id)
+	}
+	return mockapi.Category{}, nil
+}
+
+func (m *mockService) GetCategoryBooks(id string, query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+	if m.getCategoryBooksFunc != nil {
+		return m.getCategoryBooksFunc(id, query)
+	}
+	return mockapi.PaginatedBooks{}, nil
+}
+
+func (m *mockService) GetBookReviews(bookID string) ([]mockapi.Review, error) {
+	if m.getBookReviewsFunc != nil {
+		return m.getBookReviewsFunc(bookID)
+	}
+	return []mockapi.Review{}, nil
+}
+
+func (m *mockService) CreateReview(bookID string, review mockapi.Review) (mockapi.Review, error) {
+	if m.createReviewFunc != nil {
+		return m.createReviewFunc(bookID, review)
+	}
+	return review, nil
+}
+
+func newTestHandler() http.Handler {
+	return NewHandler(&mockService{
+		getBookByIDFunc: func(id string) (mockapi.Book, error) {
+			if id == "1" {
+				return mockapi.Book{ID: 1, Title: "The Go Programming Language", Author: "Alan Donovan", CoverURL: "http://test.com/go.jpg"}, nil
+			}
+			return mockapi.Book{}, mockapi.NewBookNotFoundError(id)
+		},
+		getBooksFunc: func(query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+			if err := query.Validate(); err != nil {
+				return mockapi.PaginatedBooks{}, err
+			}
+			if query.Search == "fail" {
+				return mockapi.PaginatedBooks{}, errors.New("database error")
+			}
+			return mockapi.PaginatedBooks{
+				Data:       []mockapi.Book{{ID: 1, Title: query.Search}, {ID: 2, Title: "Book 2"}},
+				Page:       query.Page,
+				PageSize:   query.PageSize,
+				TotalItems: 12,
+				TotalPages: 2,
+			}, nil
+		},
+	})
+}
+
+type response struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func postQuery(t *testing.T, handler http.Handler, query string, variables map[string]any) (*httptest.ResponseRecorder, response) {
+	t.Helper()
+	body, _ := json.Marshal(request{Query: query, Variables: variables})
+	req, _ := http.NewRequest("POST", Path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	var resp response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return w, resp
+}
+
+func TestBook(t *testing.T) {
+	handler := newTestHandler()
+
+	w, resp := postQuery(t, handler, `query($id: ID!) { book(id: $id) { id title author coverUrl } }`, map[string]any{"id": "1"})
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	var book struct {
+		ID       string `json:"id"`
+		Title    string `json:"title"`
+		Author   string `json:"author"`
+		CoverURL string `json:"coverUrl"`
+	}
+	json.Unmarshal(resp.Data["book"], &book)
+	if book.ID != "1" || book.Title != "The Go Programming Language" || book.CoverURL != "http://test.com/go.jpg" {
+		t.Errorf("Unexpected book %+v, errors %+v", book, resp.Errors)
+	}
+}
+
+func TestBook_NotFound(t *testing.T) {
+	_, resp := postQuery(t, newTestHandler(), `{ book(id: "9") { id } }`, nil)
+	if string(resp.Data["book"]) != "null" || len(resp.Errors) != 0 {
+		t.Errorf("Expected a null book without errors, got %s, %+v", resp.Data["book"], resp.Errors)
+	}
+}
+
+func TestBooks(t *testing.T) {
+	_, resp := postQuery(t, newTestHandler(), `{ books(page: 2, pageSize: 5, search: "go") { page pageSize totalItems totalPages data { id title } } }`, nil)
+	var page struct {
+		Data []struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		} `json:"data"`
+		Page       int `json:"page"`
+		PageSize   int `json:"pageSize"`
+		TotalItems int `json:"totalItems"`
+		TotalPages int `json:"totalPages"`
+	}
+	json.Unmarshal(resp.Data["books"], &page)
+	if page.Page != 2 || page.PageSize != 5 || page.TotalItems != 12 || page.TotalPages != 2 {
+		t.Errorf("Unexpected page %+v, errors %+v", page, resp.Errors)
+	}
+	if len(page.Data) != 2 || page.Data[0].Title != "go" {
+		t.Errorf("Expected the search to reach the service, got %+v", page.Data)
+	}
+}
+
+func TestBooks_Defaults(t *testing.T) {
+	_, resp := postQuery(t, newTestHandler(), `{ books { page pageSize } }`, nil)
+	if string(resp.Data["books"]) != `{"page":1,"pageSize":10}` {
+		t.Errorf("Expected page 1 of 10 books, got %s", resp.Data["books"])
+	}
+}
+
+func TestBooks_Errors(t *testing.T) {
+	handler := newTestHandler()
+
+	tests := []struct {
+		query string
+		code  float64
+	}{
+		{`{ books(pageSize: 0) { page } }`, http.StatusBadRequest},
+		{`{ books(page: 0) { page } }`, http.StatusBadRequest},
+		{`{ books(search: "fail") { page } }`, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		_, resp := postQuery(t, handler, tt.query, nil)
+		if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != tt.code {
+			t.Errorf("Expected an error with code %v for %s, got %+v", tt.code, tt.query, resp.Errors)
+		}
+	}
+}
+
+func TestHandler_Get(t *testing.T) {
+	handler := newTestHandler()
+
+	req, _ := http.NewRequest("GET", Path+"?query="+url.QueryEscape(`query($id: ID!) { book(id: $id) { title } }`)+"&variables="+url.QueryEscape(`{"id":"1"}`), nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if !strings.Contains(w.Body.String(), "The Go Programming Language") {
+		t.Errorf("Expected the book title, got %s", w.Body.String())
+	}
+
+	req, _ = http.NewRequest("GET", Path, nil)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "text/html") || !strings.Contains(w.Body.String(), "GraphiQL") {
+		t.Errorf("Expected the GraphiQL page, got %s", w.Header().Get("Content-Type"))
+	}
+}
+
+func TestHandler_BadRequest(t *testing.T) {
+	handler := newTestHandler()
+
+	tests := []struct {
+		method string
+		target string
+		body   string
+		code   int
+	}{
+		{"POST", Path, "{", http.StatusBadRequest},
+		{"POST", Path, "{}", http.StatusBadRequest},
+		{"GET", Path + "?query=%7Bbooks%7Bpage%7D%7D&variables=%7B", "", http.StatusBadRequest},
+		{"DELETE", Path, "", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != tt.code {
+			t.Errorf("Expected status code %d for %s %s, got %d", tt.code, tt.method, tt.target, w.Code)
+		}
+	}
+}
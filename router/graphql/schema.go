@@ -0,0 +1,133 @@
+package graphql
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/anggaaryas/go-mockapi"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+// Schema is the GraphQL schema served by the handler.
+const Schema = `
+schema {
+	query: Query
+}
+
+type Query {
+	"The book with the given ID, or null when there is none."
+	book(id: ID!): Book
+	"A page of books, optionally filtered by a case-insensitive search on title and author."
+	books(page: Int = 1, pageSize: Int = 10, search: String): BookPage!
+}
+
+type Book {
+	id: ID!
+	title: String!
+	author: String!
+	category: String!
+	desc: String!
+	coverUrl: String!
+}
+
+type BookPage {
+	data: [Book!]!
+	page: Int!
+	pageSize: Int!
+	totalItems: Int!
+	totalPages: Int!
+}
+`
+
+// resolver resolves the Query type through a mockapi.Service.
+type resolver struct {
+	service mockapi.Service
+}
+
+func (r *resolver) Book(args struct{ ID graphqlgo.ID }) (*bookResolver, error) {
+	book, err := r.service.GetBookByID(string(args.ID))
+	if err != nil {
+		var notFoundErr *mockapi.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return nil, nil
+		}
+		return nil, newResolverError(err)
+	}
+	return &bookResolver{book: book}, nil
+}
+
+func (r *resolver) Books(args struct {
+	Page     int32
+	PageSize int32
+	Search   *string
+}) (*bookPageResolver, error) {
+	query := mockapi.BookQuery{
+		Page:     int(args.Page),
+		PageSize: int(args.PageSize),
+	}
+	if args.Search != nil {
+		query.Search = *args.Search
+	}
+
+	page, err := r.service.GetBooks(query)
+	if err != nil {
+		return nil, newResolverError(err)
+	}
+	return &bookPageResolver{page: page}, nil
+}
+
+type bookResolver struct {
+	book mockapi.Book
+}
+
+func (r *bookResolver) ID() graphqlgo.ID {
+	return graphqlgo.ID(strconv.Itoa(r.book.ID))
+}
+
+func (r *bookResolver) Title() string {
+	return r.book.Title
+}
+
+func (r *bookResolver) Author() string {
+	return r.book.Author
+}
+
+func (r *bookResolver) Category() string {
+	return r.book.Category
+}
+
+func (r *bookResolver) Desc() string {
+	return r.book.Desc
+}
+
+func (r *bookResolver) CoverURL() string {
+	return r.book.CoverURL
+}
+
+type bookPageResolver struct {
+	page mockapi.PaginatedBooks
+}
+
+func (r *bookPageResolver) Data() []*bookResolver {
+	books := make([]*bookResolver, len(r.page.Data))
+	for i, book := range r.page.Data {
+		books[i] = &bookResolver{book: book}
+	}
+	return books
+}
+
+func (r *bookPageResolver) Page() int32 {
+	return int32(r.page.Page)
+}
+
+func (r *bookPageResolver) PageSize() int32 {
+	return int32(r.page.PageSize)
+}
+
+func (r *bookPageResolver) TotalItems() int32 {
+	return int32(r.page.TotalItems)
+}
+
+func (r *bookPageResolver) TotalPages() int32 {
+	return int32(r.page.TotalPages)
+}
@@ -0,0 +1,417 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: bookpb/book.proto
+
+package bookpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Book struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Author        string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Desc          string                 `protobuf:"bytes,5,opt,name=desc,proto3" json:"desc,omitempty"`
+	CoverUrl      string                 `protobuf:"bytes,6,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Book) Reset() {
+	*x = Book{}
+	mi := &file_bookpb_book_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Book) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Book) ProtoMessage() {}
+
+func (x *Book) ProtoReflect() protoreflect.Message {
+	mi := &file_bookpb_book_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Book.ProtoReflect.Descriptor instead.
+func (*Book) Descriptor() ([]byte, []int) {
+	return file_bookpb_book_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Book) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Book) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Book) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Book) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Book) GetDesc() string {
+	if x != nil {
+		return x.Desc
+	}
+	return ""
+}
+
+func (x *Book) GetCoverUrl() string {
+	if x != nil {
+		return x.CoverUrl
+	}
+	return ""
+}
+
+type PaginatedBooks struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*Book                `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	TotalItems    int64                  `protobuf:"varint,4,opt,name=total_items,json=totalItems,proto3" json:"total_items,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaginatedBooks) Reset() {
+	*x = PaginatedBooks{}
+	mi := &file_bookpb_book_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaginatedBooks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaginatedBooks) ProtoMessage() {}
+
+func (x *PaginatedBooks) ProtoReflect() protoreflect.Message {
+	mi := &file_bookpb_book_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaginatedBooks.ProtoReflect.Descriptor instead.
+func (*PaginatedBooks) Descriptor() ([]byte, []int) {
+	return file_bookpb_book_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PaginatedBooks) GetData() []*Book {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *PaginatedBooks) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PaginatedBooks) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *PaginatedBooks) GetTotalItems() int64 {
+	if x != nil {
+		return x.TotalItems
+	}
+	return 0
+}
+
+func (x *PaginatedBooks) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+type GetBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookRequest) Reset() {
+	*x = GetBookRequest{}
+	mi := &file_bookpb_book_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookRequest) ProtoMessage() {}
+
+func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bookpb_book_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookRequest.ProtoReflect.Descriptor instead.
+func (*GetBookRequest) Descriptor() ([]byte, []int) {
+	return file_bookpb_book_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetBookRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListBooksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Page number, 1 when unset.
+	Page int32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	// Number of books per page, 10 when unset.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Case-insensitive search on title and author.
+	Search string `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
+	// Sort field: id, title, author or category. Defaults to id.
+	Sort string `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	// Sort order: asc or desc. Defaults to asc.
+	Order string `protobuf:"bytes,5,opt,name=order,proto3" json:"order,omitempty"`
+	// Books in any of these categories, matched exactly.
+	Category []string `protobuf:"bytes,6,rep,name=category,proto3" json:"category,omitempty"`
+	// Books by any of these authors, matched exactly.
+	Author        []string `protobuf:"bytes,7,rep,name=author,proto3" json:"author,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBooksRequest) Reset() {
+	*x = ListBooksRequest{}
+	mi := &file_bookpb_book_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBooksRequest) ProtoMessage() {}
+
+func (x *ListBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bookpb_book_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBooksRequest.ProtoReflect.Descriptor instead.
+func (*ListBooksRequest) Descriptor() ([]byte, []int) {
+	return file_bookpb_book_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListBooksRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetCategory() []string {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *ListBooksRequest) GetAuthor() []string {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+var File_bookpb_book_proto protoreflect.FileDescriptor
+
+const file_bookpb_book_proto_rawDesc = "" +
+	"\n" +
+	"\x11bookpb/book.proto\x12\n" +
+	"mockapi.v1\"\x91\x01\n" +
+	"\x04Book\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06author\x18\x03 \x01(\tR\x06author\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04desc\x18\x05 \x01(\tR\x04desc\x12\x1b\n" +
+	"\tcover_url\x18\x06 \x01(\tR\bcoverUrl\"\xa9\x01\n" +
+	"\x0ePaginatedBooks\x12$\n" +
+	"\x04data\x18\x01 \x03(\v2\x10.mockapi.v1.BookR\x04data\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1f\n" +
+	"\vtotal_items\x18\x04 \x01(\x03R\n" +
+	"totalItems\x12\x1f\n" +
+	"\vtotal_pages\x18\x05 \x01(\x05R\n" +
+	"totalPages\" \n" +
+	"\x0eGetBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xb9\x01\n" +
+	"\x10ListBooksRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06search\x18\x03 \x01(\tR\x06search\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05order\x18\x05 \x01(\tR\x05order\x12\x1a\n" +
+	"\bcategory\x18\x06 \x03(\tR\bcategory\x12\x16\n" +
+	"\x06author\x18\a \x03(\tR\x06author2\x8d\x01\n" +
+	"\vBookService\x127\n" +
+	"\aGetBook\x12\x1a.mockapi.v1.GetBookRequest\x1a\x10.mockapi.v1.Book\x12E\n" +
+	"\tListBooks\x12\x1c.mockapi.v1.ListBooksRequest\x1a\x1a.mockapi.v1.PaginatedBooksBj\n" +
+	" com.github.anggaaryas.mockapi.v1B\tBookProtoP\x01Z9github.com/anggaaryas/go-mockapi/router/grpcrouter/bookpbb\x06proto3"
+
+var (
+	file_bookpb_book_proto_rawDescOnce sync.Once
+	file_bookpb_book_proto_rawDescData []byte
+)
+
+func file_bookpb_book_proto_rawDescGZIP() []byte {
+	file_bookpb_book_proto_rawDescOnce.Do(func() {
+		file_bookpb_book_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_bookpb_book_proto_rawDesc), len(file_bookpb_book_proto_rawDesc)))
+	})
+	return file_bookpb_book_proto_rawDescData
+}
+
+var file_bookpb_book_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_bookpb_book_proto_goTypes = []any{
+	(*Book)(nil),             // 0: mockapi.v1.Book
+	(*PaginatedBooks)(nil),   // 1: mockapi.v1.PaginatedBooks
+	(*GetBookRequest)(nil),   // 2: mockapi.v1.GetBookRequest
+	(*ListBooksRequest)(nil), // 3: mockapi.v1.ListBooksRequest
+}
+var file_bookpb_book_proto_depIdxs = []int32{
+	0, // 0: mockapi.v1.PaginatedBooks.data:type_name -> mockapi.v1.Book
+	2, // 1: mockapi.v1.BookService.GetBook:input_type -> mockapi.v1.GetBookRequest
+	3, // 2: mockapi.v1.BookService.ListBooks:input_type -> mockapi.v1.ListBooksRequest
+	0, // 3: mockapi.v1.BookService.GetBook:output_type -> mockapi.v1.Book
+	1, // 4: mockapi.v1.BookService.ListBooks:output_type -> mockapi.v1.PaginatedBooks
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_bookpb_book_proto_init() }
+func file_bookpb_book_proto_init() {
+	if File_bookpb_book_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bookpb_book_proto_rawDesc), len(file_bookpb_book_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bookpb_book_proto_goTypes,
+		DependencyIndexes: file_bookpb_book_proto_depIdxs,
+		MessageInfos:      file_bookpb_book_proto_msgTypes,
+	}.Build()
+	File_bookpb_book_proto = out.File
+	file_bookpb_book_proto_goTypes = nil
+	file_bookpb_book_proto_depIdxs = nil
+}
@@ -0,0 +1,48 @@
+package grpcrouter
+
+import (
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CustomError interface {
+	StatusCode() int
+	Error() string
+}
+
+// grpcCodes maps the HTTP status codes of CustomError to gRPC status codes.
+var grpcCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusInternalServerError: codes.Internal,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+}
+
+// GRPCCode returns the gRPC status code of an HTTP status code, or
+// codes.Unknown when there is none.
+func GRPCCode(statusCode int) codes.Code {
+	if code, ok := grpcCodes[statusCode]; ok {
+		return code
+	}
+	return codes.Unknown
+}
+
+// toStatusError returns err as a gRPC status error. Errors that are not a
+// CustomError are hidden behind a generic Internal error, like the REST
+// routers do.
+func toStatusError(err error) error {
+	var customErr CustomError
+	if errors.As(err, &customErr) {
+		return status.Error(GRPCCode(customErr.StatusCode()), customErr.Error())
+	}
+	return status.Error(codes.Internal, "An error occurred while processing your request")
+}
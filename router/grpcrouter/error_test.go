@@ -0,0 +1,41 @@
+package grpcrouter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anggaaryas/go-mockapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCCode(t *testing.T) {
+	tests := map[int]codes.Code{
+		400: codes.InvalidArgument,
+		401: codes.Unauthenticated,
+		403: codes.PermissionDenied,
+		404: codes.NotFound,
+		409: codes.AlreadyExists,
+		429: codes.ResourceExhausted,
+		500: codes.Internal,
+		503: codes.Unavailable,
+		418: codes.Unknown,
+	}
+	for statusCode, expected := range tests {
+		if code := GRPCCode(statusCode); code != expected {
+			t.Errorf("Expected code %s for %d, got %s", expected, statusCode, code)
+		}
+	}
+}
+
+func TestToStatusError(t *testing.T) {
+	err := toStatusError(mockapi.NewBookAlreadyExistsError("1"))
+	if status.Code(err) != codes.AlreadyExists || status.Convert(err).Message() != mockapi.NewBookAlreadyExistsError("1").Error() {
+		t.Errorf("Expected an AlreadyExists status with the error message, got %v", err)
+	}
+
+	err = toStatusError(errors.New("database error"))
+	if status.Code(err) != codes.Internal || status.Convert(err).Message() == "database error" {
+		t.Errorf("Expected an Internal status hiding the error, got %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+module github.com/anggaaryas/go-mockapi/router/grpcrouter
+
+go 1.25.1
+
+require (
+	github.com/anggaaryas/go-mockapi v0.1.3
+	google.golang.org/grpc v1.82.1
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	golang.org/x/net v0.53.0 // indirect
+	golang.org/x/sys v0.43.0 // indirect
+	golang.org/x/text v0.36.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260414002931-afd174a4e478 // indirect
+)
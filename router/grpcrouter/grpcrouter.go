@@ -0,0 +1,103 @@
+// Package grpcrouter serves the books of a mockapi.Service as the
+// mockapi.v1.BookService gRPC service defined in bookpb/book.proto.
+package grpcrouter
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative bookpb/book.proto
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/anggaaryas/go-mockapi"
+	"github.com/anggaaryas/go-mockapi/router/grpcrouter/bookpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+const (
+	defaultPage     = 1
+	defaultPageSize = 10
+)
+
+type config struct {
+	server *grpc.Server
+}
+
+// Create returns a Router that registers the BookService, along with the gRPC
+// server reflection service, on server. Routes must be set up before server
+// starts serving.
+func Create(server *grpc.Server) mockapi.Router {
+	return &config{
+		server: server,
+	}
+}
+
+func (cfg *config) SetupMockApiRoute(service mockapi.Service) error {
+	bookpb.RegisterBookServiceServer(cfg.server, &bookServer{service: service})
+	reflection.Register(cfg.server)
+	return nil
+}
+
+type bookServer struct {
+	bookpb.UnimplementedBookServiceServer
+	service mockapi.Service
+}
+
+func (s *bookServer) GetBook(ctx context.Context, req *bookpb.GetBookRequest) (*bookpb.Book, error) {
+	book, err := s.service.GetBookByID(strconv.FormatInt(req.GetId(), 10))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toBookProto(book), nil
+}
+
+func (s *bookServer) ListBooks(ctx context.Context, req *bookpb.ListBooksRequest) (*bookpb.PaginatedBooks, error) {
+	page, err := s.service.GetBooks(toBookQuery(req))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	books := make([]*bookpb.Book, len(page.Data))
+	for i, book := range page.Data {
+		books[i] = toBookProto(book)
+	}
+	return &bookpb.PaginatedBooks{
+		Data:       books,
+		Page:       int32(page.Page),
+		PageSize:   int32(page.PageSize),
+		TotalItems: page.TotalItems,
+		TotalPages: int32(page.TotalPages),
+	}, nil
+}
+
+// toBookQuery reads a BookQuery from req, where a zero page or page size is
+// unset and gets the same default as the REST routes.
+func toBookQuery(req *bookpb.ListBooksRequest) mockapi.BookQuery {
+	query := mockapi.BookQuery{
+		Page:     int(req.GetPage()),
+		PageSize: int(req.GetPageSize()),
+		Search:   req.GetSearch(),
+		Sort:     req.GetSort(),
+		Order:    req.GetOrder(),
+		Category: req.GetCategory(),
+		Author:   req.GetAuthor(),
+	}
+	if query.Page == 0 {
+		query.Page = defaultPage
+	}
+	if query.PageSize == 0 {
+		query.PageSize = defaultPageSize
+	}
+	return query
+}
+
+func toBookProto(book mockapi.Book) *bookpb.Book {
+	return &bookpb.Book{
+		Id:       int64(book.ID),
+		Title:    book.Title,
+		Author:   book.Author,
+		Category: book.Category,
+		Desc:     book.Desc,
+		CoverUrl: book.CoverURL,
+	}
+}
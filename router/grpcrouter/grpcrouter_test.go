@@ -0,0 +1,298 @@
+package grpcrouter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/anggaaryas/go-mockapi"
+	"github.com/anggaaryas/go-mockapi/router/grpcrouter/bookpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type mockService struct {
+	getBookByIDFunc      func(id string) (mockapi.Book, error)
+	getBooksFunc         func(query mockapi.BookQuery) (mockapi.PaginatedBooks, error)
+	getBooksByCursorFunc func(query mockapi.BookQuery, cursor string) (mockapi.CursorBooks, error)
+	createBookFunc       func(book mockapi.Book) (mockapi.Book, error)
+	updateBookFunc       func(id string, book mockapi.Book) (mockapi.Book, error)
+	patchBookFunc        func(id string, patch mockapi.BookPatch) (mockapi.Book, error)
+	deleteBookFunc       func(id string) error
+	getAuthorsFunc       func() ([]mockapi.Author, error)
+	getAuthorByIDFunc    func(id string) (mockapi.Author, error)
+	getAuthorBooksFunc   func(id string, query mockapi.BookQuery) (mockapi.PaginatedBooks, error)
+	getCategoriesFunc    func() ([]mockapi.Category, error)
+	getCategoryByIDFunc  func(id string) (mockapi.Category, error)
+	getCategoryBooksFunc func(id string, query mockapi.BookQuery) (mockapi.PaginatedBooks, error)
+	getBookReviewsFunc   func(bookID string) ([]mockapi.Review, error)
+	createReviewFunc     func(bookID string, review mockapi.Review) (mockapi.Review, error)
+}
+
+func (m *mockService) GetBookByID(id string) (mockapi.Book, error) {
+	if m.getBookByIDFunc != nil {
+		return m.getBookByIDFunc(id)
+	}
+	return mockapi.Book{}, nil
+}
+
+func (m *mockService) GetBooks(query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+	if m.getBooksFunc != nil {
+		return m.getBooksFunc(query)
+	}
+	return mockapi.PaginatedBooks{}, nil
+}
+
+func (m *mockService) GetBooksByCursor(query mockapi.BookQuery, cursor string) (mockapi.CursorBooks, error) {
+	if m.getBooksByCursorFunc != nil {
+		return m.getBooksByCursorFunc(query, cursor)
+	}
+	return mockapi.CursorBooks{}, nil
+}
+
+func (m *mockService) CreateBook(book mockapi.Book) (mockapi.Book, error) {
+	if m.createBookFunc != nil {
+		return m.createBookFunc(book)
+	}
+	return book, nil
+}
+
+func (m *mockService) UpdateBook(id string, book mockapi.Book) (mockapi.Book, error) {
+	if m.updateBookFunc != nil {
+		return m.updateBookFunc(id, book)
+	}
+	return book, nil
+}
+
+func (m *mockService) PatchBook(id string, patch mockapi.BookPatch) (mockapi.Book, error) {
+	if m.patchBookFunc != nil {
+		return m.patchBookFunc(id, patch)
+	}
+	return mockapi.Book{}, nil
+}
+
+func (m *mockService) DeleteBook(id string) error {
+	if m.deleteBookFunc != nil {
+		return m.deleteBookFunc(id)
+	}
+	return nil
+}
+
+func (m *mockService) GetAuthors() ([]mockapi.Author, error) {
+	if m.getAuthorsFunc != nil {
+		return m.getAuthorsFunc()
+	}
+	return []mockapi.Author{}, nil
+}
+
+func (m *mockService) GetAuthorByID(id string) (mockapi.Author, error) {
+	if m.getAuthorByIDFunc != nil {
+		return m.getAuthorByIDFunc(id)
+	}
+	return mockapi.Author{}, nil
+}
+
+func (m *mockService) GetAuthorBooks(id string, query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+	if m.getAuthorBooksFunc != nil {
+		return m.getAuthorBooksFunc(id, query)
+	}
+	return mockapi.PaginatedBooks{}, nil
+}
+
+func (m *mockService) GetCategories() ([]mockapi.Category, error) {
+	if m.getCategoriesFunc != nil {
+		return m.getCategoriesFunc()
+	}
+	return []mockapi.Category{}, nil
+}
+
+func (m *mockService) GetCategoryByID(id string) (mockapi.Category, error) {
+	if m.getCategoryByIDFunc != nil {
+		return m.getCategoryByIDFunc(id)
+	}
+	return mockapi.Category{}, nil
+}
+
+func (m *mockService) GetCategoryBooks(id string, query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+	if m.getCategoryBooksFunc != nil {
+		return m.getCategoryBooksFunc(id, query)
+	}
+	return mockapi.PaginatedBooks{}, nil
+}
+
+func (m *mockService) GetBookReviews(bookID string) ([]mockapi.Review, error) {
+	if m.getBookReviewsFunc != nil {
+		return m.getBookReviewsFunc(bookID)
+	}
+	return []mockapi.Review{}, nil
+}
+
+func (m *mockService) CreateReview(bookID string, review mockapi.Review) (mockapi.Review, error) {
+	if m.createReviewFunc != nil {
+		return m.createReviewFunc(bookID, review)
+	}
+	return review, nil
+}
+
+// setupTestServer serves service on an in-memory listener and returns a
+// connection to it.
+func setupTestServer(t *testing.T, service mockapi.Service) *grpc.ClientConn {
+	t.Helper()
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	if err := Create(server).SetupMockApiRoute(service); err != nil {
+		t.Fatalf("SetupMockApiRoute failed: %v", err)
+	}
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestCreate(t *testing.T) {
+	router := Create(grpc.NewServer())
+
+	if router == nil {
+		t.Fatal("Expected Create to return a non-nil Router")
+	}
+}
+
+func TestGetBook_Success(t *testing.T) {
+	conn := setupTestServer(t, &mockService{
+		getBookByIDFunc: func(id string) (mockapi.Book, error) {
+			return mockapi.Book{ID: 1, Title: "Test Book", Author: "Test Author", CoverURL: "http://test.com/cover.jpg"}, nil
+		},
+	})
+
+	book, err := bookpb.NewBookServiceClient(conn).GetBook(context.Background(), &bookpb.GetBookRequest{Id: 1})
+	if err != nil {
+		t.Fatalf("GetBook failed: %v", err)
+	}
+	if book.GetId() != 1 || book.GetTitle() != "Test Book" || book.GetCoverUrl() != "http://test.com/cover.jpg" {
+		t.Errorf("Unexpected book %v", book)
+	}
+}
+
+func TestGetBook_Errors(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{mockapi.NewBookNotFoundError("9"), codes.NotFound},
+		{mockapi.NewRequiredFieldError("title"), codes.InvalidArgument},
+		{errors.New("database error"), codes.Internal},
+	}
+	for _, tt := range tests {
+		conn := setupTestServer(t, &mockService{
+			getBookByIDFunc: func(id string) (mockapi.Book, error) {
+				return mockapi.Book{}, tt.err
+			},
+		})
+
+		_, err := bookpb.NewBookServiceClient(conn).GetBook(context.Background(), &bookpb.GetBookRequest{Id: 9})
+		if status.Code(err) != tt.code {
+			t.Errorf("Expected code %s for %v, got %v", tt.code, tt.err, err)
+		}
+	}
+}
+
+func TestListBooks(t *testing.T) {
+	var received mockapi.BookQuery
+	conn := setupTestServer(t, &mockService{
+		getBooksFunc: func(query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+			received = query
+			return mockapi.PaginatedBooks{
+				Data:       []mockapi.Book{{ID: 1, Title: "Book 1"}, {ID: 2, Title: "Book 2"}},
+				Page:       query.Page,
+				PageSize:   query.PageSize,
+				TotalItems: 12,
+				TotalPages: 2,
+			}, nil
+		},
+	})
+	client := bookpb.NewBookServiceClient(conn)
+
+	page, err := client.ListBooks(context.Background(), &bookpb.ListBooksRequest{})
+	if err != nil {
+		t.Fatalf("ListBooks failed: %v", err)
+	}
+	if page.GetPage() != 1 || page.GetPageSize() != 10 || page.GetTotalItems() != 12 || len(page.GetData()) != 2 {
+		t.Errorf("Unexpected page %v", page)
+	}
+
+	_, err = client.ListBooks(context.Background(), &bookpb.ListBooksRequest{
+		Page:     2,
+		PageSize: 5,
+		Search:   "go",
+		Sort:     "title",
+		Order:    "desc",
+		Category: []string{"Programming"},
+	})
+	if err != nil {
+		t.Fatalf("ListBooks failed: %v", err)
+	}
+	if received.Page != 2 || received.PageSize != 5 || received.Search != "go" || received.Sort != "title" || received.Order != "desc" || len(received.Category) != 1 {
+		t.Errorf("Unexpected query %+v", received)
+	}
+}
+
+func TestListBooks_InvalidArgument(t *testing.T) {
+	conn := setupTestServer(t, &mockService{
+		getBooksFunc: func(query mockapi.BookQuery) (mockapi.PaginatedBooks, error) {
+			return mockapi.PaginatedBooks{}, query.Validate()
+		},
+	})
+	client := bookpb.NewBookServiceClient(conn)
+
+	for _, req := range []*bookpb.ListBooksRequest{
+		{Page: -1},
+		{PageSize: -1},
+	} {
+		if _, err := client.ListBooks(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected code %s for %v, got %v", codes.InvalidArgument, req, err)
+		}
+	}
+}
+
+func TestReflection(t *testing.T) {
+	conn := setupTestServer(t, &mockService{})
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	if err != nil {
+		t.Fatalf("ServerReflectionInfo failed: %v", err)
+	}
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv failed: %v", err)
+	}
+
+	found := false
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		if service.GetName() == "mockapi.v1.BookService" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected mockapi.v1.BookService to be listed, got %v", resp.GetListServicesResponse())
+	}
+}
@@ -0,0 +1,65 @@
+package stdrouter
+
+import (
+	"fmt"
+)
+
+// BadRequestError represents a bad request error with a message.
+type BadRequestError struct {
+	Message string `json:"message"`
+}
+
+type CustomError interface {
+	StatusCode() int
+	Error() string
+}
+
+// NewIDShouldBeIntError creates a new BadRequestError for invalid ID type.
+func NewIDShouldBeIntError(param string) *BadRequestError {
+	return &BadRequestError{
+		Message: fmt.Sprintf("bad request: %s should be an integer", param),
+	}
+}
+
+// NewInvalidBodyError creates a new BadRequestError for a malformed request body.
+func NewInvalidBodyError(reason string) *BadRequestError {
+	return &BadRequestError{
+		Message: fmt.Sprintf("bad request: invalid request body: %s", reason),
+	}
+}
+
+// NewUnknownSortFieldError creates a new BadRequestError for an unsupported sort field.
+func NewUnknownSortFieldError(field string) *BadRequestError {
+	return &BadRequestError{
+		Message: fmt.Sprintf("bad request: unknown sort field %s", field),
+	}
+}
+
+// NewInvalidOrderError creates a new BadRequestError for an order other than asc or desc.
+func NewInvalidOrderError(order string) *BadRequestError {
+	return &BadRequestError{
+		Message: fmt.Sprintf("bad request: order should be asc or desc, got %s", order),
+	}
+}
+
+// NewInvalidPaginationError creates a new BadRequestError for a pagination mode other than offset or cursor.
+func NewInvalidPaginationError(mode string) *BadRequestError {
+	return &BadRequestError{
+		Message: fmt.Sprintf("bad request: pagination should be offset or cursor, got %s", mode),
+	}
+}
+
+// NewInvalidPageParamError creates a new BadRequestError for a page or page_size below one or not a number.
+func NewInvalidPageParamError(param string, value string) *BadRequestError {
+	return &BadRequestError{
+		Message: fmt.Sprintf("bad request: %s should be a positive integer, got %s", param, value),
+	}
+}
+
+func (e *BadRequestError) StatusCode() int {
+	return 400
+}
+
+func (e *BadRequestError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,100 @@
+package stdrouter
+
+import "testing"
+
+func TestNewIDShouldBeIntError(t *testing.T) {
+	err := NewIDShouldBeIntError("id")
+
+	if err == nil {
+		t.Fatal("Expected non-nil error")
+	}
+
+	expected := "bad request: id should be an integer"
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+}
+
+func TestNewIDShouldBeIntError_DifferentParam(t *testing.T) {
+	err := NewIDShouldBeIntError("userId")
+
+	expected := "bad request: userId should be an integer"
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+}
+
+func TestNewInvalidBodyError(t *testing.T) {
+	err := NewInvalidBodyError("unexpected EOF")
+
+	expected := "bad request: invalid request body: unexpected EOF"
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+}
+
+func TestNewUnknownSortFieldError(t *testing.T) {
+	err := NewUnknownSortFieldError("price")
+
+	expected := "bad request: unknown sort field price"
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+}
+
+func TestNewInvalidOrderError(t *testing.T) {
+	err := NewInvalidOrderError("up")
+
+	expected := "bad request: order should be asc or desc, got up"
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+}
+
+func TestNewInvalidPaginationError(t *testing.T) {
+	err := NewInvalidPaginationError("page")
+
+	expected := "bad request: pagination should be offset or cursor, got page"
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+}
+
+func TestNewInvalidPageParamError(t *testing.T) {
+	err := NewInvalidPageParamError("page_size", "0")
+
+	expected := "bad request: page_size should be a positive integer, got 0"
+	if err.Message != expected {
+		t.Errorf("Expected message %s, got %s", expected, err.Message)
+	}
+}
+
+func TestBadRequestError_StatusCode(t *testing.T) {
+	err := &BadRequestError{Message: "test error"}
+
+	statusCode := err.StatusCode()
+	expected := 400
+
+	if statusCode != expected {
+		t.Errorf("Expected status code %d, got %d", expected, statusCode)
+	}
+}
+
+func TestBadRequestError_Error(t *testing.T) {
+	message := "test error message"
+	err := &BadRequestError{Message: message}
+
+	errorMsg := err.Error()
+
+	if errorMsg != message {
+		t.Errorf("Expected error message %s, got %s", message, errorMsg)
+	}
+}
+
+func TestBadRequestError_ImplementsError(t *testing.T) {
+	var _ error = &BadRequestError{}
+}
+
+func TestBadRequestError_ImplementsCustomError(t *testing.T) {
+	var _ CustomError = &BadRequestError{}
+}
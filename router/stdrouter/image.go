@@ -0,0 +1,65 @@
+package stdrouter
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/anggaaryas/go-mockapi"
+)
+
+// setupImageRoute registers GET /mockapi/image/{filename}, which serves an
+// embedded image resized and converted according to the w, h and fmt query
+// parameters.
+func (cfg *config) setupImageRoute() {
+	renderer := mockapi.NewImageRenderer(mockapi.DefaultImageCacheSize)
+
+	cfg.mux.HandleFunc("GET "+mockapi.GetMockapiImagePath()+"/{filename}", func(w http.ResponseWriter, r *http.Request) {
+		opts, err := mockapi.ParseImageOptions(defaultQuery(r, "w", ""), defaultQuery(r, "h", ""), defaultQuery(r, "fmt", ""))
+		if err != nil {
+			cfg.writeError(w, err)
+			return
+		}
+		img, err := renderer.Render(r.PathValue("filename"), opts)
+		if err != nil {
+			cfg.writeError(w, err)
+			return
+		}
+
+		w.Header().Set("Cache-Control", mockapi.ImageCacheControl)
+		w.Header().Set("ETag", img.ETag)
+		if img.NotModified(r.Header.Get("If-None-Match")) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", img.ContentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(img.Data)))
+		w.Write(img.Data)
+	})
+}
+
+// setupPlaceholderRoute registers GET /mockapi/placeholder/{id}, which renders
+// the placeholder cover of a book as a PNG, or an SVG with fmt=svg.
+func (cfg *config) setupPlaceholderRoute(service mockapi.Service) {
+	cfg.mux.HandleFunc("GET "+mockapi.GetMockapiPlaceholderPath()+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		book, err := service.GetBookByID(r.PathValue("id"))
+		if err != nil {
+			cfg.writeError(w, err)
+			return
+		}
+		img, err := mockapi.RenderPlaceholder(book, defaultQuery(r, "fmt", ""))
+		if err != nil {
+			cfg.writeError(w, err)
+			return
+		}
+
+		w.Header().Set("Cache-Control", mockapi.PlaceholderCacheControl)
+		w.Header().Set("ETag", img.ETag)
+		if img.NotModified(r.Header.Get("If-None-Match")) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", img.ContentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(img.Data)))
+		w.Write(img.Data)
+	})
+}
@@ -0,0 +1,57 @@
+package stdrouter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/anggaaryas/go-mockapi"
+)
+
+// SetupMockRoute serves a route loaded from a definition file. Conflicts
+// with routes that are already registered are returned as errors.
+func (cfg *config) SetupMockRoute(route *mockapi.MockRoute) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("route %s %s: %v", route.Method, route.Path, r)
+		}
+	}()
+
+	cfg.mux.HandleFunc(route.Method+" "+route.Path, func(w http.ResponseWriter, r *http.Request) {
+		params := map[string]string{}
+		for _, param := range route.Params {
+			if param.In == "path" {
+				params[param.Name] = r.PathValue(param.Name)
+			}
+		}
+		response, err := route.Respond(params, r.URL.Query())
+		if err != nil {
+			cfg.writeError(w, err)
+			return
+		}
+		if response.Delay > 0 {
+			sleepContext(r.Context(), response.Delay)
+			if r.Context().Err() != nil {
+				// The client went away during the delay.
+				return
+			}
+		}
+		for name, value := range response.Headers {
+			w.Header().Set(name, value)
+		}
+		w.WriteHeader(response.Status)
+		w.Write(response.Body)
+	})
+	return nil
+}
+
+// sleepContext waits for d, or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
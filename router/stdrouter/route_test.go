@@ -0,0 +1,103 @@
+package stdrouter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anggaaryas/go-mockapi"
+)
+
+func setupTestMockRoutes(t *testing.T, router mockapi.Router, definitions []mockapi.RouteDefinition, service mockapi.Service) {
+	routes, err := mockapi.NewMockRoutes(definitions, service)
+	if err != nil {
+		t.Fatalf("NewMockRoutes failed: %v", err)
+	}
+	for _, route := range routes {
+		if err := router.(mockapi.MockRouteRouter).SetupMockRoute(route); err != nil {
+			t.Fatalf("SetupMockRoute failed: %v", err)
+		}
+	}
+}
+
+func TestSetupMockRoute(t *testing.T) {
+	r := http.NewServeMux()
+	router := Create(r)
+
+	service := &mockService{
+		getBookByIDFunc: func(id string) (mockapi.Book, error) {
+			if id != "1" {
+				return mockapi.Book{}, mockapi.NewBookNotFoundError(id)
+			}
+			return mockapi.Book{ID: 1, Title: "Clean Code"}, nil
+		},
+	}
+	setupTestMockRoutes(t, router, []mockapi.RouteDefinition{{
+		Method:  "GET",
+		Path:    "/api/loans/{book_id}",
+		Params:  []mockapi.RouteParam{{Name: "book_id", In: "path", Type: mockapi.ParamTypeInteger}},
+		Status:  202,
+		Headers: map[string]string{"X-Loan": "{{ .Params.book_id }}"},
+		Body:    `{"title": {{ (book (print .Params.book_id)).Title | json }}}`,
+	}}, service)
+
+	req, _ := http.NewRequest("GET", "/api/loans/1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("Expected status code %d, got %d", http.StatusAccepted, w.Code)
+	}
+	if w.Header().Get("X-Loan") != "1" {
+		t.Errorf("Expected X-Loan header 1, got %s", w.Header().Get("X-Loan"))
+	}
+	if w.Body.String() != `{"title": "Clean Code"}` {
+		t.Errorf("Unexpected body %s", w.Body.String())
+	}
+
+	for path, status := range map[string]int{
+		"/api/loans/abc": http.StatusBadRequest,
+		"/api/loans/2":   http.StatusNotFound,
+	} {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != status {
+			t.Errorf("Expected status code %d for %s, got %d", status, path, w.Code)
+		}
+	}
+}
+
+func TestSetupMockRoute_ClientGone(t *testing.T) {
+	r := http.NewServeMux()
+	setupTestMockRoutes(t, Create(r), []mockapi.RouteDefinition{{
+		Method:  "GET",
+		Path:    "/api/slow",
+		Headers: map[string]string{"X-Slow": "yes"},
+		DelayMS: 60000,
+		Body:    "{}",
+	}}, &mockService{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", "/api/slow", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Header().Get("X-Slow") != "" || w.Body.Len() != 0 {
+		t.Errorf("Expected nothing to be written for a client that went away, got %v %s", w.Header(), w.Body.String())
+	}
+}
+
+func TestSetupMockRoute_Conflict(t *testing.T) {
+	r := http.NewServeMux()
+	router := Create(r)
+	router.SetupMockApiRoute(&mockService{})
+
+	routes, _ := mockapi.NewMockRoutes([]mockapi.RouteDefinition{{Method: "GET", Path: "/api/books/{id}"}}, &mockService{})
+	if err := router.(mockapi.MockRouteRouter).SetupMockRoute(routes[0]); err == nil {
+		t.Error("Expected error for a route that is already registered")
+	}
+}
@@ -0,0 +1,414 @@
+package stdrouter
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/anggaaryas/go-mockapi"
+)
+
+type config struct {
+	mux   *http.ServeMux
+	style mockapi.ResponseStyle
+}
+
+func Create(mux *http.ServeMux) mockapi.Router {
+	return &config{
+		mux: mux,
+	}
+}
+
+// CreateWithStyle returns a Router that renders the books of its responses
+// in style, e.g. mockapi.ResponseStyleJSONAPI. Styles that
+// mockapi.ParseResponseStyle does not know are returned as errors.
+func CreateWithStyle(mux *http.ServeMux, style mockapi.ResponseStyle) (mockapi.Router, error) {
+	style, err := mockapi.ParseResponseStyle(string(style))
+	if err != nil {
+		return nil, err
+	}
+	return &config{
+		mux:   mux,
+		style: style,
+	}, nil
+}
+
+func (cfg *config) getErrorResponse(err error) mockapi.APIError {
+	statusCode := 500
+
+	if customErr, ok := err.(CustomError); ok {
+		statusCode = customErr.StatusCode()
+		return mockapi.APIError{
+			StatusCode: statusCode,
+			Message:    customErr.Error(),
+		}
+	}
+
+	return mockapi.APIError{
+		StatusCode: statusCode,
+		Message:    "An error occurred while processing your request",
+	}
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(v)
+}
+
+func (cfg *config) writeError(w http.ResponseWriter, err error) {
+	apiErr := cfg.getErrorResponse(err)
+	writeJSON(w, apiErr.StatusCode, apiErr)
+}
+
+// writeStyled writes data, books or an APIError, in the response style of the
+// router.
+func (cfg *config) writeStyled(w http.ResponseWriter, r *http.Request, service mockapi.Service, statusCode int, data any) {
+	doc, err := cfg.style.Render(service, r.URL, data)
+	if err != nil {
+		// Errors are written as they are when the style fails on them too.
+		if _, ok := data.(mockapi.APIError); ok {
+			writeJSON(w, statusCode, data)
+			return
+		}
+		cfg.writeStyledError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", cfg.style.ContentType())
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(doc)
+}
+
+func (cfg *config) writeStyledError(w http.ResponseWriter, r *http.Request, err error) {
+	apiErr := cfg.getErrorResponse(err)
+	cfg.writeStyled(w, r, nil, apiErr.StatusCode, apiErr)
+}
+
+func defaultQuery(r *http.Request, key string, defaultValue string) string {
+	if values, ok := r.URL.Query()[key]; ok && len(values) > 0 {
+		return values[0]
+	}
+	return defaultValue
+}
+
+// splitQueryValues flattens repeated and comma-separated query values.
+func splitQueryValues(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
+
+// positiveQuery returns the query parameter key as an integer of at least one.
+func positiveQuery(r *http.Request, key string, defaultValue string) (int, error) {
+	value := defaultQuery(r, key, defaultValue)
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, NewInvalidPageParamError(key, value)
+	}
+	return n, nil
+}
+
+func parseBookQuery(r *http.Request) (mockapi.BookQuery, error) {
+	page, err := positiveQuery(r, "page", "1")
+	if err != nil {
+		return mockapi.BookQuery{}, err
+	}
+	pageSize, err := positiveQuery(r, "page_size", "10")
+	if err != nil {
+		return mockapi.BookQuery{}, err
+	}
+	query := mockapi.BookQuery{
+		Page:     page,
+		PageSize: pageSize,
+		Search:   defaultQuery(r, "search", ""),
+		Sort:     defaultQuery(r, "sort", ""),
+		Order:    strings.ToLower(defaultQuery(r, "order", "")),
+		Category: splitQueryValues(r.URL.Query()["category"]),
+		Author:   splitQueryValues(r.URL.Query()["author"]),
+	}
+	if query.Sort != "" && !mockapi.IsBookSortField(query.Sort) {
+		return mockapi.BookQuery{}, NewUnknownSortFieldError(query.Sort)
+	}
+	if query.Order != "" && !mockapi.IsSortOrder(query.Order) {
+		return mockapi.BookQuery{}, NewInvalidOrderError(query.Order)
+	}
+	return query, nil
+}
+
+func parseResourceQuery(r *http.Request) mockapi.ResourceQuery {
+	page, _ := strconv.Atoi(defaultQuery(r, "page", "1"))
+	pageSize, _ := strconv.Atoi(defaultQuery(r, "page_size", "10"))
+	return mockapi.ResourceQuery{
+		Page:     page,
+		PageSize: pageSize,
+		Search:   defaultQuery(r, "search", ""),
+		Sort:     defaultQuery(r, "sort", ""),
+		Order:    strings.ToLower(defaultQuery(r, "order", "")),
+	}
+}
+
+func (cfg *config) SetupMockApiRoute(service mockapi.Service) error {
+
+	cfg.mux.Handle("GET "+mockapi.GetMockapiStaticPath()+"/", http.StripPrefix(mockapi.GetMockapiStaticPath(), http.FileServerFS(mockapi.GetStaticFS())))
+	cfg.setupImageRoute()
+	cfg.setupPlaceholderRoute(service)
+
+	cfg.mux.HandleFunc("GET /api/books/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			cfg.writeStyledError(w, r, NewIDShouldBeIntError("id"))
+			return
+		}
+		book, err := service.GetBookByID(id)
+		if err != nil {
+			cfg.writeStyledError(w, r, err)
+			return
+		}
+		cfg.writeStyled(w, r, service, 200, book)
+	})
+	cfg.mux.HandleFunc("GET /api/books", func(w http.ResponseWriter, r *http.Request) {
+		query, err := parseBookQuery(r)
+		if err != nil {
+			cfg.writeStyledError(w, r, err)
+			return
+		}
+		cursor := defaultQuery(r, "cursor", "")
+		pagination := defaultQuery(r, "pagination", "offset")
+		if cursor != "" {
+			pagination = "cursor"
+		}
+		switch pagination {
+		case "offset":
+			books, err := service.GetBooks(query)
+			if err != nil {
+				cfg.writeStyledError(w, r, err)
+				return
+			}
+			cfg.writeStyled(w, r, service, 200, books)
+		case "cursor":
+			books, err := service.GetBooksByCursor(query, cursor)
+			if err != nil {
+				cfg.writeStyledError(w, r, err)
+				return
+			}
+			cfg.writeStyled(w, r, service, 200, books)
+		default:
+			cfg.writeStyledError(w, r, NewInvalidPaginationError(pagination))
+		}
+	})
+	cfg.mux.HandleFunc("POST /api/books", func(w http.ResponseWriter, r *http.Request) {
+		var book mockapi.Book
+		if err := json.NewDecoder(r.Body).Decode(&book); err != nil {
+			cfg.writeStyledError(w, r, NewInvalidBodyError(err.Error()))
+			return
+		}
+		created, err := service.CreateBook(book)
+		if err != nil {
+			cfg.writeStyledError(w, r, err)
+			return
+		}
+		cfg.writeStyled(w, r, service, 201, created)
+	})
+	cfg.mux.HandleFunc("PUT /api/books/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			cfg.writeStyledError(w, r, NewIDShouldBeIntError("id"))
+			return
+		}
+		var book mockapi.Book
+		if err := json.NewDecoder(r.Body).Decode(&book); err != nil {
+			cfg.writeStyledError(w, r, NewInvalidBodyError(err.Error()))
+			return
+		}
+		updated, err := service.UpdateBook(id, book)
+		if err != nil {
+			cfg.writeStyledError(w, r, err)
+			return
+		}
+		cfg.writeStyled(w, r, service, 200, updated)
+	})
+	cfg.mux.HandleFunc("PATCH /api/books/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			cfg.writeStyledError(w, r, NewIDShouldBeIntError("id"))
+			return
+		}
+		var patch mockapi.BookPatch
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			cfg.writeStyledError(w, r, NewInvalidBodyError(err.Error()))
+			return
+		}
+		patched, err := service.PatchBook(id, patch)
+		if err != nil {
+			cfg.writeStyledError(w, r, err)
+			return
+		}
+		cfg.writeStyled(w, r, service, 200, patched)
+	})
+	cfg.mux.HandleFunc("DELETE /api/books/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			cfg.writeStyledError(w, r, NewIDShouldBeIntError("id"))
+			return
+		}
+		if err := service.DeleteBook(id); err != nil {
+			cfg.writeStyledError(w, r, err)
+			return
+		}
+		w.WriteHeader(204)
+	})
+	cfg.mux.HandleFunc("GET /api/books/{id}/reviews", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			cfg.writeError(w, NewIDShouldBeIntError("id"))
+			return
+		}
+		reviews, err := service.GetBookReviews(id)
+		if err != nil {
+			cfg.writeError(w, err)
+			return
+		}
+		writeJSON(w, 200, reviews)
+	})
+	cfg.mux.HandleFunc("POST /api/books/{id}/reviews", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			cfg.writeError(w, NewIDShouldBeIntError("id"))
+			return
+		}
+		var review mockapi.Review
+		if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+			cfg.writeError(w, NewInvalidBodyError(err.Error()))
+			return
+		}
+		created, err := service.CreateReview(id, review)
+		if err != nil {
+			cfg.writeError(w, err)
+			return
+		}
+		writeJSON(w, 201, created)
+	})
+
+	cfg.mux.HandleFunc("GET /api/authors", func(w http.ResponseWriter, r *http.Request) {
+		authors, err := service.GetAuthors()
+		if err != nil {
+			cfg.writeError(w, err)
+			return
+		}
+		writeJSON(w, 200, authors)
+	})
+	cfg.mux.HandleFunc("GET /api/authors/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			cfg.writeError(w, NewIDShouldBeIntError("id"))
+			return
+		}
+		author, err := service.GetAuthorByID(id)
+		if err != nil {
+			cfg.writeError(w, err)
+			return
+		}
+		writeJSON(w, 200, author)
+	})
+	cfg.mux.HandleFunc("GET /api/authors/{id}/books", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			cfg.writeStyledError(w, r, NewIDShouldBeIntError("id"))
+			return
+		}
+		query, err := parseBookQuery(r)
+		if err != nil {
+			cfg.writeStyledError(w, r, err)
+			return
+		}
+		books, err := service.GetAuthorBooks(id, query)
+		if err != nil {
+			cfg.writeStyledError(w, r, err)
+			return
+		}
+		cfg.writeStyled(w, r, service, 200, books)
+	})
+
+	cfg.mux.HandleFunc("GET /api/categories", func(w http.ResponseWriter, r *http.Request) {
+		categories, err := service.GetCategories()
+		if err != nil {
+			cfg.writeError(w, err)
+			return
+		}
+		writeJSON(w, 200, categories)
+	})
+	cfg.mux.HandleFunc("GET /api/categories/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			cfg.writeError(w, NewIDShouldBeIntError("id"))
+			return
+		}
+		category, err := service.GetCategoryByID(id)
+		if err != nil {
+			cfg.writeError(w, err)
+			return
+		}
+		writeJSON(w, 200, category)
+	})
+	cfg.mux.HandleFunc("GET /api/categories/{id}/books", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		_, err := strconv.Atoi(id)
+		if err != nil {
+			cfg.writeStyledError(w, r, NewIDShouldBeIntError("id"))
+			return
+		}
+		query, err := parseBookQuery(r)
+		if err != nil {
+			cfg.writeStyledError(w, r, err)
+			return
+		}
+		books, err := service.GetCategoryBooks(id, query)
+		if err != nil {
+			cfg.writeStyledError(w, r, err)
+			return
+		}
+		cfg.writeStyled(w, r, service, 200, books)
+	})
+
+	return nil
+}
+
+// SetupResourceRoute serves a generic resource under /api/{name}.
+func (cfg *config) SetupResourceRoute(resource mockapi.ResourceService) error {
+	name := resource.Schema().Name
+
+	cfg.mux.HandleFunc("GET /api/"+name, func(w http.ResponseWriter, r *http.Request) {
+		items, err := resource.GetResources(parseResourceQuery(r))
+		if err != nil {
+			cfg.writeError(w, err)
+			return
+		}
+		writeJSON(w, 200, items)
+	})
+	cfg.mux.HandleFunc("GET /api/"+name+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		item, err := resource.GetResource(r.PathValue("id"))
+		if err != nil {
+			cfg.writeError(w, err)
+			return
+		}
+		writeJSON(w, 200, item)
+	})
+
+	return nil
+}
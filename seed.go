@@ -0,0 +1,254 @@
+package mockapi
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Seed formats understood by LoadBooks.
+const (
+	SeedFormatJSON = "json"
+	SeedFormatYAML = "yaml"
+	SeedFormatCSV  = "csv"
+)
+
+// SeedFormatFromName returns the seed format matching the extension of name.
+func SeedFormatFromName(name string) (string, error) {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".json":
+		return SeedFormatJSON, nil
+	case ".yaml", ".yml":
+		return SeedFormatYAML, nil
+	case ".csv":
+		return SeedFormatCSV, nil
+	}
+	return "", fmt.Errorf("seed: unsupported file extension %q, expected .json, .yaml, .yml or .csv", path.Ext(name))
+}
+
+// LoadBooks reads a list of books in the given format and validates it with
+// ValidateBooks. Fields use the same names as the JSON API.
+func LoadBooks(r io.Reader, format string) ([]Book, error) {
+	var books []Book
+	var err error
+	switch format {
+	case SeedFormatJSON:
+		books, err = decodeJSONBooks(r)
+	case SeedFormatYAML:
+		books, err = decodeYAMLBooks(r)
+	case SeedFormatCSV:
+		books, err = decodeCSVBooks(r)
+	default:
+		return nil, fmt.Errorf("seed: unsupported format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateBooks(books); err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+// LoadBooksFile reads books from a file on disk, picking the format from its extension.
+func LoadBooksFile(name string) ([]Book, error) {
+	return LoadBooksFS(os.DirFS(filepath.Dir(name)), filepath.Base(name))
+}
+
+// LoadBooksFS reads books from a file in fsys, picking the format from its extension.
+func LoadBooksFS(fsys fs.FS, name string) ([]Book, error) {
+	format, err := SeedFormatFromName(name)
+	if err != nil {
+		return nil, err
+	}
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadBooks(f, format)
+}
+
+func decodeJSONBooks(r io.Reader) ([]Book, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	var books []Book
+	if err := dec.Decode(&books); err != nil {
+		return nil, fmt.Errorf("seed: %w", err)
+	}
+	return books, nil
+}
+
+// decodeYAMLBooks converts the YAML document to JSON so books are decoded with
+// the same field names and checks as JSON seeds.
+func decodeYAMLBooks(r io.Reader) ([]Book, error) {
+	var raw []map[string]any
+	if err := yaml.NewDecoder(r).Decode(&raw); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("seed: %w", err)
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("seed: %w", err)
+	}
+	return decodeJSONBooks(bytes.NewReader(data))
+}
+
+// decodeCSVBooks reads a CSV file whose header row names the columns. Only
+// title and author columns are required.
+func decodeCSVBooks(r io.Reader) ([]Book, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("seed: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	for _, column := range header {
+		switch column {
+		case "id", "title", "author", "category", "desc", "cover_url":
+		default:
+			return nil, fmt.Errorf("seed: unknown CSV column %q", column)
+		}
+	}
+
+	books := make([]Book, 0, len(records)-1)
+	for i, record := range records[1:] {
+		var book Book
+		for j, value := range record {
+			switch header[j] {
+			case "id":
+				if value == "" {
+					continue
+				}
+				id, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, fmt.Errorf("seed: line %d: id should be an integer, got %q", i+2, value)
+				}
+				book.ID = id
+			case "title":
+				book.Title = value
+			case "author":
+				book.Author = value
+			case "category":
+				book.Category = value
+			case "desc":
+				book.Desc = value
+			case "cover_url":
+				book.CoverURL = value
+			}
+		}
+		books = append(books, book)
+	}
+	return books, nil
+}
+
+// ValidateBooks checks a seed before it is used. Every book needs a title and
+// an author, and IDs must be unique. Books without an ID are numbered after
+// the highest given ID, and cover URLs that are a bare file name refer to the
+// embedded cover images.
+func ValidateBooks(books []Book) error {
+	seen := make(map[int]bool, len(books))
+	maxID := 0
+	for i, book := range books {
+		if book.Title == "" {
+			return fmt.Errorf("seed: book %d: %w", i+1, NewRequiredFieldError("title"))
+		}
+		if book.Author == "" {
+			return fmt.Errorf("seed: book %d: %w", i+1, NewRequiredFieldError("author"))
+		}
+		if book.ID < 0 {
+			return fmt.Errorf("seed: book %d: id should not be negative", i+1)
+		}
+		if book.ID == 0 {
+			continue
+		}
+		if seen[book.ID] {
+			return fmt.Errorf("seed: book %d: %w", i+1, NewBookAlreadyExistsError(strconv.Itoa(book.ID)))
+		}
+		seen[book.ID] = true
+		maxID = max(maxID, book.ID)
+	}
+
+	for i := range books {
+		if books[i].ID == 0 {
+			maxID++
+			books[i].ID = maxID
+		}
+		if books[i].CoverURL != "" && !strings.Contains(books[i].CoverURL, "/") {
+			books[i].CoverURL = GetCoverURL(books[i].CoverURL)
+		}
+	}
+	return nil
+}
+
+// ValidateDataset checks data before it is imported. Books are checked with
+// ValidateBooks. Authors, categories and reviews need unique positive IDs and
+// reviews must refer to one of the books. When authors, categories or reviews
+// are nil they are derived from the books as for a seed.
+func ValidateDataset(data *Dataset) error {
+	if err := ValidateBooks(data.Books); err != nil {
+		return err
+	}
+	if data.Authors == nil {
+		data.Authors = GetInitialAuthors(data.Books)
+	}
+	if data.Categories == nil {
+		data.Categories = GetInitialCategories(data.Books)
+	}
+	if data.Reviews == nil {
+		data.Reviews = GetInitialReviews(data.Books)
+	}
+
+	if err := checkRecordIDs("author", len(data.Authors), func(i int) int { return data.Authors[i].ID }); err != nil {
+		return err
+	}
+	if err := checkRecordIDs("category", len(data.Categories), func(i int) int { return data.Categories[i].ID }); err != nil {
+		return err
+	}
+	if err := checkRecordIDs("review", len(data.Reviews), func(i int) int { return data.Reviews[i].ID }); err != nil {
+		return err
+	}
+
+	books := make(map[int]bool, len(data.Books))
+	for _, book := range data.Books {
+		books[book.ID] = true
+	}
+	for i, review := range data.Reviews {
+		if !books[review.BookID] {
+			return fmt.Errorf("dataset: review %d: %w", i+1, NewBookNotFoundError(strconv.Itoa(review.BookID)))
+		}
+		if review.Rating < 1 || review.Rating > 5 {
+			return fmt.Errorf("dataset: review %d: %w", i+1, NewInvalidRatingError(review.Rating))
+		}
+	}
+	return nil
+}
+
+// checkRecordIDs checks that the n IDs returned by id are positive and unique.
+func checkRecordIDs(kind string, n int, id func(i int) int) error {
+	seen := make(map[int]bool, n)
+	for i := range n {
+		if id(i) <= 0 {
+			return fmt.Errorf("dataset: %s %d: id should be positive", kind, i+1)
+		}
+		if seen[id(i)] {
+			return fmt.Errorf("dataset: %s %d: id %d is used more than once", kind, i+1, id(i))
+		}
+		seen[id(i)] = true
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package mockapi
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func checkSeedBooks(t *testing.T, books []Book) {
+	t.Helper()
+	if len(books) != 2 {
+		t.Fatalf("Expected 2 books, got %d", len(books))
+	}
+	if books[0].ID != 7 || books[0].Title != "Seed One" || books[0].Author != "Author One" || books[0].Category != "Fiction" {
+		t.Errorf("Unexpected first book %+v", books[0])
+	}
+	// Missing IDs are numbered after the highest given ID
+	if books[1].ID != 8 || books[1].Title != "Seed Two" {
+		t.Errorf("Unexpected second book %+v", books[1])
+	}
+}
+
+func TestLoadBooks_JSON(t *testing.T) {
+	data := `[
+		{"id": 7, "title": "Seed One", "author": "Author One", "category": "Fiction", "cover_url": "https://example.com/one.jpg"},
+		{"title": "Seed Two", "author": "Author Two"}
+	]`
+	books, err := LoadBooks(strings.NewReader(data), SeedFormatJSON)
+	if err != nil {
+		t.Fatalf("LoadBooks failed: %v", err)
+	}
+	checkSeedBooks(t, books)
+	if books[0].CoverURL != "https://example.com/one.jpg" {
+		t.Errorf("Expected cover URL to be kept, got %s", books[0].CoverURL)
+	}
+}
+
+func TestLoadBooks_YAML(t *testing.T) {
+	data := `
+- id: 7
+  title: Seed One
+  author: Author One
+  category: Fiction
+  cover_url: clean-code.jpg
+- title: Seed Two
+  author: Author Two
+`
+	books, err := LoadBooks(strings.NewReader(data), SeedFormatYAML)
+	if err != nil {
+		t.Fatalf("LoadBooks failed: %v", err)
+	}
+	checkSeedBooks(t, books)
+	if books[0].CoverURL != GetCoverURL("clean-code.jpg") {
+		t.Errorf("Expected bare file name to refer to an embedded cover, got %s", books[0].CoverURL)
+	}
+}
+
+func TestLoadBooks_CSV(t *testing.T) {
+	data := "id,title,author,category\n7,Seed One,Author One,Fiction\n,Seed Two,Author Two,\n"
+	books, err := LoadBooks(strings.NewReader(data), SeedFormatCSV)
+	if err != nil {
+		t.Fatalf("LoadBooks failed: %v", err)
+	}
+	checkSeedBooks(t, books)
+}
+
+func TestLoadBooks_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format string
+	}{
+		{"unknown format", `[]`, "xml"},
+		{"missing title", `[{"author": "A"}]`, SeedFormatJSON},
+		{"missing author", "title\nOnly Title\n", SeedFormatCSV},
+		{"duplicate id", `[{"id": 1, "title": "A", "author": "A"}, {"id": 1, "title": "B", "author": "B"}]`, SeedFormatJSON},
+		{"negative id", "- id: -1\n  title: A\n  author: A\n", SeedFormatYAML},
+		{"unknown JSON field", `[{"title": "A", "author": "A", "isbn": "1"}]`, SeedFormatJSON},
+		{"unknown YAML field", "- title: A\n  author: A\n  isbn: 1\n", SeedFormatYAML},
+		{"unknown CSV column", "title,author,isbn\nA,A,1\n", SeedFormatCSV},
+		{"non-integer CSV id", "id,title,author\none,A,A\n", SeedFormatCSV},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadBooks(strings.NewReader(tt.data), tt.format); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadBooksFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"seed/books.yml": {Data: []byte("- title: A\n  author: B\n")},
+		"seed/books.txt": {Data: []byte("A,B\n")},
+	}
+
+	books, err := LoadBooksFS(fsys, "seed/books.yml")
+	if err != nil {
+		t.Fatalf("LoadBooksFS failed: %v", err)
+	}
+	if len(books) != 1 || books[0].ID != 1 {
+		t.Errorf("Expected one book with ID 1, got %+v", books)
+	}
+
+	if _, err := LoadBooksFS(fsys, "seed/books.txt"); err == nil {
+		t.Error("Expected error for unsupported extension")
+	}
+	if _, err := LoadBooksFS(fsys, "seed/missing.json"); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestLoadBooksFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "books.json")
+	if err := os.WriteFile(name, []byte(`[{"title": "A", "author": "B"}]`), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	books, err := LoadBooksFile(name)
+	if err != nil {
+		t.Fatalf("LoadBooksFile failed: %v", err)
+	}
+	if len(books) != 1 || books[0].Title != "A" {
+		t.Errorf("Expected one book titled A, got %+v", books)
+	}
+}
+
+func TestValidateDataset(t *testing.T) {
+	data := Dataset{Books: []Book{{Title: "A", Author: "B", Category: "C"}}}
+	if err := ValidateDataset(&data); err != nil {
+		t.Fatalf("ValidateDataset failed: %v", err)
+	}
+	if data.Books[0].ID != 1 || len(data.Authors) != 1 || len(data.Categories) != 1 || len(data.Reviews) != 2 {
+		t.Errorf("Expected related records derived from the books, got %+v", data)
+	}
+
+	invalid := []Dataset{
+		{Books: []Book{{ID: 1, Title: "A"}}},
+		{Books: []Book{{ID: 1, Title: "A", Author: "B"}}, Authors: []Author{{ID: 1, Name: "B"}, {ID: 1, Name: "C"}}},
+		{Books: []Book{{ID: 1, Title: "A", Author: "B"}}, Categories: []Category{{Name: "C"}}},
+		{Books: []Book{{ID: 1, Title: "A", Author: "B"}}, Reviews: []Review{{ID: 1, BookID: 2, Rating: 5}}},
+		{Books: []Book{{ID: 1, Title: "A", Author: "B"}}, Reviews: []Review{{ID: 1, BookID: 1, Rating: 9}}},
+	}
+	for _, data := range invalid {
+		if err := ValidateDataset(&data); err == nil {
+			t.Errorf("Expected error for dataset %+v", data)
+		}
+	}
+}
@@ -6,7 +6,20 @@ type service struct {
 
 type Service interface {
 	GetBookByID(id string) (Book, error)
-	GetBooks(page int, pageSize int, search string) (PaginatedBooks, error)
+	GetBooks(query BookQuery) (PaginatedBooks, error)
+	GetBooksByCursor(query BookQuery, cursor string) (CursorBooks, error)
+	CreateBook(book Book) (Book, error)
+	UpdateBook(id string, book Book) (Book, error)
+	PatchBook(id string, patch BookPatch) (Book, error)
+	DeleteBook(id string) error
+	GetAuthors() ([]Author, error)
+	GetAuthorByID(id string) (Author, error)
+	GetAuthorBooks(id string, query BookQuery) (PaginatedBooks, error)
+	GetCategories() ([]Category, error)
+	GetCategoryByID(id string) (Category, error)
+	GetCategoryBooks(id string, query BookQuery) (PaginatedBooks, error)
+	GetBookReviews(bookID string) ([]Review, error)
+	CreateReview(bookID string, review Review) (Review, error)
 }
 
 func NewService(dataSource DataSource) Service {
@@ -15,21 +28,223 @@ func NewService(dataSource DataSource) Service {
 	}
 }
 
+// withCoverURL fills in the cover URL of a book read from the data source, see
+// BookCoverURL.
+func withCoverURL(book Book, err error) (Book, error) {
+	if err != nil {
+		return book, err
+	}
+	book.CoverURL = BookCoverURL(book)
+	return book, nil
+}
+
+// withCoverURLs is withCoverURL for a list of books. The books are copied, as
+// the data source may share them.
+func withCoverURLs(books []Book) []Book {
+	result := make([]Book, len(books))
+	for i, book := range books {
+		book.CoverURL = BookCoverURL(book)
+		result[i] = book
+	}
+	return result
+}
+
 func (s *service) GetBookByID(id string) (Book, error) {
-	return s.dataSource.GetBookByID(id)
+	return withCoverURL(s.dataSource.GetBookByID(id))
 }
 
-func (s *service) GetBooks(page int, pageSize int, search string) (PaginatedBooks, error) {
-	books, err := s.dataSource.GetBooks(page, pageSize, search)
-	totalCount, err := s.dataSource.GetBooksCount(search)
+func (s *service) GetBooks(query BookQuery) (PaginatedBooks, error) {
+	if err := query.Validate(); err != nil {
+		return PaginatedBooks{}, err
+	}
+	query = query.WithDefaults()
+	books, err := s.dataSource.GetBooks(query)
+	if err != nil {
+		return PaginatedBooks{}, err
+	}
+	totalCount, err := s.dataSource.GetBooksCount(query)
 	if err != nil {
 		return PaginatedBooks{}, err
 	}
 	return PaginatedBooks{
-		Data:       books,
+		Data:       withCoverURLs(books),
 		TotalItems: totalCount,
-		TotalPages: int((totalCount + int64(pageSize) - 1) / int64(pageSize)),
-		Page:       page,
-		PageSize:   pageSize,
+		TotalPages: int((totalCount + int64(query.PageSize) - 1) / int64(query.PageSize)),
+		Page:       query.Page,
+		PageSize:   query.PageSize,
 	}, nil
-}
\ No newline at end of file
+}
+
+func reverseBooks(books []Book) {
+	for i, j := 0, len(books)-1; i < j; i, j = i+1, j-1 {
+		books[i], books[j] = books[j], books[i]
+	}
+}
+
+func (s *service) GetBooksByCursor(query BookQuery, token string) (CursorBooks, error) {
+	// Cursor pages are not numbered.
+	query.Page = 1
+	if err := query.Validate(); err != nil {
+		return CursorBooks{}, err
+	}
+	query = query.WithDefaults()
+	pageSize := query.PageSize
+
+	var cursor *BookCursor
+	if token != "" {
+		c, err := DecodeBookCursor(token)
+		if err != nil {
+			return CursorBooks{}, err
+		}
+		if !c.Matches(query) {
+			return CursorBooks{}, NewCursorSortMismatchError()
+		}
+		cursor = &c
+	}
+
+	// Fetch one extra book to find out whether there is another page.
+	query.PageSize = pageSize + 1
+	books, err := s.dataSource.GetBooksByCursor(query, cursor)
+	if err != nil {
+		return CursorBooks{}, err
+	}
+	hasMore := len(books) > pageSize
+	if hasMore {
+		books = books[:pageSize]
+	}
+
+	books = withCoverURLs(books)
+	result := CursorBooks{
+		Data:     books,
+		PageSize: pageSize,
+	}
+	if cursor != nil && cursor.Before {
+		reverseBooks(books)
+		if hasMore {
+			result.PrevCursor = NewBookCursor(query, books[0], true).Encode()
+		}
+		if len(books) > 0 {
+			result.NextCursor = NewBookCursor(query, books[len(books)-1], false).Encode()
+		} else {
+			result.NextCursor = cursor.reversed().Encode()
+		}
+		return result, nil
+	}
+
+	if hasMore {
+		result.NextCursor = NewBookCursor(query, books[len(books)-1], false).Encode()
+	}
+	if cursor != nil {
+		if len(books) > 0 {
+			result.PrevCursor = NewBookCursor(query, books[0], true).Encode()
+		} else {
+			result.PrevCursor = cursor.reversed().Encode()
+		}
+	}
+	return result, nil
+}
+
+func validateBook(book Book) error {
+	if book.Title == "" {
+		return NewRequiredFieldError("title")
+	}
+	if book.Author == "" {
+		return NewRequiredFieldError("author")
+	}
+	return nil
+}
+
+func (s *service) CreateBook(book Book) (Book, error) {
+	if err := validateBook(book); err != nil {
+		return Book{}, err
+	}
+	book.ID = 0
+	return withCoverURL(s.dataSource.CreateBook(book))
+}
+
+func (s *service) UpdateBook(id string, book Book) (Book, error) {
+	if err := validateBook(book); err != nil {
+		return Book{}, err
+	}
+	return withCoverURL(s.dataSource.UpdateBook(id, book))
+}
+
+func (s *service) PatchBook(id string, patch BookPatch) (Book, error) {
+	if patch.Title != nil && *patch.Title == "" {
+		return Book{}, NewRequiredFieldError("title")
+	}
+	if patch.Author != nil && *patch.Author == "" {
+		return Book{}, NewRequiredFieldError("author")
+	}
+	return withCoverURL(s.dataSource.PatchBook(id, patch))
+}
+
+func (s *service) DeleteBook(id string) error {
+	return s.dataSource.DeleteBook(id)
+}
+
+func (s *service) GetAuthors() ([]Author, error) {
+	return s.dataSource.GetAuthors()
+}
+
+func (s *service) GetAuthorByID(id string) (Author, error) {
+	return s.dataSource.GetAuthorByID(id)
+}
+
+// GetAuthorBooks lists the books of an author, replacing any author filter in query.
+func (s *service) GetAuthorBooks(id string, query BookQuery) (PaginatedBooks, error) {
+	author, err := s.dataSource.GetAuthorByID(id)
+	if err != nil {
+		return PaginatedBooks{}, err
+	}
+	query.Author = []string{author.Name}
+	return s.GetBooks(query)
+}
+
+func (s *service) GetCategories() ([]Category, error) {
+	return s.dataSource.GetCategories()
+}
+
+func (s *service) GetCategoryByID(id string) (Category, error) {
+	return s.dataSource.GetCategoryByID(id)
+}
+
+// GetCategoryBooks lists the books of a category, replacing any category filter in query.
+func (s *service) GetCategoryBooks(id string, query BookQuery) (PaginatedBooks, error) {
+	category, err := s.dataSource.GetCategoryByID(id)
+	if err != nil {
+		return PaginatedBooks{}, err
+	}
+	query.Category = []string{category.Name}
+	return s.GetBooks(query)
+}
+
+func (s *service) GetBookReviews(bookID string) ([]Review, error) {
+	if _, err := s.dataSource.GetBookByID(bookID); err != nil {
+		return nil, err
+	}
+	return s.dataSource.GetReviews(bookID)
+}
+
+func validateReview(review Review) error {
+	if review.Reviewer == "" {
+		return NewRequiredFieldError("reviewer")
+	}
+	if review.Rating < 1 || review.Rating > 5 {
+		return NewInvalidRatingError(review.Rating)
+	}
+	return nil
+}
+
+func (s *service) CreateReview(bookID string, review Review) (Review, error) {
+	if err := validateReview(review); err != nil {
+		return Review{}, err
+	}
+	book, err := s.dataSource.GetBookByID(bookID)
+	if err != nil {
+		return Review{}, err
+	}
+	review.ID = 0
+	review.BookID = book.ID
+	return s.dataSource.CreateReview(review)
+}
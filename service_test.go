@@ -6,10 +6,21 @@ import (
 )
 
 type mockDataSource struct {
-	populateDataFunc  func() error
-	getBookByIDFunc   func(id string) (Book, error)
-	getBooksFunc      func(page int, pageSize int, search string) ([]Book, error)
-	getBooksCountFunc func(search string) (int64, error)
+	populateDataFunc     func() error
+	getBookByIDFunc      func(id string) (Book, error)
+	getBooksFunc         func(query BookQuery) ([]Book, error)
+	getBooksCountFunc    func(query BookQuery) (int64, error)
+	getBooksByCursorFunc func(query BookQuery, cursor *BookCursor) ([]Book, error)
+	createBookFunc       func(book Book) (Book, error)
+	updateBookFunc       func(id string, book Book) (Book, error)
+	patchBookFunc        func(id string, patch BookPatch) (Book, error)
+	deleteBookFunc       func(id string) error
+	getAuthorsFunc       func() ([]Author, error)
+	getAuthorByIDFunc    func(id string) (Author, error)
+	getCategoriesFunc    func() ([]Category, error)
+	getCategoryByIDFunc  func(id string) (Category, error)
+	getReviewsFunc       func(bookID string) ([]Review, error)
+	createReviewFunc     func(review Review) (Review, error)
 }
 
 func (m *mockDataSource) PopulateData() error {
@@ -26,20 +37,97 @@ func (m *mockDataSource) GetBookByID(id string) (Book, error) {
 	return Book{}, nil
 }
 
-func (m *mockDataSource) GetBooks(page int, pageSize int, search string) ([]Book, error) {
+func (m *mockDataSource) GetBooks(query BookQuery) ([]Book, error) {
 	if m.getBooksFunc != nil {
-		return m.getBooksFunc(page, pageSize, search)
+		return m.getBooksFunc(query)
 	}
 	return []Book{}, nil
 }
 
-func (m *mockDataSource) GetBooksCount(search string) (int64, error) {
+func (m *mockDataSource) GetBooksCount(query BookQuery) (int64, error) {
 	if m.getBooksCountFunc != nil {
-		return m.getBooksCountFunc(search)
+		return m.getBooksCountFunc(query)
 	}
 	return 0, nil
 }
 
+func (m *mockDataSource) GetBooksByCursor(query BookQuery, cursor *BookCursor) ([]Book, error) {
+	if m.getBooksByCursorFunc != nil {
+		return m.getBooksByCursorFunc(query, cursor)
+	}
+	return []Book{}, nil
+}
+
+func (m *mockDataSource) CreateBook(book Book) (Book, error) {
+	if m.createBookFunc != nil {
+		return m.createBookFunc(book)
+	}
+	return book, nil
+}
+
+func (m *mockDataSource) UpdateBook(id string, book Book) (Book, error) {
+	if m.updateBookFunc != nil {
+		return m.updateBookFunc(id, book)
+	}
+	return book, nil
+}
+
+func (m *mockDataSource) PatchBook(id string, patch BookPatch) (Book, error) {
+	if m.patchBookFunc != nil {
+		return m.patchBookFunc(id, patch)
+	}
+	return Book{}, nil
+}
+
+func (m *mockDataSource) DeleteBook(id string) error {
+	if m.deleteBookFunc != nil {
+		return m.deleteBookFunc(id)
+	}
+	return nil
+}
+
+func (m *mockDataSource) GetAuthors() ([]Author, error) {
+	if m.getAuthorsFunc != nil {
+		return m.getAuthorsFunc()
+	}
+	return []Author{}, nil
+}
+
+func (m *mockDataSource) GetAuthorByID(id string) (Author, error) {
+	if m.getAuthorByIDFunc != nil {
+		return m.getAuthorByIDFunc(id)
+	}
+	return Author{}, nil
+}
+
+func (m *mockDataSource) GetCategories() ([]Category, error) {
+	if m.getCategoriesFunc != nil {
+		return m.getCategoriesFunc()
+	}
+	return []Category{}, nil
+}
+
+func (m *mockDataSource) GetCategoryByID(id string) (Category, error) {
+	if m.getCategoryByIDFunc != nil {
+		return m.getCategoryByIDFunc(id)
+	}
+	return Category{}, nil
+}
+
+func (m *mockDataSource) GetReviews(bookID string) ([]Review, error) {
+	if m.getReviewsFunc != nil {
+		return m.getReviewsFunc(bookID)
+	}
+	return []Review{}, nil
+}
+
+func (m *mockDataSource) CreateReview(review Review) (Review, error) {
+	if m.createReviewFunc != nil {
+		return m.createReviewFunc(review)
+	}
+	return review, nil
+}
+
 func TestNewService(t *testing.T) {
 	ds := &mockDataSource{}
 	svc := NewService(ds)
@@ -109,16 +197,16 @@ func TestService_GetBooks_Success(t *testing.T) {
 	}
 
 	ds := &mockDataSource{
-		getBooksFunc: func(page int, pageSize int, search string) ([]Book, error) {
+		getBooksFunc: func(query BookQuery) ([]Book, error) {
 			return expectedBooks, nil
 		},
-		getBooksCountFunc: func(search string) (int64, error) {
+		getBooksCountFunc: func(query BookQuery) (int64, error) {
 			return 2, nil
 		},
 	}
 
 	svc := NewService(ds)
-	result, err := svc.GetBooks(1, 10, "")
+	result, err := svc.GetBooks(BookQuery{Page: 1, PageSize: 10})
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -147,14 +235,14 @@ func TestService_GetBooks_WithSearch(t *testing.T) {
 	}
 
 	ds := &mockDataSource{
-		getBooksFunc: func(page int, pageSize int, search string) ([]Book, error) {
-			if search == "Go" {
+		getBooksFunc: func(query BookQuery) ([]Book, error) {
+			if query.Search == "Go" {
 				return expectedBooks, nil
 			}
 			return []Book{}, nil
 		},
-		getBooksCountFunc: func(search string) (int64, error) {
-			if search == "Go" {
+		getBooksCountFunc: func(query BookQuery) (int64, error) {
+			if query.Search == "Go" {
 				return 1, nil
 			}
 			return 0, nil
@@ -162,7 +250,7 @@ func TestService_GetBooks_WithSearch(t *testing.T) {
 	}
 
 	svc := NewService(ds)
-	result, err := svc.GetBooks(1, 10, "Go")
+	result, err := svc.GetBooks(BookQuery{Page: 1, PageSize: 10, Search: "Go"})
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -178,16 +266,16 @@ func TestService_GetBooks_WithSearch(t *testing.T) {
 
 func TestService_GetBooks_Pagination(t *testing.T) {
 	ds := &mockDataSource{
-		getBooksFunc: func(page int, pageSize int, search string) ([]Book, error) {
+		getBooksFunc: func(query BookQuery) ([]Book, error) {
 			return []Book{}, nil
 		},
-		getBooksCountFunc: func(search string) (int64, error) {
+		getBooksCountFunc: func(query BookQuery) (int64, error) {
 			return 25, nil
 		},
 	}
 
 	svc := NewService(ds)
-	result, err := svc.GetBooks(1, 10, "")
+	result, err := svc.GetBooks(BookQuery{Page: 1, PageSize: 10})
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -201,18 +289,372 @@ func TestService_GetBooks_Pagination(t *testing.T) {
 func TestService_GetBooks_Error(t *testing.T) {
 	expectedError := errors.New("database error")
 	ds := &mockDataSource{
-		getBooksFunc: func(page int, pageSize int, search string) ([]Book, error) {
+		getBooksFunc: func(query BookQuery) ([]Book, error) {
 			return []Book{}, expectedError
 		},
-		getBooksCountFunc: func(search string) (int64, error) {
+		getBooksCountFunc: func(query BookQuery) (int64, error) {
 			return 0, expectedError
 		},
 	}
 
 	svc := NewService(ds)
-	_, err := svc.GetBooks(1, 10, "")
+	_, err := svc.GetBooks(BookQuery{Page: 1, PageSize: 10})
 
 	if err == nil {
 		t.Fatal("Expected an error, got nil")
 	}
 }
+
+func TestService_GetBooks_DefaultSort(t *testing.T) {
+	var gotQuery BookQuery
+	ds := &mockDataSource{
+		getBooksFunc: func(query BookQuery) ([]Book, error) {
+			gotQuery = query
+			return []Book{}, nil
+		},
+	}
+
+	svc := NewService(ds)
+	if _, err := svc.GetBooks(BookQuery{Page: 1, PageSize: 10}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if gotQuery.Sort != "id" || gotQuery.Order != OrderAsc {
+		t.Errorf("Expected default sort id asc, got %s %s", gotQuery.Sort, gotQuery.Order)
+	}
+}
+
+func TestService_GetBooks_InvalidSort(t *testing.T) {
+	ds := &mockDataSource{
+		getBooksFunc: func(query BookQuery) ([]Book, error) {
+			t.Error("Expected data source not to be called")
+			return []Book{}, nil
+		},
+	}
+
+	svc := NewService(ds)
+	_, err := svc.GetBooks(BookQuery{Page: 1, PageSize: 10, Sort: "price"})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Expected ValidationError, got %v", err)
+	}
+
+	_, err = svc.GetBooks(BookQuery{Page: 1, PageSize: 10, Sort: "title", Order: "up"})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Expected ValidationError, got %v", err)
+	}
+}
+
+func TestService_GetBooks_InvalidPage(t *testing.T) {
+	ds := &mockDataSource{
+		getBooksFunc: func(query BookQuery) ([]Book, error) {
+			t.Error("Expected data source not to be called")
+			return []Book{}, nil
+		},
+	}
+
+	svc := NewService(ds)
+	for _, query := range []BookQuery{{Page: 0, PageSize: 10}, {Page: 1, PageSize: 0}, {Page: 1, PageSize: -5}} {
+		if _, err := svc.GetBooks(query); !errors.As(err, new(*ValidationError)) {
+			t.Errorf("Expected ValidationError for %+v, got %v", query, err)
+		}
+	}
+}
+
+func TestService_CreateBook_Success(t *testing.T) {
+	ds := &mockDataSource{
+		createBookFunc: func(book Book) (Book, error) {
+			if book.ID != 0 {
+				t.Errorf("Expected ID to be reset to 0, got %d", book.ID)
+			}
+			book.ID = 51
+			return book, nil
+		},
+	}
+
+	svc := NewService(ds)
+	book, err := svc.CreateBook(Book{ID: 7, Title: "New Book", Author: "New Author"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if book.ID != 51 {
+		t.Errorf("Expected book ID 51, got %d", book.ID)
+	}
+	if book.CoverURL != GetPlaceholderURL(51) {
+		t.Errorf("Expected placeholder cover %s, got %s", GetPlaceholderURL(51), book.CoverURL)
+	}
+}
+
+func TestService_CreateBook_MissingTitle(t *testing.T) {
+	ds := &mockDataSource{
+		createBookFunc: func(book Book) (Book, error) {
+			t.Error("Expected CreateBook not to reach the data source")
+			return book, nil
+		},
+	}
+
+	svc := NewService(ds)
+	_, err := svc.CreateBook(Book{Author: "New Author"})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+	if validationErr.StatusCode() != 400 {
+		t.Errorf("Expected status code 400, got %d", validationErr.StatusCode())
+	}
+}
+
+func TestService_UpdateBook_MissingAuthor(t *testing.T) {
+	svc := NewService(&mockDataSource{})
+	_, err := svc.UpdateBook("1", Book{Title: "Title"})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+}
+
+func TestService_UpdateBook_Success(t *testing.T) {
+	ds := &mockDataSource{
+		updateBookFunc: func(id string, book Book) (Book, error) {
+			if id != "1" {
+				t.Errorf("Expected id 1, got %s", id)
+			}
+			book.ID = 1
+			return book, nil
+		},
+	}
+
+	svc := NewService(ds)
+	book, err := svc.UpdateBook("1", Book{Title: "Title", Author: "Author"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if book.ID != 1 {
+		t.Errorf("Expected book ID 1, got %d", book.ID)
+	}
+}
+
+func TestService_PatchBook_EmptyTitle(t *testing.T) {
+	svc := NewService(&mockDataSource{})
+	empty := ""
+	_, err := svc.PatchBook("1", BookPatch{Title: &empty})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+}
+
+func TestService_PatchBook_Success(t *testing.T) {
+	desc := "Updated description"
+	ds := &mockDataSource{
+		patchBookFunc: func(id string, patch BookPatch) (Book, error) {
+			book := Book{ID: 1, Title: "Title", Author: "Author"}
+			patch.Apply(&book)
+			return book, nil
+		},
+	}
+
+	svc := NewService(ds)
+	book, err := svc.PatchBook("1", BookPatch{Desc: &desc})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if book.Desc != desc {
+		t.Errorf("Expected desc %s, got %s", desc, book.Desc)
+	}
+}
+
+func TestService_DeleteBook_Error(t *testing.T) {
+	ds := &mockDataSource{
+		deleteBookFunc: func(id string) error {
+			return NewBookNotFoundError(id)
+		},
+	}
+
+	svc := NewService(ds)
+	err := svc.DeleteBook("99")
+
+	var notFoundErr *NotFoundError
+	if !errors.As(err, &notFoundErr) {
+		t.Fatalf("Expected NotFoundError, got %v", err)
+	}
+}
+
+// idCursorDataSource pages through books sorted by ascending ID.
+func idCursorDataSource(books []Book) *mockDataSource {
+	return &mockDataSource{
+		getBooksByCursorFunc: func(query BookQuery, cursor *BookCursor) ([]Book, error) {
+			result := []Book{}
+			if cursor != nil && cursor.Before {
+				for i := len(books) - 1; i >= 0; i-- {
+					if books[i].ID < cursor.ID {
+						result = append(result, books[i])
+					}
+				}
+			} else {
+				for _, book := range books {
+					if cursor == nil || book.ID > cursor.ID {
+						result = append(result, book)
+					}
+				}
+			}
+			if len(result) > query.PageSize {
+				result = result[:query.PageSize]
+			}
+			return result, nil
+		},
+	}
+}
+
+func TestService_GetBooksByCursor_Pages(t *testing.T) {
+	books := []Book{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}}
+	svc := NewService(idCursorDataSource(books))
+
+	first, err := svc.GetBooksByCursor(BookQuery{PageSize: 2}, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(first.Data) != 2 || first.Data[0].ID != 1 || first.PrevCursor != "" || first.NextCursor == "" {
+		t.Fatalf("Unexpected first page %+v", first)
+	}
+
+	second, err := svc.GetBooksByCursor(BookQuery{PageSize: 2}, first.NextCursor)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(second.Data) != 2 || second.Data[0].ID != 3 || second.PrevCursor == "" || second.NextCursor == "" {
+		t.Fatalf("Unexpected second page %+v", second)
+	}
+
+	last, _ := svc.GetBooksByCursor(BookQuery{PageSize: 2}, second.NextCursor)
+	if len(last.Data) != 1 || last.Data[0].ID != 5 || last.NextCursor != "" {
+		t.Fatalf("Unexpected last page %+v", last)
+	}
+
+	back, _ := svc.GetBooksByCursor(BookQuery{PageSize: 2}, last.PrevCursor)
+	if len(back.Data) != 2 || back.Data[0].ID != 3 || back.Data[1].ID != 4 {
+		t.Fatalf("Expected to page back to books 3 and 4, got %+v", back.Data)
+	}
+
+	start, _ := svc.GetBooksByCursor(BookQuery{PageSize: 2}, back.PrevCursor)
+	if len(start.Data) != 2 || start.Data[0].ID != 1 || start.PrevCursor != "" || start.NextCursor == "" {
+		t.Fatalf("Unexpected page at the start %+v", start)
+	}
+}
+
+func TestService_GetBooksByCursor_InvalidCursor(t *testing.T) {
+	svc := NewService(idCursorDataSource(nil))
+
+	_, err := svc.GetBooksByCursor(BookQuery{PageSize: 2}, "not a cursor")
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Expected ValidationError, got %v", err)
+	}
+
+	token := NewBookCursor(BookQuery{}, Book{ID: 1}, false).Encode()
+	_, err = svc.GetBooksByCursor(BookQuery{PageSize: 2, Sort: "title"}, token)
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Expected ValidationError for mismatched sort, got %v", err)
+	}
+
+	_, err = svc.GetBooksByCursor(BookQuery{PageSize: 0}, "")
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Expected ValidationError for page size 0, got %v", err)
+	}
+}
+
+func TestService_GetAuthorBooks(t *testing.T) {
+	ds := &mockDataSource{
+		getAuthorByIDFunc: func(id string) (Author, error) {
+			return Author{ID: 3, Name: "Martin Fowler"}, nil
+		},
+		getBooksFunc: func(query BookQuery) ([]Book, error) {
+			if len(query.Author) != 1 || query.Author[0] != "Martin Fowler" {
+				t.Errorf("Expected author filter [Martin Fowler], got %v", query.Author)
+			}
+			return []Book{{ID: 7, Author: "Martin Fowler"}}, nil
+		},
+		getBooksCountFunc: func(query BookQuery) (int64, error) {
+			return 1, nil
+		},
+	}
+	svc := NewService(ds)
+
+	result, err := svc.GetAuthorBooks("3", BookQuery{Page: 1, PageSize: 10, Author: []string{"Someone Else"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Data) != 1 || result.TotalItems != 1 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+}
+
+func TestService_GetCategoryBooks_NotFound(t *testing.T) {
+	ds := &mockDataSource{
+		getCategoryByIDFunc: func(id string) (Category, error) {
+			return Category{}, NewCategoryNotFoundError(id)
+		},
+		getBooksFunc: func(query BookQuery) ([]Book, error) {
+			t.Error("Expected GetBooks not to be called")
+			return nil, nil
+		},
+	}
+	svc := NewService(ds)
+
+	_, err := svc.GetCategoryBooks("99", BookQuery{Page: 1, PageSize: 10})
+	if _, ok := err.(*NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %v", err)
+	}
+}
+
+func TestService_GetBookReviews_BookNotFound(t *testing.T) {
+	ds := &mockDataSource{
+		getBookByIDFunc: func(id string) (Book, error) {
+			return Book{}, NewBookNotFoundError(id)
+		},
+	}
+	svc := NewService(ds)
+
+	_, err := svc.GetBookReviews("99")
+	if _, ok := err.(*NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %v", err)
+	}
+}
+
+func TestService_CreateReview(t *testing.T) {
+	ds := &mockDataSource{
+		getBookByIDFunc: func(id string) (Book, error) {
+			return Book{ID: 5}, nil
+		},
+		createReviewFunc: func(review Review) (Review, error) {
+			if review.ID != 0 || review.BookID != 5 {
+				t.Errorf("Expected ID 0 and book ID 5, got %d and %d", review.ID, review.BookID)
+			}
+			review.ID = 101
+			return review, nil
+		},
+	}
+	svc := NewService(ds)
+
+	review, err := svc.CreateReview("5", Review{ID: 9, BookID: 8, Reviewer: "Alice", Rating: 4})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if review.ID != 101 {
+		t.Errorf("Expected ID 101, got %d", review.ID)
+	}
+
+	for _, invalid := range []Review{
+		{Rating: 4},
+		{Reviewer: "Alice", Rating: 0},
+		{Reviewer: "Alice", Rating: 6},
+	} {
+		if _, err := svc.CreateReview("5", invalid); err == nil {
+			t.Errorf("Expected validation error for %+v", invalid)
+		}
+	}
+}
@@ -0,0 +1,385 @@
+package mockapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ResponseStyle is the envelope that book responses are rendered in.
+type ResponseStyle string
+
+const (
+	// ResponseStylePlain renders Book, PaginatedBooks and CursorBooks as
+	// they are. It is the default.
+	ResponseStylePlain ResponseStyle = "plain"
+	// ResponseStyleJSONAPI renders books as JSON:API documents, see
+	// https://jsonapi.org.
+	ResponseStyleJSONAPI ResponseStyle = "jsonapi"
+	// ResponseStyleHAL renders books as HAL resources, see
+	// https://datatracker.ietf.org/doc/html/draft-kelly-json-hal.
+	ResponseStyleHAL ResponseStyle = "hal"
+)
+
+// ParseResponseStyle returns the ResponseStyle named name. An empty name is
+// ResponseStylePlain.
+func ParseResponseStyle(name string) (ResponseStyle, error) {
+	switch style := ResponseStyle(name); style {
+	case "":
+		return ResponseStylePlain, nil
+	case ResponseStylePlain, ResponseStyleJSONAPI, ResponseStyleHAL:
+		return style, nil
+	}
+	return "", NewInvalidResponseStyleError(name)
+}
+
+// IsPlain reports whether s renders responses as they are.
+func (s ResponseStyle) IsPlain() bool {
+	return s == "" || s == ResponseStylePlain
+}
+
+// ContentType returns the Content-Type of responses in style s.
+func (s ResponseStyle) ContentType() string {
+	switch s {
+	case ResponseStyleJSONAPI:
+		return "application/vnd.api+json"
+	case ResponseStyleHAL:
+		return "application/hal+json"
+	}
+	return "application/json; charset=utf-8"
+}
+
+// Render returns data, a Book, PaginatedBooks, CursorBooks or APIError, in
+// style s. self is the URL of the request, which the links between pages are
+// derived from. The authors and categories of books are looked up through
+// service to link and include them.
+func (s ResponseStyle) Render(service Service, self *url.URL, data any) (any, error) {
+	if s.IsPlain() {
+		return data, nil
+	}
+	if s != ResponseStyleJSONAPI && s != ResponseStyleHAL {
+		return nil, NewInvalidResponseStyleError(string(s))
+	}
+	if apiErr, ok := data.(APIError); ok {
+		if s == ResponseStyleJSONAPI {
+			return jsonAPIErrors{Errors: []jsonAPIError{{
+				Status: strconv.Itoa(apiErr.StatusCode),
+				Title:  http.StatusText(apiErr.StatusCode),
+				Detail: apiErr.Message,
+			}}}, nil
+		}
+		return apiErr, nil
+	}
+
+	var books []Book
+	var links map[string]string
+	var meta any
+	switch data := data.(type) {
+	case Book:
+		books = []Book{data}
+		links = map[string]string{"self": bookURL(data.ID)}
+	case PaginatedBooks:
+		books = data.Data
+		links = pageLinks(self, data)
+		meta = pageMeta{Page: data.Page, PageSize: data.PageSize, TotalItems: data.TotalItems, TotalPages: data.TotalPages}
+	case CursorBooks:
+		books = data.Data
+		links = cursorLinks(self, data)
+		meta = cursorMeta{PageSize: data.PageSize}
+	default:
+		return nil, fmt.Errorf("style: cannot render %T", data)
+	}
+
+	related, err := newBookRelations(service)
+	if err != nil {
+		return nil, err
+	}
+	if s == ResponseStyleJSONAPI {
+		return related.jsonAPIDocument(books, links, meta), nil
+	}
+	return related.halDocument(books, links, meta), nil
+}
+
+func bookURL(id int) string {
+	return fmt.Sprintf("%s/api/books/%d", GetBaseURL(), id)
+}
+
+func authorURL(id int) string {
+	return fmt.Sprintf("%s/api/authors/%d", GetBaseURL(), id)
+}
+
+func categoryURL(id int) string {
+	return fmt.Sprintf("%s/api/categories/%d", GetBaseURL(), id)
+}
+
+// pageURL returns self with the query parameters in set changed, or removed
+// when their value is empty.
+func pageURL(self *url.URL, set map[string]string) string {
+	query := self.Query()
+	for key, value := range set {
+		if value == "" {
+			query.Del(key)
+		} else {
+			query.Set(key, value)
+		}
+	}
+	result := GetBaseURL() + self.Path
+	if encoded := query.Encode(); encoded != "" {
+		result += "?" + encoded
+	}
+	return result
+}
+
+func pageLinks(self *url.URL, page PaginatedBooks) map[string]string {
+	links := map[string]string{
+		"self":  pageURL(self, nil),
+		"first": pageURL(self, map[string]string{"page": "1"}),
+		"last":  pageURL(self, map[string]string{"page": strconv.Itoa(max(page.TotalPages, 1))}),
+	}
+	if page.Page > 1 {
+		links["prev"] = pageURL(self, map[string]string{"page": strconv.Itoa(min(page.Page-1, max(page.TotalPages, 1)))})
+	}
+	if page.Page < page.TotalPages {
+		links["next"] = pageURL(self, map[string]string{"page": strconv.Itoa(page.Page + 1)})
+	}
+	return links
+}
+
+// cursorLinks links the pages around a cursor page. There is no last link,
+// as the last page of a cursor pagination is not known.
+func cursorLinks(self *url.URL, page CursorBooks) map[string]string {
+	links := map[string]string{
+		"self":  pageURL(self, nil),
+		"first": pageURL(self, map[string]string{"pagination": "cursor", "cursor": ""}),
+	}
+	if page.NextCursor != "" {
+		links["next"] = pageURL(self, map[string]string{"cursor": page.NextCursor})
+	}
+	if page.PrevCursor != "" {
+		links["prev"] = pageURL(self, map[string]string{"cursor": page.PrevCursor})
+	}
+	return links
+}
+
+type pageMeta struct {
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	TotalItems int64 `json:"total_items"`
+	TotalPages int   `json:"total_pages"`
+}
+
+type cursorMeta struct {
+	PageSize int `json:"page_size"`
+}
+
+// bookRelations finds the author and category records of books by name.
+type bookRelations struct {
+	authors    map[string]Author
+	categories map[string]Category
+}
+
+func newBookRelations(service Service) (*bookRelations, error) {
+	authors, err := service.GetAuthors()
+	if err != nil {
+		return nil, err
+	}
+	categories, err := service.GetCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	related := &bookRelations{authors: map[string]Author{}, categories: map[string]Category{}}
+	for _, author := range authors {
+		related.authors[author.Name] = author
+	}
+	for _, category := range categories {
+		related.categories[category.Name] = category
+	}
+	return related, nil
+}
+
+type jsonAPIDocument struct {
+	Data     any               `json:"data"`
+	Included []jsonAPIResource `json:"included"`
+	Meta     any               `json:"meta,omitempty"`
+	Links    map[string]string `json:"links"`
+}
+
+type jsonAPIResource struct {
+	Type          string                         `json:"type"`
+	ID            string                         `json:"id"`
+	Attributes    any                            `json:"attributes"`
+	Relationships map[string]jsonAPIRelationship `json:"relationships,omitempty"`
+	Links         map[string]string              `json:"links"`
+}
+
+type jsonAPIResourceID struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type jsonAPIRelationship struct {
+	Data  *jsonAPIResourceID `json:"data,omitempty"`
+	Links map[string]string  `json:"links,omitempty"`
+}
+
+type jsonAPIBookAttributes struct {
+	Title    string `json:"title"`
+	Author   string `json:"author"`
+	Category string `json:"category"`
+	Desc     string `json:"desc"`
+	CoverURL string `json:"cover_url"`
+}
+
+type jsonAPINameAttributes struct {
+	Name string `json:"name"`
+}
+
+type jsonAPIErrors struct {
+	Errors []jsonAPIError `json:"errors"`
+}
+
+type jsonAPIError struct {
+	Status string `json:"status"`
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
+
+// jsonAPIDocument renders books as a JSON:API document whose primary data is
+// a single resource when there is no page meta. The authors and categories
+// of the books are included once each.
+func (related *bookRelations) jsonAPIDocument(books []Book, links map[string]string, meta any) jsonAPIDocument {
+	resources := make([]jsonAPIResource, 0, len(books))
+	included := []jsonAPIResource{}
+	seen := map[jsonAPIResourceID]bool{}
+	include := func(resource jsonAPIResource) *jsonAPIResourceID {
+		id := jsonAPIResourceID{Type: resource.Type, ID: resource.ID}
+		if !seen[id] {
+			seen[id] = true
+			included = append(included, resource)
+		}
+		return &id
+	}
+
+	for _, book := range books {
+		id := strconv.Itoa(book.ID)
+		relationships := map[string]jsonAPIRelationship{
+			"reviews": {Links: map[string]string{"related": bookURL(book.ID) + "/reviews"}},
+		}
+		if author, ok := related.authors[book.Author]; ok {
+			relationships["author"] = jsonAPIRelationship{Data: include(jsonAPIResource{
+				Type:       "authors",
+				ID:         strconv.Itoa(author.ID),
+				Attributes: jsonAPINameAttributes{Name: author.Name},
+				Links:      map[string]string{"self": authorURL(author.ID)},
+			})}
+		}
+		if category, ok := related.categories[book.Category]; ok {
+			relationships["category"] = jsonAPIRelationship{Data: include(jsonAPIResource{
+				Type:       "categories",
+				ID:         strconv.Itoa(category.ID),
+				Attributes: jsonAPINameAttributes{Name: category.Name},
+				Links:      map[string]string{"self": categoryURL(category.ID)},
+			})}
+		}
+
+		resources = append(resources, jsonAPIResource{
+			Type: "books",
+			ID:   id,
+			Attributes: jsonAPIBookAttributes{
+				Title:    book.Title,
+				Author:   book.Author,
+				Category: book.Category,
+				Desc:     book.Desc,
+				CoverURL: book.CoverURL,
+			},
+			Relationships: relationships,
+			Links:         map[string]string{"self": bookURL(book.ID)},
+		})
+	}
+
+	doc := jsonAPIDocument{Data: resources, Included: included, Meta: meta, Links: links}
+	if meta == nil {
+		doc.Data = resources[0]
+	}
+	return doc
+}
+
+type halLink struct {
+	Href string `json:"href"`
+}
+
+type halNamed struct {
+	ID    int                `json:"id"`
+	Name  string             `json:"name"`
+	Links map[string]halLink `json:"_links"`
+}
+
+type halBookEmbedded struct {
+	Author   *halNamed `json:"author,omitempty"`
+	Category *halNamed `json:"category,omitempty"`
+}
+
+type halBook struct {
+	Book
+	Links    map[string]halLink `json:"_links"`
+	Embedded halBookEmbedded    `json:"_embedded"`
+}
+
+type halBooks struct {
+	Books []halBook `json:"books"`
+}
+
+type halPage struct {
+	Links    map[string]halLink `json:"_links"`
+	Embedded halBooks           `json:"_embedded"`
+	pageMeta
+}
+
+type halCursorPage struct {
+	Links    map[string]halLink `json:"_links"`
+	Embedded halBooks           `json:"_embedded"`
+	cursorMeta
+}
+
+func halLinks(links map[string]string) map[string]halLink {
+	result := make(map[string]halLink, len(links))
+	for rel, href := range links {
+		result[rel] = halLink{Href: href}
+	}
+	return result
+}
+
+// halDocument renders books as a page embedding them, or as a single HAL
+// resource when there is no page meta. The author and category of each book
+// are embedded in it.
+func (related *bookRelations) halDocument(books []Book, links map[string]string, meta any) any {
+	resources := make([]halBook, 0, len(books))
+	for _, book := range books {
+		resource := halBook{
+			Book: book,
+			Links: halLinks(map[string]string{
+				"self":    bookURL(book.ID),
+				"reviews": bookURL(book.ID) + "/reviews",
+			}),
+		}
+		if author, ok := related.authors[book.Author]; ok {
+			resource.Links["author"] = halLink{Href: authorURL(author.ID)}
+			resource.Embedded.Author = &halNamed{ID: author.ID, Name: author.Name, Links: halLinks(map[string]string{"self": authorURL(author.ID)})}
+		}
+		if category, ok := related.categories[book.Category]; ok {
+			resource.Links["category"] = halLink{Href: categoryURL(category.ID)}
+			resource.Embedded.Category = &halNamed{ID: category.ID, Name: category.Name, Links: halLinks(map[string]string{"self": categoryURL(category.ID)})}
+		}
+		resources = append(resources, resource)
+	}
+
+	switch meta := meta.(type) {
+	case pageMeta:
+		return halPage{Links: halLinks(links), Embedded: halBooks{Books: resources}, pageMeta: meta}
+	case cursorMeta:
+		return halCursorPage{Links: halLinks(links), Embedded: halBooks{Books: resources}, cursorMeta: meta}
+	}
+	return resources[0]
+}
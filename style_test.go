@@ -0,0 +1,164 @@
+package mockapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"testing"
+)
+
+func newStyleService() Service {
+	return NewService(&mockDataSource{
+		getAuthorsFunc: func() ([]Author, error) {
+			return []Author{{ID: 7, Name: "Rob Pike"}}, nil
+		},
+		getCategoriesFunc: func() ([]Category, error) {
+			return []Category{{ID: 2, Name: "Programming"}}, nil
+		},
+	})
+}
+
+// renderStyle renders data in style and decodes the JSON it marshals to.
+func renderStyle(t *testing.T, style ResponseStyle, self string, data any) map[string]any {
+	t.Helper()
+	u, _ := url.Parse(self)
+	doc, err := style.Render(newStyleService(), u, data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	encoded, _ := json.Marshal(doc)
+	var result map[string]any
+	json.Unmarshal(encoded, &result)
+	return result
+}
+
+func TestParseResponseStyle(t *testing.T) {
+	for name, expected := range map[string]ResponseStyle{"": ResponseStylePlain, "plain": ResponseStylePlain, "jsonapi": ResponseStyleJSONAPI, "hal": ResponseStyleHAL} {
+		if style, err := ParseResponseStyle(name); err != nil || style != expected {
+			t.Errorf("Expected style %s for %q, got %s, %v", expected, name, style, err)
+		}
+	}
+
+	var validationErr *ValidationError
+	if _, err := ParseResponseStyle("xml"); !errors.As(err, &validationErr) {
+		t.Errorf("Expected ValidationError, got %v", err)
+	}
+}
+
+func TestResponseStyle_Plain(t *testing.T) {
+	book := Book{ID: 1, Title: "Go"}
+	data, err := ResponseStylePlain.Render(nil, nil, book)
+	if err != nil || data != book {
+		t.Errorf("Expected the book as it is, got %v, %v", data, err)
+	}
+}
+
+func TestResponseStyle_JSONAPI(t *testing.T) {
+	originalURL := os.Getenv("BASE_URL")
+	os.Setenv("BASE_URL", "http://test.com")
+	defer os.Setenv("BASE_URL", originalURL)
+
+	page := PaginatedBooks{
+		Data: []Book{
+			{ID: 1, Title: "Go", Author: "Rob Pike", Category: "Programming"},
+			{ID: 2, Title: "Unix", Author: "Rob Pike", Category: "Unknown"},
+		},
+		Page:       2,
+		PageSize:   2,
+		TotalItems: 6,
+		TotalPages: 3,
+	}
+	doc := renderStyle(t, ResponseStyleJSONAPI, "/api/books?page=2&page_size=2", page)
+
+	data := doc["data"].([]any)
+	if len(data) != 2 {
+		t.Fatalf("Expected 2 resources, got %v", doc["data"])
+	}
+	first := data[0].(map[string]any)
+	if first["type"] != "books" || first["id"] != "1" || first["attributes"].(map[string]any)["title"] != "Go" {
+		t.Errorf("Unexpected resource %v", first)
+	}
+	author := first["relationships"].(map[string]any)["author"].(map[string]any)["data"].(map[string]any)
+	if author["type"] != "authors" || author["id"] != "7" {
+		t.Errorf("Expected author relationship 7, got %v", author)
+	}
+	if _, ok := data[1].(map[string]any)["relationships"].(map[string]any)["category"]; ok {
+		t.Error("Expected no category relationship for an unknown category")
+	}
+	if included := doc["included"].([]any); len(included) != 2 {
+		t.Errorf("Expected the author and category to be included once, got %v", included)
+	}
+
+	links := doc["links"].(map[string]any)
+	expected := map[string]string{
+		"self":  "http://test.com/api/books?page=2&page_size=2",
+		"first": "http://test.com/api/books?page=1&page_size=2",
+		"prev":  "http://test.com/api/books?page=1&page_size=2",
+		"next":  "http://test.com/api/books?page=3&page_size=2",
+		"last":  "http://test.com/api/books?page=3&page_size=2",
+	}
+	for rel, href := range expected {
+		if links[rel] != href {
+			t.Errorf("Expected %s link %s, got %v", rel, href, links[rel])
+		}
+	}
+	if meta := doc["meta"].(map[string]any); meta["total_items"] != float64(6) {
+		t.Errorf("Expected meta total_items 6, got %v", meta)
+	}
+
+	single := renderStyle(t, ResponseStyleJSONAPI, "/api/books", Book{ID: 1, Title: "Go"})
+	if single["data"].(map[string]any)["id"] != "1" || single["links"].(map[string]any)["self"] != "http://test.com/api/books/1" {
+		t.Errorf("Unexpected single resource document %v", single)
+	}
+
+	errs := renderStyle(t, ResponseStyleJSONAPI, "/api/books/9", APIError{StatusCode: 404, Message: "not found"})
+	apiErr := errs["errors"].([]any)[0].(map[string]any)
+	if apiErr["status"] != "404" || apiErr["title"] != "Not Found" || apiErr["detail"] != "not found" {
+		t.Errorf("Unexpected error object %v", apiErr)
+	}
+}
+
+func TestResponseStyle_HAL(t *testing.T) {
+	originalURL := os.Getenv("BASE_URL")
+	os.Setenv("BASE_URL", "http://test.com")
+	defer os.Setenv("BASE_URL", originalURL)
+
+	page := CursorBooks{
+		Data:       []Book{{ID: 1, Title: "Go", Author: "Rob Pike", Category: "Programming"}},
+		PageSize:   1,
+		NextCursor: "abc",
+	}
+	doc := renderStyle(t, ResponseStyleHAL, "/api/books?pagination=cursor&page_size=1", page)
+
+	links := doc["_links"].(map[string]any)
+	if next := links["next"].(map[string]any)["href"]; next != "http://test.com/api/books?cursor=abc&page_size=1&pagination=cursor" {
+		t.Errorf("Unexpected next link %v", next)
+	}
+	if _, ok := links["prev"]; ok {
+		t.Error("Expected no prev link on the first page")
+	}
+	if doc["page_size"] != float64(1) {
+		t.Errorf("Expected page_size 1, got %v", doc["page_size"])
+	}
+
+	books := doc["_embedded"].(map[string]any)["books"].([]any)
+	book := books[0].(map[string]any)
+	if book["title"] != "Go" || book["_links"].(map[string]any)["author"].(map[string]any)["href"] != "http://test.com/api/authors/7" {
+		t.Errorf("Unexpected embedded book %v", book)
+	}
+	if category := book["_embedded"].(map[string]any)["category"].(map[string]any); category["name"] != "Programming" {
+		t.Errorf("Expected embedded category, got %v", category)
+	}
+}
+
+func TestResponseStyle_LookupError(t *testing.T) {
+	service := NewService(&mockDataSource{
+		getAuthorsFunc: func() ([]Author, error) {
+			return nil, errors.New("database error")
+		},
+	})
+	if _, err := ResponseStyleHAL.Render(service, &url.URL{Path: "/api/books/1"}, Book{ID: 1}); err == nil {
+		t.Error("Expected the lookup error")
+	}
+}
@@ -0,0 +1,166 @@
+package mockapi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math/bits"
+)
+
+// webpMaxDimension is the largest width or height a VP8L image can have.
+const webpMaxDimension = 1 << 14
+
+// bitWriter packs values least significant bit first, as VP8L expects.
+type bitWriter struct {
+	buf   bytes.Buffer
+	acc   uint64
+	nbits uint
+}
+
+func (w *bitWriter) writeBits(value uint32, n uint) {
+	w.acc |= uint64(value) << w.nbits
+	w.nbits += n
+	for w.nbits >= 8 {
+		w.buf.WriteByte(byte(w.acc))
+		w.acc >>= 8
+		w.nbits -= 8
+	}
+}
+
+func (w *bitWriter) bytes() []byte {
+	if w.nbits > 0 {
+		w.buf.WriteByte(byte(w.acc))
+		w.acc, w.nbits = 0, 0
+	}
+	return w.buf.Bytes()
+}
+
+// writeConstantCode writes a simple prefix code with one symbol, which then
+// takes no bits per pixel.
+func (w *bitWriter) writeConstantCode(symbol uint8) {
+	w.writeBits(1, 1) // simple code
+	w.writeBits(0, 1) // one symbol
+	w.writeBits(1, 1) // 8 bit symbol
+	w.writeBits(uint32(symbol), 8)
+}
+
+// writeLiteralCode writes a normal prefix code giving the first 256 symbols
+// of an alphabet of size symbols a length of 8 and the rest a length of 0,
+// so literal v is coded as v itself.
+func (w *bitWriter) writeLiteralCode(size int) {
+	w.writeBits(0, 1) // normal code
+
+	// The code length code only uses lengths 0 and 8, one bit each. Its
+	// lengths are stored in the order 17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8,
+	// so 12 entries reach length 8.
+	w.writeBits(12-4, 4)
+	for _, length := range []uint32{0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 1} {
+		w.writeBits(length, 3)
+	}
+
+	w.writeBits(0, 1) // lengths for the whole alphabet follow
+	for symbol := range size {
+		if symbol < 256 {
+			w.writeBits(1, 1) // code for length 8
+		} else {
+			w.writeBits(0, 1) // code for length 0
+		}
+	}
+}
+
+// encodeWebP writes img as a lossless WebP. It uses no transforms or
+// backward references, so the output is about four bytes per pixel, which is
+// fine for thumbnails of a mock API.
+func encodeWebP(out io.Writer, img image.Image) error {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width < 1 || height < 1 || width > webpMaxDimension || height > webpMaxDimension {
+		return fmt.Errorf("webp: cannot encode a %dx%d image", width, height)
+	}
+
+	pixels := make([]color.NRGBA, 0, width*height)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			pixels = append(pixels, color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA))
+		}
+	}
+
+	// Channels holding a single value are coded with a constant code.
+	channels := [4]func(c color.NRGBA) uint8{
+		func(c color.NRGBA) uint8 { return c.G },
+		func(c color.NRGBA) uint8 { return c.R },
+		func(c color.NRGBA) uint8 { return c.B },
+		func(c color.NRGBA) uint8 { return c.A },
+	}
+	var constant [4]bool
+	for i, channel := range channels {
+		constant[i] = true
+		for _, c := range pixels {
+			if channel(c) != channel(pixels[0]) {
+				constant[i] = false
+				break
+			}
+		}
+	}
+	hasAlpha := !constant[3] || pixels[0].A != 0xff
+
+	w := &bitWriter{}
+	w.writeBits(0x2f, 8) // signature
+	w.writeBits(uint32(width-1), 14)
+	w.writeBits(uint32(height-1), 14)
+	if hasAlpha {
+		w.writeBits(1, 1)
+	} else {
+		w.writeBits(0, 1)
+	}
+	w.writeBits(0, 3) // version
+
+	w.writeBits(0, 1) // no transforms
+	w.writeBits(0, 1) // no color cache
+	w.writeBits(0, 1) // a single prefix code group
+
+	for i, channel := range channels {
+		switch {
+		case constant[i]:
+			w.writeConstantCode(channel(pixels[0]))
+		case i == 0:
+			w.writeLiteralCode(256 + 24) // green also codes backward reference lengths
+		default:
+			w.writeLiteralCode(256)
+		}
+	}
+	w.writeConstantCode(0) // distance code, unused
+
+	for _, c := range pixels {
+		for i, channel := range channels {
+			if !constant[i] {
+				// Prefix codes are read most significant bit first.
+				w.writeBits(uint32(bits.Reverse8(channel(c))), 8)
+			}
+		}
+	}
+
+	data := w.bytes()
+	chunkSize := len(data)
+	padding := chunkSize % 2
+
+	var header [20]byte
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(4+8+chunkSize+padding))
+	copy(header[8:], "WEBPVP8L")
+	binary.LittleEndian.PutUint32(header[16:], uint32(chunkSize))
+	if _, err := out.Write(header[:]); err != nil {
+		return err
+	}
+	if _, err := out.Write(data); err != nil {
+		return err
+	}
+	if padding == 1 {
+		_, err := out.Write([]byte{0})
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package mockapi
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"golang.org/x/image/webp"
+)
+
+func TestEncodeWebP(t *testing.T) {
+	opaque := image.NewNRGBA(image.Rect(0, 0, 7, 5))
+	for y := range 5 {
+		for x := range 7 {
+			opaque.Set(x, y, color.NRGBA{R: uint8(x * 30), G: uint8(y * 50), B: uint8(x*y + 1), A: 0xff})
+		}
+	}
+	translucent := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	for i := range translucent.Pix {
+		translucent.Pix[i] = uint8(i * 11)
+	}
+	solid := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	for i := range solid.Pix {
+		solid.Pix[i] = 0x80
+	}
+
+	for _, img := range []*image.NRGBA{opaque, translucent, solid} {
+		var buf bytes.Buffer
+		if err := encodeWebP(&buf, img); err != nil {
+			t.Fatalf("encodeWebP failed: %v", err)
+		}
+		decoded, err := webp.Decode(&buf)
+		if err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+		if decoded.Bounds() != img.Bounds() {
+			t.Fatalf("Expected bounds %v, got %v", img.Bounds(), decoded.Bounds())
+		}
+		for y := range img.Bounds().Dy() {
+			for x := range img.Bounds().Dx() {
+				got := color.NRGBAModel.Convert(decoded.At(x, y))
+				if got != img.NRGBAAt(x, y) {
+					t.Errorf("Expected pixel %d,%d to be %v, got %v", x, y, img.NRGBAAt(x, y), got)
+				}
+			}
+		}
+	}
+
+	if err := encodeWebP(&bytes.Buffer{}, image.NewNRGBA(image.Rect(0, 0, 0, 0))); err == nil {
+		t.Error("Expected error for an empty image")
+	}
+}